@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"fmt"
+
+	"github.com/agglayer/go_signer/signer/audit"
+	cli "github.com/urfave/cli/v2"
+)
+
+const FlagFile = "file"
+
+// VerifyCmd checks the hash chain of an audit log file
+func VerifyCmd(cliCtx *cli.Context) error {
+	path := cliCtx.String(FlagFile)
+	records, err := audit.VerifyFile(path)
+	if err != nil {
+		return fmt.Errorf("audit log %s is not valid (%d valid records). Err: %w", path, records, err)
+	}
+	fmt.Fprintf(cliCtx.App.Writer, "audit log %s is valid: %d records\n", path, records)
+	return nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/go_signer/signer"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+	cli "github.com/urfave/cli/v2"
+)
+
+const (
+	FlagFile = "file"
+	FlagKey  = "key"
+	// DefaultKey is the key of the signer config on the file if it doesn't have signer.WatchConfigKey
+	DefaultKey = "Signer"
+)
+
+var ErrInvalidConfig = errors.New("invalid signer config")
+
+// ValidateCmd validates the signers of a config file: the map signer.WatchConfigKey
+// (name -> SignerConfig) if it's present, otherwise the SignerConfig under --key
+func ValidateCmd(cliCtx *cli.Context) error {
+	path := cliCtx.String(FlagFile)
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("can't read %s. Err: %w", path, err)
+	}
+	cfgs := map[string]signertypes.SignerConfig{}
+	decodeHook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.TextUnmarshallerHookFunc(), mapstructure.StringToSliceHookFunc(",")))
+	if v.IsSet(signer.WatchConfigKey) && !cliCtx.IsSet(FlagKey) {
+		if err := v.UnmarshalKey(signer.WatchConfigKey, &cfgs, decodeHook); err != nil {
+			return fmt.Errorf("can't decode %s of %s. Err: %w", signer.WatchConfigKey, path, err)
+		}
+	} else {
+		key := cliCtx.String(FlagKey)
+		if !v.IsSet(key) {
+			return fmt.Errorf("key %s not found on %s. Err: %w", key, path, signertypes.ErrMissingConfigParam)
+		}
+		var cfg signertypes.SignerConfig
+		if err := v.UnmarshalKey(key, &cfg, decodeHook); err != nil {
+			return fmt.Errorf("can't decode %s of %s. Err: %w", key, path, err)
+		}
+		cfgs[key] = cfg
+	}
+	invalid := 0
+	for name, cfg := range cfgs {
+		if err := cfg.Validate(); err != nil {
+			invalid++
+			fmt.Fprintf(cliCtx.App.Writer, "%s: INVALID\n%v\n", name, err)
+			continue
+		}
+		fmt.Fprintf(cliCtx.App.Writer, "%s: OK\n", name)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d signers. Err: %w", invalid, len(cfgs), ErrInvalidConfig)
+	}
+	return nil
+}
+
+// SchemaCmd prints the JSON Schema of SignerConfig
+func SchemaCmd(cliCtx *cli.Context) error {
+	schema, err := signer.JSONSchema()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cliCtx.App.Writer, string(schema))
+	return nil
+}
@@ -0,0 +1,135 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/agglayer/go_signer/cmd/password"
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/shamir"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	cli "github.com/urfave/cli/v2"
+)
+
+const (
+	FlagKeystore  = "keystore"
+	FlagThreshold = "threshold"
+	FlagParties   = "parties"
+	FlagOut       = "out"
+	FlagShare     = "share"
+	// FlagPasswordFile is the file with the password of the keystore (the new one for combine)
+	FlagPasswordFile = "password-file"
+	// FlagSharePasswordFile is the file with the password of a share, once per share
+	FlagSharePasswordFile = "share-password-file"
+
+	// EnvPassword is the env var with the password of the keystore, if there is no FlagPasswordFile
+	EnvPassword = "GO_SIGNER_PASSWORD"
+	// EnvSharePassword is the prefix of the env vars with the password of each share, if there are
+	// no FlagSharePasswordFile: GO_SIGNER_SHARE_PASSWORD_1 is the password of the first share
+	EnvSharePassword = "GO_SIGNER_SHARE_PASSWORD"
+)
+
+var ErrSharePasswordFiles = errors.New("share-password-file must be set once per share")
+
+// SplitCmd splits a keystore in Shamir share files (share-<index>.json), threshold of them recover it
+func SplitCmd(cliCtx *cli.Context) error {
+	parties := cliCtx.Int(FlagParties)
+	keystorePassword, err := password.Read(password.Source{
+		File: cliCtx.String(FlagPasswordFile), Env: EnvPassword, Prompt: "Password of the keystore",
+	})
+	if err != nil {
+		return err
+	}
+	passwords, err := sharePasswords(cliCtx, parties, true)
+	if err != nil {
+		return err
+	}
+	key, err := signercommon.NewKeyFromKeystore(signercommon.KeystoreFileConfig{
+		Path:     cliCtx.String(FlagKeystore),
+		Password: keystorePassword,
+	})
+	if err != nil {
+		return fmt.Errorf("can't read keystore. Err: %w", err)
+	}
+	shares, err := shamir.SplitKey(key, cliCtx.Int(FlagThreshold), parties)
+	if err != nil {
+		return err
+	}
+	out := cliCtx.String(FlagOut)
+	for i, share := range shares {
+		path := filepath.Join(out, fmt.Sprintf("share-%d.json", share.Index))
+		if err := shamir.WriteKeystoreShareFile(path, share, passwords[i]); err != nil {
+			return fmt.Errorf("can't write %s. Err: %w", path, err)
+		}
+		fmt.Fprintf(cliCtx.App.Writer, "share %d: %s\n", share.Index, path)
+	}
+	fmt.Fprintf(cliCtx.App.Writer, "address %s: %d of %d shares\n", shares[0].Address.Hex(),
+		shares[0].Threshold, parties)
+	return nil
+}
+
+// CombineCmd recovers a key from its share files and stores it as a keystore on the out directory
+func CombineCmd(cliCtx *cli.Context) error {
+	paths := cliCtx.StringSlice(FlagShare)
+	passwords, err := sharePasswords(cliCtx, len(paths), false)
+	if err != nil {
+		return err
+	}
+	keystorePassword, err := password.ReadNew(password.Source{
+		File: cliCtx.String(FlagPasswordFile), Env: EnvPassword, Prompt: "Password of the new keystore",
+	})
+	if err != nil {
+		return err
+	}
+	shares := make([]shamir.KeystoreShare, len(paths))
+	for i, path := range paths {
+		if shares[i], err = shamir.ReadKeystoreShareFile(path, passwords[i]); err != nil {
+			return err
+		}
+	}
+	key, err := shamir.CombineKey(shares)
+	if err != nil {
+		return err
+	}
+	ks := keystore.NewKeyStore(cliCtx.String(FlagOut), keystore.StandardScryptN, keystore.StandardScryptP)
+	account, err := ks.ImportECDSA(key, keystorePassword)
+	if err != nil {
+		return fmt.Errorf("can't write keystore. Err: %w", err)
+	}
+	fmt.Fprintf(cliCtx.App.Writer, "address %s: %s\n", account.Address.Hex(), account.URL.Path)
+	return nil
+}
+
+// sharePasswords returns the password of each of the n shares. The new ones (split) can't be
+// empty and each share must have its own password
+func sharePasswords(cliCtx *cli.Context, n int, isNew bool) ([]string, error) {
+	files := cliCtx.StringSlice(FlagSharePasswordFile)
+	if len(files) != 0 && len(files) != n {
+		return nil, fmt.Errorf("%d password files for %d shares. Err: %w", len(files), n, ErrSharePasswordFiles)
+	}
+	res := make([]string, n)
+	for i := range res {
+		src := password.Source{
+			Env:    fmt.Sprintf("%s_%d", EnvSharePassword, i+1),
+			Prompt: fmt.Sprintf("Password of share %d", i+1),
+		}
+		if len(files) != 0 {
+			src.File = files[i]
+		}
+		read := password.Read
+		if isNew {
+			read = password.ReadNew
+		}
+		var err error
+		if res[i], err = read(src); err != nil {
+			return nil, err
+		}
+	}
+	if isNew {
+		if err := password.CheckDistinct(res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
@@ -5,6 +5,10 @@ import (
 	"os"
 
 	gosigner "github.com/agglayer/go_signer"
+	"github.com/agglayer/go_signer/cmd/audit"
+	"github.com/agglayer/go_signer/cmd/config"
+	"github.com/agglayer/go_signer/cmd/keystore"
+	"github.com/agglayer/go_signer/cmd/tss"
 	"github.com/agglayer/go_signer/cmd/version"
 	cli "github.com/urfave/cli/v2"
 )
@@ -23,6 +27,274 @@ func main() {
 			Usage:   "Application version and build",
 			Action:  version.VersionCmd,
 		},
+		{
+			Name:  "audit",
+			Usage: "Audit log tools",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "verify",
+					Usage:  "Check the hash chain of an audit log file",
+					Action: audit.VerifyCmd,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     audit.FlagFile,
+							Aliases:  []string{"f"},
+							Usage:    "Path of the audit log file",
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:  "config",
+			Usage: "Signer configuration tools",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "validate",
+					Usage:  "Validate the signer configs of a file",
+					Action: config.ValidateCmd,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     config.FlagFile,
+							Aliases:  []string{"f"},
+							Usage:    "Path of the config file",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:  config.FlagKey,
+							Usage: "Key of the SignerConfig on the file (if it has no Signers map)",
+							Value: config.DefaultKey,
+						},
+					},
+				},
+				{
+					Name:   "schema",
+					Usage:  "Print the JSON Schema of the signer config",
+					Action: config.SchemaCmd,
+				},
+			},
+		},
+		{
+			Name:  "keystore",
+			Usage: "Keystore tools",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "split",
+					Usage:  "Split a keystore in Shamir share files, threshold of them recover the key",
+					Action: keystore.SplitCmd,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     keystore.FlagKeystore,
+							Usage:    "Keystore file to split",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:  keystore.FlagPasswordFile,
+							Usage: "File with the password of the keystore, else env " + keystore.EnvPassword + " or prompt",
+						},
+						&cli.IntFlag{
+							Name:     keystore.FlagThreshold,
+							Usage:    "Number of shares needed to recover the key",
+							Required: true,
+						},
+						&cli.IntFlag{
+							Name:     keystore.FlagParties,
+							Usage:    "Number of shares",
+							Required: true,
+						},
+						&cli.StringSliceFlag{
+							Name: keystore.FlagSharePasswordFile,
+							Usage: "File with the password of a share, once per share (each one different), else env " +
+								keystore.EnvSharePassword + "_<n> or prompt",
+						},
+						&cli.StringFlag{
+							Name:  keystore.FlagOut,
+							Usage: "Directory of the share files (share-<index>.json)",
+							Value: ".",
+						},
+					},
+				},
+				{
+					Name:   "combine",
+					Usage:  "Recover a key from its Shamir share files and store it as a keystore",
+					Action: keystore.CombineCmd,
+					Flags: []cli.Flag{
+						&cli.StringSliceFlag{
+							Name:     keystore.FlagShare,
+							Usage:    "Share file, at least the threshold",
+							Required: true,
+						},
+						&cli.StringSliceFlag{
+							Name: keystore.FlagSharePasswordFile,
+							Usage: "File with the password of a share, once per share in the same order, else env " +
+								keystore.EnvSharePassword + "_<n> or prompt",
+						},
+						&cli.StringFlag{
+							Name: keystore.FlagPasswordFile,
+							Usage: "File with the password of the new keystore (required, not empty), else env " +
+								keystore.EnvPassword + " or prompt",
+						},
+						&cli.StringFlag{
+							Name:  keystore.FlagOut,
+							Usage: "Keystore directory where the key is stored",
+							Value: ".",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:  "tss",
+			Usage: "Threshold signing (key split between several parties) tools",
+			Subcommands: []*cli.Command{
+				{
+					Name:  "keygen",
+					Usage: "Create a new key with the other parties (distributed key generation), writes the share of this party",
+					Description: "All the parties run it at the same time with the same session, threshold and parties. " +
+						"No machine has the whole key",
+					Action: tss.KeyGenCmd,
+					Flags: []cli.Flag{
+						&cli.IntFlag{
+							Name:     tss.FlagIndex,
+							Usage:    "Index of the party (1..parties)",
+							Required: true,
+						},
+						&cli.IntFlag{
+							Name:     tss.FlagThreshold,
+							Usage:    "Number of parties needed to sign",
+							Required: true,
+						},
+						&cli.IntFlag{
+							Name:     tss.FlagParties,
+							Usage:    "Number of parties",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagSession,
+							Usage:    "Id of the key generation, the same on all the parties and new for each key",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagSharePassword,
+							Usage:    "Password of the share file",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:  tss.FlagOut,
+							Usage: "Directory of the share file (share-<index>.json)",
+							Value: ".",
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagListen,
+							Usage:    "Address where the party listens (host:port)",
+							Required: true,
+						},
+						&cli.StringSliceFlag{
+							Name:  tss.FlagPeer,
+							Usage: "Address of another party as index=host:port",
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagTLSCACert,
+							Usage:    "CA that signs the certificates of all the parties (PEM file)",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagTLSCert,
+							Usage:    "Certificate of the party (PEM file) with the DNS name party-<index>",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagTLSKey,
+							Usage:    "Private key of the certificate (PEM file)",
+							Required: true,
+						},
+					},
+				},
+				{
+					Name:  "deal",
+					Usage: "Split an existing keystore in key shares, one file per party (import of a key)",
+					Description: "Trusted dealer: this machine has the whole key, it's a single point of " +
+						"compromise. Only to import an existing key, run it on an offline machine. Use keygen for a new key",
+					Action: tss.DealCmd,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     tss.FlagKeystore,
+							Usage:    "Keystore file of the key to split",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:  tss.FlagKeystorePassword,
+							Usage: "Password of the keystore",
+						},
+						&cli.IntFlag{
+							Name:     tss.FlagThreshold,
+							Usage:    "Number of parties needed to sign",
+							Required: true,
+						},
+						&cli.IntFlag{
+							Name:     tss.FlagParties,
+							Usage:    "Number of parties (share files)",
+							Required: true,
+						},
+						&cli.StringSliceFlag{
+							Name:     tss.FlagSharePassword,
+							Usage:    "Password of the share files, once for all or once per party",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:  tss.FlagOut,
+							Usage: "Directory of the share files (share-<index>.json)",
+							Value: ".",
+						},
+					},
+				},
+				{
+					Name:   "cosign",
+					Usage:  "Run a party that joins the signing sessions of the other parties",
+					Action: tss.CosignCmd,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:     tss.FlagShare,
+							Usage:    "Key share file of the party",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:  tss.FlagPassword,
+							Usage: "Password of the key share file",
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagListen,
+							Usage:    "Address where the party listens (host:port)",
+							Required: true,
+						},
+						&cli.StringSliceFlag{
+							Name:  tss.FlagPeer,
+							Usage: "Address of another party as index=host:port",
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagTLSCACert,
+							Usage:    "CA that signs the certificates of all the parties (PEM file)",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagTLSCert,
+							Usage:    "Certificate of the party (PEM file) with the DNS name party-<index>",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     tss.FlagTLSKey,
+							Usage:    "Private key of the certificate (PEM file)",
+							Required: true,
+						},
+						&cli.BoolFlag{
+							Name:  tss.FlagApproveAll,
+							Usage: "Join all the signing sessions of the other parties (required, there is no other policy)",
+						},
+					},
+				},
+			},
+		},
 	}
 	err := app.Run(os.Args)
 	if err != nil {
@@ -0,0 +1,99 @@
+// Package password reads the passwords of the CLI commands without taking them from the
+// command line (that is visible on the process list and the shell history)
+package password
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var (
+	ErrNoPassword         = errors.New("no password: use a password file, the env var or a terminal")
+	ErrEmptyPassword      = errors.New("password can't be empty")
+	ErrPasswordMismatch   = errors.New("passwords don't match")
+	ErrDuplicatedPassword = errors.New("each share needs its own password")
+)
+
+// Source is where a password is read from, in order: File, the environment variable Env
+// and, if stdin is a terminal, a prompt
+type Source struct {
+	// File is the path of a file that contains the password
+	File string
+	// Env is the environment variable with the password
+	Env string
+	// Prompt is shown on the terminal to ask for the password
+	Prompt string
+}
+
+// Read returns the password of src. An empty password is only accepted from a file
+func Read(src Source) (string, error) {
+	if src.File != "" {
+		return readFile(src.File)
+	}
+	if password := os.Getenv(src.Env); src.Env != "" && password != "" {
+		return password, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("%s. Err: %w", src.Prompt, ErrNoPassword)
+	}
+	return prompt(src.Prompt)
+}
+
+// ReadNew returns a new password of src (e.g. to encrypt a file): it can't be empty and
+// the prompt asks for it twice
+func ReadNew(src Source) (string, error) {
+	password, err := Read(src)
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", fmt.Errorf("%s. Err: %w", src.Prompt, ErrEmptyPassword)
+	}
+	if src.File != "" || (src.Env != "" && os.Getenv(src.Env) != "") {
+		return password, nil
+	}
+	repeated, err := prompt(src.Prompt + " (repeat)")
+	if err != nil {
+		return "", err
+	}
+	if repeated != password {
+		return "", fmt.Errorf("%s. Err: %w", src.Prompt, ErrPasswordMismatch)
+	}
+	return password, nil
+}
+
+// CheckDistinct returns ErrDuplicatedPassword if two passwords are equal
+func CheckDistinct(passwords []string) error {
+	seen := make(map[string]int, len(passwords))
+	for i, password := range passwords {
+		if j, ok := seen[password]; ok {
+			return fmt.Errorf("shares %d and %d. Err: %w", j+1, i+1, ErrDuplicatedPassword)
+		}
+		seen[password] = i
+	}
+	return nil
+}
+
+func prompt(text string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", text)
+	password, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("can't read password. Err: %w", err)
+	}
+	return string(password), nil
+}
+
+// readFile returns the content of a password file without the trailing newline
+func readFile(path string) (string, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("can't read password file %s. Err: %w", path, err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
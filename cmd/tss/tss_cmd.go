@@ -0,0 +1,183 @@
+package tss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/log"
+	"github.com/agglayer/go_signer/signer/tss"
+	cli "github.com/urfave/cli/v2"
+)
+
+const (
+	FlagKeystore         = "keystore"
+	FlagKeystorePassword = "keystore-password"
+	FlagThreshold        = "threshold"
+	FlagParties          = "parties"
+	FlagIndex            = "index"
+	// FlagSession is the id of a key generation, the same on all the parties
+	FlagSession = "session"
+	FlagOut     = "out"
+	// FlagSharePassword is the password of the shares, once for all or once per party
+	FlagSharePassword = "share-password"
+	FlagShare         = "share"
+	FlagPassword      = "password"
+	FlagListen        = "listen"
+	// FlagPeer is the address of another party as index=host:port
+	FlagPeer      = "peer"
+	FlagTLSCACert = "tls-ca-cert"
+	FlagTLSCert   = "tls-cert"
+	FlagTLSKey    = "tls-key"
+	// FlagApproveAll makes cosign join all the sessions, it has no other approval policy
+	FlagApproveAll = "approve-all"
+)
+
+var (
+	ErrSharePasswords = errors.New("share-password must be set once or once per party")
+	// ErrNoApprovalPolicy is returned by cosign without approve-all, it would reject all the sessions
+	ErrNoApprovalPolicy = errors.New("cosign has no approval policy, set approve-all to join all the sessions")
+)
+
+const (
+	// dealWarning is shown by deal, the key to split is in the memory of this machine
+	dealWarning = "WARNING: this machine has the whole key while it deals the shares, it's a single point of " +
+		"compromise. Use an offline machine and erase the keystore afterwards, or create a new key with keygen\n"
+	// keyGenTimeout is the max time waiting for all the parties to run keygen
+	keyGenTimeout = 10 * time.Minute
+)
+
+// DealCmd splits an existing keystore in key shares, one file per party. It's only to import a
+// key: the key is in the memory of this process, KeyGenCmd creates a new one without a dealer
+func DealCmd(cliCtx *cli.Context) error {
+	fmt.Fprint(cliCtx.App.ErrWriter, dealWarning)
+	parties := cliCtx.Int(FlagParties)
+	passwords := cliCtx.StringSlice(FlagSharePassword)
+	if len(passwords) != 1 && len(passwords) != parties {
+		return fmt.Errorf("%d passwords for %d parties. Err: %w", len(passwords), parties, ErrSharePasswords)
+	}
+	key, err := signercommon.NewKeyFromKeystore(signercommon.KeystoreFileConfig{
+		Path:     cliCtx.String(FlagKeystore),
+		Password: cliCtx.String(FlagKeystorePassword),
+	})
+	if err != nil {
+		return fmt.Errorf("can't get the key. Err: %w", err)
+	}
+	defer signercommon.ZeroKey(key)
+	shares, err := tss.Deal(key, cliCtx.Int(FlagThreshold), parties)
+	if err != nil {
+		return err
+	}
+	out := cliCtx.String(FlagOut)
+	for i, share := range shares {
+		password := passwords[0]
+		if len(passwords) == parties {
+			password = passwords[i]
+		}
+		path := filepath.Join(out, fmt.Sprintf("share-%d.json", share.Index))
+		if err := tss.WriteKeyShareFile(path, share, password); err != nil {
+			return fmt.Errorf("can't write %s. Err: %w", path, err)
+		}
+		fmt.Fprintf(cliCtx.App.Writer, "party %d: %s\n", share.Index, path)
+	}
+	fmt.Fprintf(cliCtx.App.Writer, "address %s: %d of %d parties\n", shares[0].Address().Hex(),
+		shares[0].Threshold, parties)
+	return nil
+}
+
+// KeyGenCmd runs the distributed key generation of a party with the other ones and writes its key
+// share file. All the parties run it at the same time with the same session, threshold and parties
+func KeyGenCmd(cliCtx *cli.Context) error {
+	index := cliCtx.Int(FlagIndex)
+	transport, err := listen(cliCtx, index)
+	if err != nil {
+		return err
+	}
+	defer transport.Close()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, keyGenTimeout)
+	defer cancel()
+	logger := log.WithFields("party", index)
+	logger.Infof("party %d waiting for the other parties on %s", index, transport.Addr())
+	share, err := tss.KeyGen(ctx, index, cliCtx.Int(FlagThreshold), cliCtx.Int(FlagParties),
+		cliCtx.String(FlagSession), transport, logger)
+	if err != nil {
+		return err
+	}
+	defer signercommon.ZeroInt(share.Share)
+	path := filepath.Join(cliCtx.String(FlagOut), fmt.Sprintf("share-%d.json", share.Index))
+	if err := tss.WriteKeyShareFile(path, share, cliCtx.String(FlagSharePassword)); err != nil {
+		return fmt.Errorf("can't write %s. Err: %w", path, err)
+	}
+	fmt.Fprintf(cliCtx.App.Writer, "party %d: %s\n", share.Index, path)
+	fmt.Fprintf(cliCtx.App.Writer, "address %s: %d of %d parties\n", share.Address().Hex(), share.Threshold,
+		share.Parties())
+	return nil
+}
+
+// CosignCmd runs a party that joins the signing sessions of the other parties until it's interrupted
+func CosignCmd(cliCtx *cli.Context) error {
+	if !cliCtx.Bool(FlagApproveAll) {
+		return ErrNoApprovalPolicy
+	}
+	share, err := tss.ReadKeyShareFile(cliCtx.String(FlagShare), cliCtx.String(FlagPassword))
+	if err != nil {
+		return err
+	}
+	transport, err := listen(cliCtx, share.Index)
+	if err != nil {
+		return err
+	}
+	defer transport.Close()
+	logger := log.WithFields("party", share.Index)
+	party, err := tss.NewParty(share, transport, logger, tss.WithApproval(tss.ApproveAll))
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	logger.Infof("party %d (%d of %d) of %s listening on %s", share.Index, share.Threshold, share.Parties(),
+		share.Address().Hex(), transport.Addr())
+	return party.Run(ctx)
+}
+
+// listen creates the TCP transport of party index with the TLS, listen and peer flags
+func listen(cliCtx *cli.Context, index int) (*tss.TCPTransport, error) {
+	tlsConfig, err := tss.NewTLSConfig(index, cliCtx.String(FlagTLSCACert), cliCtx.String(FlagTLSCert),
+		cliCtx.String(FlagTLSKey))
+	if err != nil {
+		return nil, err
+	}
+	transport, err := tss.ListenTCP(index, cliCtx.String(FlagListen), tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	for _, peer := range cliCtx.StringSlice(FlagPeer) {
+		peerIndex, addr, err := parsePeer(peer)
+		if err != nil {
+			_ = transport.Close()
+			return nil, err
+		}
+		transport.AddPeer(peerIndex, addr)
+	}
+	return transport, nil
+}
+
+// parsePeer parses index=host:port
+func parsePeer(peer string) (int, string, error) {
+	indexStr, addr, ok := strings.Cut(peer, "=")
+	index, err := strconv.Atoi(indexStr)
+	if !ok || err != nil || index < 1 || addr == "" {
+		return 0, "", fmt.Errorf("peer %q is not index=host:port", peer)
+	}
+	return index, addr, nil
+}
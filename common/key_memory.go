@@ -0,0 +1,55 @@
+package common
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"unsafe"
+)
+
+// ErrMemoryLockNotSupported is returned by LockKey on the systems without mlock support
+var ErrMemoryLockNotSupported = errors.New("memory lock is only supported on linux")
+
+// ZeroKey overwrites the private scalar of key with zeros. The public key is kept.
+// The copies made by the callers of the key (e.g. its bytes) are not wiped
+func ZeroKey(key *ecdsa.PrivateKey) {
+	if key == nil {
+		return
+	}
+	ZeroInt(key.D)
+}
+
+// ZeroInt overwrites the words of x (e.g. a secret share) with zeros and sets it to 0
+func ZeroInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+	words := x.Bits()
+	clear(words[:cap(words)])
+	x.SetInt64(0)
+}
+
+// LockKey locks in RAM the memory pages of the private scalar of key (mlock), so it's
+// never written to swap. It must be unlocked with UnlockKey before wiping it
+func LockKey(key *ecdsa.PrivateKey) error {
+	return mlock(keyMemory(key))
+}
+
+// UnlockKey unlocks the memory pages locked by LockKey. The pages are not reference counted,
+// so other data locked on the same pages is also unlocked
+func UnlockKey(key *ecdsa.PrivateKey) error {
+	return munlock(keyMemory(key))
+}
+
+// keyMemory returns the memory of the words of the private scalar of key (nil if there is no key)
+func keyMemory(key *ecdsa.PrivateKey) []byte {
+	if key == nil || key.D == nil {
+		return nil
+	}
+	words := key.D.Bits()
+	if cap(words) == 0 {
+		return nil
+	}
+	words = words[:cap(words)]
+	return unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), len(words)*int(unsafe.Sizeof(big.Word(0))))
+}
@@ -0,0 +1,26 @@
+package common
+
+import (
+	"fmt"
+	"syscall"
+)
+
+func mlock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	if err := syscall.Mlock(b); err != nil {
+		return fmt.Errorf("can't lock key memory (check RLIMIT_MEMLOCK). Err: %w", err)
+	}
+	return nil
+}
+
+func munlock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	if err := syscall.Munlock(b); err != nil {
+		return fmt.Errorf("can't unlock key memory. Err: %w", err)
+	}
+	return nil
+}
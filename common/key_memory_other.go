@@ -0,0 +1,11 @@
+//go:build !linux
+
+package common
+
+func mlock(b []byte) error {
+	return ErrMemoryLockNotSupported
+}
+
+func munlock(b []byte) error {
+	return ErrMemoryLockNotSupported
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -15,6 +16,28 @@ type KeystoreFileConfig struct {
 
 	// Password is the password to decrypt the key store file
 	Password string `mapstructure:"Password"`
+
+	// Address selects the account if Path is a keystore directory
+	Address string `mapstructure:"Address"`
+
+	// Passwords are the per-account passwords (address -> password) if Path is a keystore directory.
+	// The accounts that are not here use Password
+	Passwords map[string]string `mapstructure:"Passwords"`
+}
+
+// String keeps the format of the fields Path and Password, the passwords are redacted
+func (c KeystoreFileConfig) String() string {
+	if c.Address == "" {
+		return fmt.Sprintf("{%s ***}", c.Path)
+	}
+	return fmt.Sprintf("{%s *** %s}", c.Path, c.Address)
+}
+
+// WipePasswords drops the passwords once the key has been decrypted. Go strings can't be
+// overwritten, so the memory is freed by the garbage collector if there are no other copies
+func (c *KeystoreFileConfig) WipePasswords() {
+	c.Password = ""
+	c.Passwords = nil
 }
 
 // NewKeyFromKeystore creates a private key from a keystore file
@@ -0,0 +1,294 @@
+package common
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fsnotify/fsnotify"
+)
+
+var (
+	ErrAccountNotFound = errors.New("account not found on keystore directory")
+	ErrAccountLocked   = errors.New("account is locked (no password for it)")
+)
+
+// keystoreDirDebounce groups the events of a file being written before rescanning the directory
+const keystoreDirDebounce = 100 * time.Millisecond
+
+// KeystoreDir is a directory of keystore files (as geth's keystore.KeyStore). All the accounts
+// with a known password are unlocked. The directory is watched, so added accounts are unlocked
+// and the removed ones are forgotten
+type KeystoreDir struct {
+	mu        sync.RWMutex
+	path      string
+	logger    Logger
+	password  string
+	passwords map[common.Address]string
+	// files are the keystore files on the directory by account
+	files map[common.Address]string
+	keys  map[common.Address]*ecdsa.PrivateKey
+	// removed are the keys of the accounts removed from the directory, wiped on Close
+	removed []*ecdsa.PrivateKey
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// IsKeystoreDir returns true if path is a directory
+func IsKeystoreDir(path string) bool {
+	info, err := os.Stat(filepath.Clean(path))
+	return err == nil && info.IsDir()
+}
+
+// NewKeystoreDir opens the keystore directory cfg.Path and unlocks the accounts that have password:
+// the one of cfg.Passwords or, if it's not there, cfg.Password. The accounts that can't be
+// decrypted stay locked
+func NewKeystoreDir(cfg KeystoreFileConfig, logger Logger) (*KeystoreDir, error) {
+	if !IsKeystoreDir(cfg.Path) {
+		return nil, fmt.Errorf("keystore: %s is not a directory", cfg.Path)
+	}
+	res := &KeystoreDir{
+		path:      filepath.Clean(cfg.Path),
+		logger:    logger,
+		password:  cfg.Password,
+		passwords: make(map[common.Address]string, len(cfg.Passwords)),
+		files:     map[common.Address]string{},
+		keys:      map[common.Address]*ecdsa.PrivateKey{},
+		done:      make(chan struct{}),
+	}
+	for addr, password := range cfg.Passwords {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("keystore: %s on passwords is not an address", addr)
+		}
+		res.passwords[common.HexToAddress(addr)] = password
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("keystore %s: can't watch the directory. Err: %w", res.path, err)
+	}
+	if err := watcher.Add(res.path); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("keystore %s: can't watch the directory. Err: %w", res.path, err)
+	}
+	res.watcher = watcher
+	files, err := res.scan()
+	if err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	for addr, file := range files {
+		res.files[addr] = file
+		if err := res.unlock(addr, file); err != nil {
+			// The account stays locked, Key returns ErrAccountLocked for it
+			logger.Warnf("%v", err)
+		}
+	}
+	go res.watch(watcher)
+	return res, nil
+}
+
+// Accounts returns the addresses of the keystore files on the directory (none once it's closed)
+func (d *KeystoreDir) Accounts() []common.Address {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	res := make([]common.Address, 0, len(d.files))
+	for addr := range d.files {
+		res = append(res, addr)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Cmp(res[j]) < 0 })
+	return res
+}
+
+// Unlocked returns the addresses of the unlocked accounts
+func (d *KeystoreDir) Unlocked() []common.Address {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	res := make([]common.Address, 0, len(d.keys))
+	for addr := range d.keys {
+		res = append(res, addr)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Cmp(res[j]) < 0 })
+	return res
+}
+
+// Key returns the private key of an unlocked account
+func (d *KeystoreDir) Key(addr common.Address) (*ecdsa.PrivateKey, error) {
+	d.mu.RLock()
+	key, ok := d.keys[addr]
+	_, found := d.files[addr]
+	d.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if found {
+		return nil, fmt.Errorf("keystore %s: account %s. Err: %w", d.path, addr.Hex(), ErrAccountLocked)
+	}
+	return nil, fmt.Errorf("keystore %s: account %s. Err: %w", d.path, addr.Hex(), ErrAccountNotFound)
+}
+
+// Has returns true if the account is unlocked
+func (d *KeystoreDir) Has(addr common.Address) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.keys[addr]
+	return ok
+}
+
+// Close stops watching the directory, wipes the unlocked keys (ZeroKey) and drops the passwords.
+// The keys returned by Key can't be used after it
+func (d *KeystoreDir) Close() {
+	d.mu.Lock()
+	watcher := d.watcher
+	d.watcher = nil
+	d.mu.Unlock()
+	if watcher == nil {
+		return
+	}
+	if err := watcher.Close(); err != nil {
+		d.logger.Warnf("keystore %s: error closing the directory watcher. Err: %v", d.path, err)
+	}
+	<-d.done
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for addr, key := range d.keys {
+		ZeroKey(key)
+		delete(d.keys, addr)
+	}
+	for _, key := range d.removed {
+		ZeroKey(key)
+	}
+	d.removed = nil
+	clear(d.files)
+	d.password = ""
+	clear(d.passwords)
+}
+
+func (d *KeystoreDir) watch(watcher *fsnotify.Watcher) {
+	defer close(d.done)
+	debounce := time.NewTimer(keystoreDirDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+	for {
+		select {
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			debounce.Reset(keystoreDirDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			d.logger.Errorf("keystore %s: error watching the directory. Err: %v", d.path, err)
+		case <-debounce.C:
+			d.rescan()
+		}
+	}
+}
+
+// rescan unlocks the accounts added to the directory and forgets the removed ones
+func (d *KeystoreDir) rescan() {
+	files, err := d.scan()
+	if err != nil {
+		d.logger.Errorf("%v", err)
+		return
+	}
+	d.mu.Lock()
+	previous := d.files
+	d.files = files
+	d.mu.Unlock()
+	for addr := range previous {
+		if _, ok := files[addr]; ok {
+			continue
+		}
+		// The key is not wiped here, a signer can be using it. Close wipes it
+		d.mu.Lock()
+		if key, ok := d.keys[addr]; ok {
+			d.removed = append(d.removed, key)
+			delete(d.keys, addr)
+		}
+		d.mu.Unlock()
+		d.logger.Warnf("keystore %s: account %s removed", d.path, addr.Hex())
+	}
+	for addr, file := range files {
+		if _, ok := previous[addr]; ok {
+			continue
+		}
+		if err := d.unlock(addr, file); err != nil {
+			d.logger.Errorf("keystore %s: can't unlock added account %s. Err: %v", d.path, addr.Hex(), err)
+			continue
+		}
+		d.logger.Infof("keystore %s: account %s added", d.path, addr.Hex())
+	}
+}
+
+// scan returns the keystore files of the directory by account. As geth, hidden and backup (~)
+// files are skipped, and if several files have the same account the first one (by name) is used
+func (d *KeystoreDir) scan() (map[common.Address]string, error) {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return nil, fmt.Errorf("keystore %s: can't read the directory. Err: %w", d.path, err)
+	}
+	res := map[common.Address]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+			continue
+		}
+		file := filepath.Join(d.path, name)
+		addr, ok := keystoreFileAddress(file)
+		if !ok {
+			continue
+		}
+		if _, dup := res[addr]; !dup {
+			res[addr] = file
+		}
+	}
+	return res, nil
+}
+
+// keystoreFileAddress returns the account of a keystore file, false if it isn't one
+func keystoreFileAddress(file string) (common.Address, bool) {
+	content, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return common.Address{}, false
+	}
+	var key struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(content, &key); err != nil || !common.IsHexAddress(key.Address) {
+		return common.Address{}, false
+	}
+	addr := common.HexToAddress(key.Address)
+	return addr, addr != common.Address{}
+}
+
+// unlock decrypts the keystore file of an account if there is a password for it
+func (d *KeystoreDir) unlock(addr common.Address, file string) error {
+	d.mu.RLock()
+	password, ok := d.passwords[addr]
+	if !ok {
+		password = d.password
+	}
+	d.mu.RUnlock()
+	if password == "" && !ok {
+		return nil
+	}
+	key, err := NewKeyFromKeystore(KeystoreFileConfig{Path: file, Password: password})
+	if err != nil {
+		return fmt.Errorf("keystore %s: can't decrypt account %s. Err: %w", d.path, addr.Hex(), err)
+	}
+	d.mu.Lock()
+	d.keys[addr] = key
+	d.mu.Unlock()
+	return nil
+}
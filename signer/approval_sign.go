@@ -0,0 +1,334 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/batch"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// approvalDomain separates the approvals from any other signature of the approver keys
+const approvalDomain = "go_signer approval"
+
+var (
+	// ErrApprovalBadThreshold is returned by NewApprovalSign if the threshold is not possible
+	ErrApprovalBadThreshold = errors.New("approval signer: threshold must be between 1 and the number of approvers")
+	// ErrApprovalDuplicatedApprover is returned if two approvers have the same address
+	ErrApprovalDuplicatedApprover = errors.New("approval signer: duplicated approver")
+	// ErrApprovalNotReached is returned if less than threshold approvers have approved the request
+	ErrApprovalNotReached = errors.New("approval signer: approval threshold not reached")
+	// ErrApprovalInvalid is returned for an approval that is not signed by the approver
+	ErrApprovalInvalid = errors.New("approval signer: invalid approval")
+	// ErrApprovalNotInitialized is returned by the sign calls until Initialize succeeds
+	ErrApprovalNotInitialized = errors.New("approval signer: not initialized")
+)
+
+// ApprovalRequest is the request sent to the approvers before signing
+type ApprovalRequest struct {
+	// Name is the name of the ApprovalSign
+	Name string
+	// Signer is the address of the key that is going to sign
+	Signer common.Address
+	// Operation is the call (SignHash, SignTx...)
+	Operation string
+	// Digest is the hash that Signer is going to sign
+	Digest common.Hash
+	// Tx is the tx to sign for SignTx, so an external approver can show it (nil otherwise)
+	Tx *types.Transaction
+}
+
+// Hash returns the hash that the approvers sign: the EIP-191 personal message of
+// keccak256("go_signer approval" || Signer || Digest)
+func (r ApprovalRequest) Hash() common.Hash {
+	inner := crypto.Keccak256([]byte(approvalDomain), r.Signer.Bytes(), r.Digest.Bytes())
+	return common.BytesToHash(accounts.TextHash(inner))
+}
+
+// Approver approves the requests of an ApprovalSign. It's implemented by SignerApprover
+// (a go_signer key) and ApproverFunc (any external channel: chat, ticketing, HSM...)
+type Approver interface {
+	// Address is the key of the approver, the approvals are verified against it
+	Address() common.Address
+	// Approve returns the signature of request.Hash() ([R || S || V]) or an error if it's rejected
+	Approve(ctx context.Context, request ApprovalRequest) ([]byte, error)
+}
+
+// SignerApprover is an Approver that signs the approvals with a go_signer signer
+type SignerApprover struct {
+	signer signertypes.Signer
+}
+
+// NewSignerApprover creates an Approver that approves all the requests with signer
+func NewSignerApprover(signer signertypes.Signer) *SignerApprover {
+	return &SignerApprover{signer: signer}
+}
+
+// Initialize initializes the signer of the approver
+func (a *SignerApprover) Initialize(ctx context.Context) error {
+	return a.signer.Initialize(ctx)
+}
+
+// Close closes the signer of the approver
+func (a *SignerApprover) Close() error {
+	return a.signer.Close()
+}
+
+func (a *SignerApprover) Address() common.Address {
+	return a.signer.PublicAddress()
+}
+
+func (a *SignerApprover) Approve(ctx context.Context, request ApprovalRequest) ([]byte, error) {
+	return a.signer.SignHash(ctx, request.Hash())
+}
+
+// ApproverFunc is an Approver with address and the approval done by fn
+type ApproverFunc struct {
+	address common.Address
+	fn      func(ctx context.Context, request ApprovalRequest) ([]byte, error)
+}
+
+// NewApproverFunc creates an Approver for address that calls fn for each request
+func NewApproverFunc(address common.Address,
+	fn func(ctx context.Context, request ApprovalRequest) ([]byte, error)) *ApproverFunc {
+	return &ApproverFunc{address: address, fn: fn}
+}
+
+func (a *ApproverFunc) Address() common.Address {
+	return a.address
+}
+
+func (a *ApproverFunc) Approve(ctx context.Context, request ApprovalRequest) ([]byte, error) {
+	return a.fn(ctx, request)
+}
+
+// ApprovalSign is a signer that only signs (with signer) when at least threshold of the
+// approvers have approved the request. The approvers are asked in parallel and the pending
+// ones are canceled once the threshold is reached
+type ApprovalSign struct {
+	name      string
+	logger    signercommon.Logger
+	signer    signertypes.Signer
+	approvers []Approver
+	threshold int
+	// chainID is the default chain of the txs, used to compute the digest to approve
+	chainID uint64
+	batcher *batch.Batcher
+	// initialized is set once Initialize has checked the approvers, it doesn't sign before
+	initialized atomic.Bool
+}
+
+var _ signertypes.Signer = (*ApprovalSign)(nil)
+
+// NewApprovalSign creates an ApprovalSign that signs with signer after threshold (m) of
+// the approvers (n) approve each request. The addresses known now (e.g. ApproverFunc) must be
+// different, the rest are checked by Initialize
+func NewApprovalSign(name string, logger signercommon.Logger, signer signertypes.Signer,
+	approvers []Approver, threshold int, chainID uint64) (*ApprovalSign, error) {
+	if threshold < 1 || threshold > len(approvers) {
+		return nil, fmt.Errorf("%w: threshold %d, approvers %d", ErrApprovalBadThreshold, threshold, len(approvers))
+	}
+	if err := checkDuplicatedApprovers(approvers); err != nil {
+		return nil, err
+	}
+	return &ApprovalSign{
+		name:      name,
+		logger:    logger,
+		signer:    signer,
+		approvers: approvers,
+		threshold: threshold,
+		chainID:   chainID,
+		batcher:   batch.NewBatcher(signertypes.DefaultBatchConfig()),
+	}, nil
+}
+
+// Initialize initializes the signer and the approvers that need it (e.g. SignerApprover) and
+// checks that all the approvers have different addresses. It doesn't sign until it succeeds
+func (a *ApprovalSign) Initialize(ctx context.Context) error {
+	if err := a.signer.Initialize(ctx); err != nil {
+		return err
+	}
+	for _, approver := range a.approvers {
+		if initializer, ok := approver.(interface{ Initialize(context.Context) error }); ok {
+			if err := initializer.Initialize(ctx); err != nil {
+				return fmt.Errorf("%s can't initialize approver. Err: %w", a.logPrefix(), err)
+			}
+		}
+		if approver.Address() == (common.Address{}) {
+			return fmt.Errorf("%s approver without address. Err: %w", a.logPrefix(), ErrApprovalInvalid)
+		}
+	}
+	if err := checkDuplicatedApprovers(a.approvers); err != nil {
+		return fmt.Errorf("%s %w", a.logPrefix(), err)
+	}
+	a.initialized.Store(true)
+	return nil
+}
+
+// checkDuplicatedApprovers returns ErrApprovalDuplicatedApprover if two approvers have the
+// same address. The approvers without address yet (not initialized) are skipped
+func checkDuplicatedApprovers(approvers []Approver) error {
+	seen := make(map[common.Address]bool, len(approvers))
+	for _, approver := range approvers {
+		addr := approver.Address()
+		if addr == (common.Address{}) {
+			continue
+		}
+		if seen[addr] {
+			return fmt.Errorf("%w: %s", ErrApprovalDuplicatedApprover, addr.Hex())
+		}
+		seen[addr] = true
+	}
+	return nil
+}
+
+// Close closes the signer and the approvers that need it (e.g. SignerApprover)
+func (a *ApprovalSign) Close() error {
+	a.initialized.Store(false)
+	errs := []error{a.signer.Close()}
+	for _, approver := range a.approvers {
+		if closer, ok := approver.(interface{ Close() error }); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Unwrap returns the signer that signs the approved requests
+func (a *ApprovalSign) Unwrap() signertypes.Signer {
+	return a.signer
+}
+
+func (a *ApprovalSign) PublicAddress() common.Address {
+	return a.signer.PublicAddress()
+}
+
+func (a *ApprovalSign) String() string {
+	return fmt.Sprintf("%s %d-of-%d: %s", a.logPrefix(), a.threshold, len(a.approvers), a.signer.String())
+}
+
+func (a *ApprovalSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	if err := a.approve(ctx, ApprovalRequest{Operation: operationSignHash, Digest: hash}); err != nil {
+		return nil, err
+	}
+	return a.signer.SignHash(ctx, hash)
+}
+
+func (a *ApprovalSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return a.SignTxForChain(ctx, 0, tx)
+}
+
+func (a *ApprovalSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	if chainID == 0 {
+		chainID = a.chainID
+	}
+	chainID, err := signertypes.ResolveTxChainID(tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("%s SignTx. Err: %w", a.logPrefix(), err)
+	}
+	digest := types.LatestSignerForChainID(new(big.Int).SetUint64(chainID)).Hash(tx)
+	if err := a.approve(ctx, ApprovalRequest{Operation: operationSignTx, Digest: digest, Tx: tx}); err != nil {
+		return nil, err
+	}
+	return a.signer.SignTxForChain(ctx, chainID, tx)
+}
+
+func (a *ApprovalSign) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	digest, err := signertypes.AuthorizationHash(auth)
+	if err != nil {
+		return types.SetCodeAuthorization{}, err
+	}
+	if err := a.approve(ctx, ApprovalRequest{Operation: operationSignAuthorization, Digest: digest}); err != nil {
+		return types.SetCodeAuthorization{}, err
+	}
+	return a.signer.SignAuthorization(ctx, auth)
+}
+
+// SetBatchConfig sets the parameters used by SignHashes and SignTxs
+func (a *ApprovalSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	a.batcher = batch.NewBatcher(cfg)
+}
+
+// SignHashes signs a batch of hashes, each one is approved on its own
+func (a *ApprovalSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	return a.batcher.SignHashes(ctx, a, hashes)
+}
+
+// SignTxs signs a batch of txs, each one is approved on its own
+func (a *ApprovalSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	return a.batcher.SignTxs(ctx, a, txs)
+}
+
+// approve asks all the approvers in parallel and returns nil once threshold valid approvals are
+// collected, or ErrApprovalNotReached (with the error of each rejection) when it's not possible anymore
+func (a *ApprovalSign) approve(ctx context.Context, request ApprovalRequest) error {
+	if !a.initialized.Load() {
+		return fmt.Errorf("%s %w", a.logPrefix(), ErrApprovalNotInitialized)
+	}
+	request.Name = a.name
+	request.Signer = a.signer.PublicAddress()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// results is buffered so the approvers still pending when it returns don't block (ctx is canceled)
+	results := make(chan error, len(a.approvers))
+	for _, approver := range a.approvers {
+		go func(approver Approver) {
+			results <- a.checkApproval(ctx, approver, request)
+		}(approver)
+	}
+	approved := 0
+	var errs []error
+	for range a.approvers {
+		err := <-results
+		if err != nil {
+			errs = append(errs, err)
+			if len(a.approvers)-len(errs) < a.threshold {
+				return fmt.Errorf("%s %s %s: %w (%d of %d). Err: %w", a.logPrefix(), request.Operation,
+					request.Digest.Hex(), ErrApprovalNotReached, approved, a.threshold, errors.Join(errs...))
+			}
+			continue
+		}
+		approved++
+		if approved >= a.threshold {
+			a.logger.Infof("%s %s %s approved by %d of %d", a.logPrefix(), request.Operation,
+				request.Digest.Hex(), approved, len(a.approvers))
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %w", a.logPrefix(), ErrApprovalNotReached)
+}
+
+// checkApproval asks approver and verifies that the approval is signed by it
+func (a *ApprovalSign) checkApproval(ctx context.Context, approver Approver, request ApprovalRequest) error {
+	signature, err := approver.Approve(ctx, request)
+	if err != nil {
+		return fmt.Errorf("approver %s: %w", approver.Address().Hex(), err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("approver %s: %w: length %d", approver.Address().Hex(), ErrApprovalInvalid, len(signature))
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(request.Hash().Bytes(), sig)
+	if err != nil || crypto.PubkeyToAddress(*pub) != approver.Address() {
+		return fmt.Errorf("approver %s: %w", approver.Address().Hex(), ErrApprovalInvalid)
+	}
+	return nil
+}
+
+func (a *ApprovalSign) logPrefix() string {
+	return fmt.Sprintf("signer: approval[%s]: ", a.name)
+}
@@ -0,0 +1,156 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/agglayer/go_signer/log"
+	"github.com/agglayer/go_signer/signer/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestRejected = errors.New("rejected by operator")
+
+func newTestLocalSign(t *testing.T, chainID uint64) *LocalSign {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return NewLocalSignFromPrivateKey("name", log.WithFields("test", "test"), key, chainID)
+}
+
+// rejectApprover rejects all the requests
+func rejectApprover(t *testing.T) Approver {
+	t.Helper()
+	return NewApproverFunc(newTestLocalSign(t, 0).PublicAddress(),
+		func(context.Context, ApprovalRequest) ([]byte, error) { return nil, errTestRejected })
+}
+
+func TestApprovalSignThreshold(t *testing.T) {
+	ctx := context.TODO()
+	logger := log.WithFields("test", "test")
+	signer := newTestLocalSign(t, 1)
+	approvers := []Approver{
+		NewSignerApprover(newTestLocalSign(t, 0)),
+		NewSignerApprover(newTestLocalSign(t, 0)),
+		rejectApprover(t),
+	}
+	_, err := NewApprovalSign("name", logger, signer, approvers, 4, 1)
+	require.ErrorIs(t, err, ErrApprovalBadThreshold)
+
+	sut, err := NewApprovalSign("name", logger, signer, approvers, 2, 1)
+	require.NoError(t, err)
+	require.NoError(t, sut.Initialize(ctx))
+	require.Equal(t, signer.PublicAddress(), sut.PublicAddress())
+	hash := common.Hash{1}
+	signature, err := sut.SignHash(ctx, hash)
+	require.NoError(t, err)
+	require.NoError(t, signer.Verify(hash, signature))
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 1})
+	signed, err := sut.SignTx(ctx, tx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), signed)
+	require.NoError(t, err)
+	require.Equal(t, signer.PublicAddress(), sender)
+
+	// 3 of 3 is not possible with a rejection
+	sut, err = NewApprovalSign("name", logger, signer, approvers, 3, 1)
+	require.NoError(t, err)
+	require.NoError(t, sut.Initialize(ctx))
+	_, err = sut.SignHash(ctx, hash)
+	require.ErrorIs(t, err, ErrApprovalNotReached)
+	require.ErrorIs(t, err, errTestRejected)
+	_, err = sut.SignTxs(ctx, []*types.Transaction{tx})
+	require.ErrorIs(t, err, ErrApprovalNotReached)
+}
+
+func TestApprovalSignInvalidApproval(t *testing.T) {
+	ctx := context.TODO()
+	approverKey := newTestLocalSign(t, 0)
+	other := newTestLocalSign(t, 0)
+	require.NoError(t, approverKey.Initialize(ctx))
+	require.NoError(t, other.Initialize(ctx))
+	var received ApprovalRequest
+	approvers := []Approver{
+		// The approval is signed by other key
+		NewApproverFunc(approverKey.PublicAddress(), func(ctx context.Context, request ApprovalRequest) ([]byte, error) {
+			received = request
+			return other.SignHash(ctx, request.Hash())
+		}),
+	}
+	signer := newTestLocalSign(t, 1)
+	sut, err := NewApprovalSign("name", log.WithFields("test", "test"), signer, approvers, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, sut.Initialize(ctx))
+	_, err = sut.SignHash(ctx, common.Hash{1})
+	require.ErrorIs(t, err, ErrApprovalInvalid)
+	require.Equal(t, ApprovalRequest{Name: "name", Signer: signer.PublicAddress(), Operation: operationSignHash,
+		Digest: common.Hash{1}}, received)
+}
+
+func TestApprovalSignCancelsPending(t *testing.T) {
+	ctx := context.TODO()
+	canceled := make(chan struct{})
+	blocked := NewApproverFunc(common.HexToAddress("0x1"), func(ctx context.Context, _ ApprovalRequest) ([]byte, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	})
+	sut, err := NewApprovalSign("name", log.WithFields("test", "test"), newTestLocalSign(t, 1),
+		[]Approver{NewSignerApprover(newTestLocalSign(t, 0)), blocked}, 1, 1)
+	require.NoError(t, err)
+	require.NoError(t, sut.Initialize(ctx))
+	_, err = sut.SignHash(ctx, common.Hash{1})
+	require.NoError(t, err)
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		require.Fail(t, "pending approver not canceled")
+	}
+}
+
+func TestApprovalSignDuplicatedApprover(t *testing.T) {
+	logger := log.WithFields("test", "test")
+	// The address is known on construction
+	approver := NewSignerApprover(newTestLocalSign(t, 0))
+	_, err := NewApprovalSign("name", logger, newTestLocalSign(t, 1), []Approver{approver, approver}, 1, 1)
+	require.ErrorIs(t, err, ErrApprovalDuplicatedApprover)
+	_, err = NewApprovalSign("name", logger, newTestLocalSign(t, 1), nil, 1, 0)
+	require.ErrorIs(t, err, ErrApprovalBadThreshold)
+
+	// The address is known once the approver is initialized
+	addr := common.HexToAddress("0x1")
+	uninitialized := mocks.NewSigner(t)
+	uninitialized.EXPECT().PublicAddress().Return(common.Address{}).Once()
+	uninitialized.EXPECT().Initialize(mock.Anything).Return(nil).Once()
+	uninitialized.EXPECT().PublicAddress().Return(addr)
+	approvers := []Approver{NewSignerApprover(uninitialized), NewApproverFunc(addr, nil)}
+	sut, err := NewApprovalSign("name", logger, newTestLocalSign(t, 1), approvers, 1, 1)
+	require.NoError(t, err)
+	require.ErrorIs(t, sut.Initialize(context.TODO()), ErrApprovalDuplicatedApprover)
+	_, err = sut.SignHash(context.TODO(), common.Hash{1})
+	require.ErrorIs(t, err, ErrApprovalNotInitialized)
+}
+
+func TestApprovalSignNotInitialized(t *testing.T) {
+	approved := false
+	approver := NewApproverFunc(common.HexToAddress("0x1"), func(context.Context, ApprovalRequest) ([]byte, error) {
+		approved = true
+		return nil, errTestRejected
+	})
+	sut, err := NewApprovalSign("name", log.WithFields("test", "test"), newTestLocalSign(t, 1),
+		[]Approver{approver}, 1, 1)
+	require.NoError(t, err)
+	_, err = sut.SignHash(context.TODO(), common.Hash{1})
+	require.ErrorIs(t, err, ErrApprovalNotInitialized)
+	_, err = sut.SignTx(context.TODO(), types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1)}))
+	require.ErrorIs(t, err, ErrApprovalNotInitialized)
+	require.False(t, approved)
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// selectorLength is the length of the function selector on tx data
+	selectorLength = 4
+)
+
+// Record is an entry of the audit log, one per signature produced
+type Record struct {
+	// Seq is the position of the record on the log (starting at 1)
+	Seq uint64 `json:"seq"`
+	// Timestamp is when the signature was produced
+	Timestamp time.Time `json:"timestamp"`
+	// SignerName is the name of the signer (name argument of NewSigner)
+	SignerName string `json:"signerName"`
+	// Method is the sign method of the signer (local, GCP, ...)
+	Method string `json:"method"`
+	// Operation is the signer call that produced the signature (SignHash, SignTx, ...)
+	Operation string `json:"operation"`
+	// Address is the public address of the signer
+	Address common.Address `json:"address"`
+	// Digest is the hash that has been signed
+	Digest common.Hash `json:"digest"`
+	// Tx are the decoded fields of the tx if the signature is for a tx
+	Tx *TxRecord `json:"tx,omitempty"`
+	// PrevHash is the Hash of the previous record (empty for the first one)
+	PrevHash string `json:"prevHash"`
+	// Hash is sha256(PrevHash || record without Hash), it chains all the records
+	Hash string `json:"hash"`
+}
+
+// TxRecord are the relevant fields of a signed tx
+type TxRecord struct {
+	Hash     common.Hash     `json:"hash"`
+	Type     uint8           `json:"type"`
+	ChainID  *big.Int        `json:"chainId,omitempty"`
+	Nonce    uint64          `json:"nonce"`
+	To       *common.Address `json:"to,omitempty"`
+	Value    *big.Int        `json:"value,omitempty"`
+	Selector hexutil.Bytes   `json:"selector,omitempty"`
+}
+
+// NewTxRecord extracts the fields to audit from a tx
+func NewTxRecord(tx *types.Transaction) *TxRecord {
+	res := &TxRecord{
+		Hash:    tx.Hash(),
+		Type:    tx.Type(),
+		ChainID: tx.ChainId(),
+		Nonce:   tx.Nonce(),
+		To:      tx.To(),
+		Value:   tx.Value(),
+	}
+	if data := tx.Data(); len(data) >= selectorLength {
+		res.Selector = common.CopyBytes(data[:selectorLength])
+	}
+	return res
+}
+
+// computeHash returns the hash that chains this record with the previous one
+func (r Record) computeHash() (string, error) {
+	r.Hash = ""
+	content, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("audit: can't marshal record %d. Err: %w", r.Seq, err)
+	}
+	h := sha256.New()
+	h.Write([]byte(r.PrevHash))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// chain sets Seq, PrevHash and Hash of the record so it goes after prev
+func (r *Record) chain(prevSeq uint64, prevHash string) error {
+	r.Seq = prevSeq + 1
+	r.PrevHash = prevHash
+	// UTC so the JSON encoding doesn't depend on the local timezone
+	r.Timestamp = r.Timestamp.UTC()
+	hash, err := r.computeHash()
+	if err != nil {
+		return err
+	}
+	r.Hash = hash
+	return nil
+}
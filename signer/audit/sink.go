@@ -0,0 +1,164 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	filePermissions = 0o600
+	// maxLineSize is the max size of a record on the log file
+	maxLineSize = 1024 * 1024
+)
+
+var (
+	ErrBrokenChain = errors.New("audit: broken chain")
+	ErrSinkClosed  = errors.New("audit: sink is closed")
+)
+
+// Sink receives a record for each signature produced
+type Sink interface {
+	// Write stores the record. It sets the fields that chain the record (Seq, PrevHash, Hash)
+	Write(ctx context.Context, record Record) error
+	// Close releases the resources of the sink
+	Close() error
+}
+
+// FileSink is a Sink that appends the records to a JSONL file, one record per line.
+// Each record is hash-chained to the previous one, so any modification of the file
+// is detected by Verify
+type FileSink struct {
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	lastSeq  uint64
+	lastHash string
+}
+
+var _ Sink = (*FileSink)(nil)
+
+// NewFileSink opens (or creates) the audit log file. If the file already has records
+// the new ones are chained to the last one. It fails if the existing chain is broken
+func NewFileSink(path string) (*FileSink, error) {
+	path = filepath.Clean(path)
+	res := &FileSink{path: path}
+	existing, err := os.Open(path)
+	switch {
+	case err == nil:
+		lastSeq, lastHash, err := verify(existing)
+		existing.Close()
+		if err != nil {
+			return nil, fmt.Errorf("audit: existing log %s is not valid. Err: %w", path, err)
+		}
+		res.lastSeq = lastSeq
+		res.lastHash = lastHash
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("audit: can't open log %s. Err: %w", path, err)
+	}
+	res.file, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return nil, fmt.Errorf("audit: can't open log %s for append. Err: %w", path, err)
+	}
+	return res, nil
+}
+
+// Write appends the record to the file and syncs it to disk
+func (s *FileSink) Write(ctx context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return ErrSinkClosed
+	}
+	if err := record.chain(s.lastSeq, s.lastHash); err != nil {
+		return err
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("audit: can't marshal record %d. Err: %w", record.Seq, err)
+	}
+	line = append(line, '\n')
+	if _, err := s.file.Write(line); err != nil {
+		return fmt.Errorf("audit: can't write record %d to %s. Err: %w", record.Seq, s.path, err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("audit: can't sync %s. Err: %w", s.path, err)
+	}
+	s.lastSeq = record.Seq
+	s.lastHash = record.Hash
+	return nil
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// Verify checks the chain of records read from r. It returns the number of records
+func Verify(r io.Reader) (uint64, error) {
+	lastSeq, _, err := verify(r)
+	return lastSeq, err
+}
+
+// VerifyFile checks the chain of records of an audit log file
+func VerifyFile(path string) (uint64, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return 0, fmt.Errorf("audit: can't open log %s. Err: %w", path, err)
+	}
+	defer f.Close()
+	return Verify(f)
+}
+
+func verify(r io.Reader) (uint64, string, error) {
+	var (
+		lastSeq  uint64
+		lastHash string
+	)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			return lastSeq, lastHash, fmt.Errorf("%w: record after seq %d can't be decoded. Err: %s",
+				ErrBrokenChain, lastSeq, err.Error())
+		}
+		if record.Seq != lastSeq+1 {
+			return lastSeq, lastHash, fmt.Errorf("%w: expected seq %d, found %d", ErrBrokenChain, lastSeq+1, record.Seq)
+		}
+		if record.PrevHash != lastHash {
+			return lastSeq, lastHash, fmt.Errorf("%w: record %d prevHash doesn't match the previous record",
+				ErrBrokenChain, record.Seq)
+		}
+		hash, err := record.computeHash()
+		if err != nil {
+			return lastSeq, lastHash, err
+		}
+		if hash != record.Hash {
+			return lastSeq, lastHash, fmt.Errorf("%w: record %d has been modified", ErrBrokenChain, record.Seq)
+		}
+		lastSeq = record.Seq
+		lastHash = record.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return lastSeq, lastHash, fmt.Errorf("audit: error reading log. Err: %w", err)
+	}
+	return lastSeq, lastHash, nil
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRecords(t *testing.T, path string, n int) {
+	t.Helper()
+	sut, err := NewFileSink(path)
+	require.NoError(t, err)
+	for i := 0; i < n; i++ {
+		require.NoError(t, sut.Write(context.TODO(), Record{
+			Timestamp:  time.Now(),
+			SignerName: "test",
+			Method:     "local",
+			Operation:  "SignHash",
+			Digest:     common.BigToHash(big.NewInt(int64(i))),
+		}))
+	}
+	require.NoError(t, sut.Close())
+}
+
+func TestFileSinkWriteAndVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeRecords(t, path, 3)
+	// Reopening the file continues the chain
+	writeRecords(t, path, 2)
+
+	records, err := VerifyFile(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), records)
+}
+
+func TestFileSinkClosed(t *testing.T) {
+	sut, err := NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	require.NoError(t, sut.Close())
+	require.ErrorIs(t, sut.Write(context.TODO(), Record{}), ErrSinkClosed)
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeRecords(t, path, 3)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+
+	t.Run("modified record", func(t *testing.T) {
+		modified := strings.Replace(lines[1], `"signerName":"test"`, `"signerName":"evil"`, 1)
+		tampered := strings.Join([]string{lines[0], modified, lines[2]}, "\n")
+		_, err := Verify(strings.NewReader(tampered))
+		require.ErrorIs(t, err, ErrBrokenChain)
+	})
+	t.Run("removed record", func(t *testing.T) {
+		tampered := strings.Join([]string{lines[0], lines[2]}, "\n")
+		records, err := Verify(strings.NewReader(tampered))
+		require.ErrorIs(t, err, ErrBrokenChain)
+		require.Equal(t, uint64(1), records)
+	})
+	t.Run("reopen tampered file", func(t *testing.T) {
+		tamperedPath := filepath.Join(t.TempDir(), "audit.jsonl")
+		require.NoError(t, os.WriteFile(tamperedPath, []byte(lines[1]+"\n"), 0o600))
+		_, err := NewFileSink(tamperedPath)
+		require.ErrorIs(t, err, ErrBrokenChain)
+	})
+}
+
+func TestNewTxRecord(t *testing.T) {
+	to := common.HexToAddress("0x1234")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID: big.NewInt(1337),
+		Nonce:   3,
+		To:      &to,
+		Value:   big.NewInt(10),
+		Data:    []byte{0xa9, 0x05, 0x9c, 0xbb, 0x01},
+	})
+	res := NewTxRecord(tx)
+	require.Equal(t, tx.Hash(), res.Hash)
+	require.Equal(t, uint8(types.DynamicFeeTxType), res.Type)
+	require.Equal(t, int64(1337), res.ChainID.Int64())
+	require.Equal(t, uint64(3), res.Nonce)
+	require.Equal(t, &to, res.To)
+	require.Equal(t, int64(10), res.Value.Int64())
+	require.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb}, []byte(res.Selector))
+}
@@ -0,0 +1,184 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agglayer/go_signer/signer/audit"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// AuditSign is a decorator over a signer that records on an audit.Sink every signature
+// produced. If the record can't be stored the signature is not returned (fail closed)
+type AuditSign struct {
+	method signertypes.SignMethod
+	name   string
+	signer signertypes.Signer
+	sink   audit.Sink
+}
+
+var _ signertypes.Signer = (*AuditSign)(nil)
+
+// NewAuditSign creates an AuditSign over signer
+func NewAuditSign(method signertypes.SignMethod, name string, signer signertypes.Signer,
+	sink audit.Sink) *AuditSign {
+	return &AuditSign{
+		method: method,
+		name:   name,
+		signer: signer,
+		sink:   sink,
+	}
+}
+
+// Unwrap returns the decorated signer
+func (a *AuditSign) Unwrap() signertypes.Signer {
+	return a.signer
+}
+
+func (a *AuditSign) Initialize(ctx context.Context) error {
+	return a.signer.Initialize(ctx)
+}
+
+func (a *AuditSign) PublicAddress() common.Address {
+	return a.signer.PublicAddress()
+}
+
+func (a *AuditSign) String() string {
+	return a.signer.String()
+}
+
+// Close closes the wrapped signer, the sink is shared so it is not closed
+func (a *AuditSign) Close() error {
+	return a.signer.Close()
+}
+
+func (a *AuditSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	res, err := a.signer.SignHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.sink.Write(ctx, a.hashRecord(operationSignHash, hash)); err != nil {
+		return nil, fmt.Errorf("%s SignHash can't write audit record. Err: %w", a.logPrefix(), err)
+	}
+	return res, nil
+}
+
+func (a *AuditSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	res, err := a.signer.SignTx(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.sink.Write(ctx, a.txRecord(operationSignTx, res)); err != nil {
+		return nil, fmt.Errorf("%s SignTx can't write audit record. Err: %w", a.logPrefix(), err)
+	}
+	return res, nil
+}
+
+func (a *AuditSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	res, err := a.signer.SignTxForChain(ctx, chainID, tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.sink.Write(ctx, a.txRecord(operationSignTx, res)); err != nil {
+		return nil, fmt.Errorf("%s SignTxForChain can't write audit record. Err: %w", a.logPrefix(), err)
+	}
+	return res, nil
+}
+
+func (a *AuditSign) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	res, err := a.signer.SignAuthorization(ctx, auth)
+	if err != nil {
+		return types.SetCodeAuthorization{}, err
+	}
+	digest, err := signertypes.AuthorizationHash(res)
+	if err != nil {
+		return types.SetCodeAuthorization{}, err
+	}
+	if err := a.sink.Write(ctx, a.hashRecord(operationSignAuthorization, digest)); err != nil {
+		return types.SetCodeAuthorization{}, fmt.Errorf("%s SignAuthorization can't write audit record. Err: %w",
+			a.logPrefix(), err)
+	}
+	return res, nil
+}
+
+// SetBatchConfig sets the batch parameters of the decorated signer
+func (a *AuditSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	if configurable, ok := a.signer.(batchConfigurable); ok {
+		configurable.SetBatchConfig(cfg)
+	}
+}
+
+func (a *AuditSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	res, err := a.signer.SignHashes(ctx, hashes)
+	return auditBatch(ctx, a, hashes, res, err, func(i int, _ []byte) audit.Record {
+		return a.hashRecord(operationSignHashes, hashes[i])
+	})
+}
+
+func (a *AuditSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	res, err := a.signer.SignTxs(ctx, txs)
+	return auditBatch(ctx, a, txs, res, err, func(_ int, signed *types.Transaction) audit.Record {
+		return a.txRecord(operationSignTxs, signed)
+	})
+}
+
+func (a *AuditSign) hashRecord(operation string, hash common.Hash) audit.Record {
+	return audit.Record{
+		Timestamp:  time.Now(),
+		SignerName: a.name,
+		Method:     a.method.String(),
+		Operation:  operation,
+		Address:    a.signer.PublicAddress(),
+		Digest:     hash,
+	}
+}
+
+func (a *AuditSign) txRecord(operation string, signed *types.Transaction) audit.Record {
+	return audit.Record{
+		Timestamp:  time.Now(),
+		SignerName: a.name,
+		Method:     a.method.String(),
+		Operation:  operation,
+		Address:    a.signer.PublicAddress(),
+		Digest:     types.LatestSignerForChainID(signed.ChainId()).Hash(signed),
+		Tx:         audit.NewTxRecord(signed),
+	}
+}
+
+func (a *AuditSign) logPrefix() string {
+	return fmt.Sprintf("signer: audit[%s]: ", a.name)
+}
+
+// auditBatch records each signed item of a batch. The items that can't be recorded are
+// removed from the result and reported as failed on the BatchError
+func auditBatch[T, R any](ctx context.Context, a *AuditSign, items []T, res []R, err error,
+	record func(int, R) audit.Record) ([]R, error) {
+	if len(res) != len(items) {
+		return res, err
+	}
+	errs := batchItemErrors(err, len(items))
+	if errs == nil {
+		errs = make([]error, len(items))
+	}
+	var zero R
+	failed := err != nil
+	for i, signed := range res {
+		if errs[i] != nil {
+			continue
+		}
+		if writeErr := a.sink.Write(ctx, record(i, signed)); writeErr != nil {
+			errs[i] = fmt.Errorf("%s can't write audit record. Err: %w", a.logPrefix(), writeErr)
+			res[i] = zero
+			failed = true
+		}
+	}
+	if failed {
+		return res, &signertypes.BatchError{Errors: errs}
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/agglayer/go_signer/signer/audit"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/agglayer/go_signer/signer/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestAudit = errors.New("audit sink error")
+
+// memorySink keeps the records in memory and fails if err is set
+type memorySink struct {
+	records []audit.Record
+	err     error
+}
+
+func (m *memorySink) Write(_ context.Context, record audit.Record) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *memorySink) Close() error {
+	return nil
+}
+
+func TestAuditSignRecordsSignatures(t *testing.T) {
+	inner := mocks.NewSigner(t)
+	addr := common.HexToAddress("0x1234")
+	inner.EXPECT().PublicAddress().Return(addr)
+	sink := &memorySink{}
+	sut := NewAuditSign(signertypes.MethodLocal, "sequencer", inner, sink)
+	ctx := context.TODO()
+
+	hash := common.HexToHash("0xabcd")
+	inner.EXPECT().SignHash(mock.Anything, hash).Return([]byte{1}, nil).Once()
+	_, err := sut.SignHash(ctx, hash)
+	require.NoError(t, err)
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1337), Nonce: 7})
+	inner.EXPECT().SignTx(mock.Anything, tx).Return(tx, nil).Once()
+	_, err = sut.SignTx(ctx, tx)
+	require.NoError(t, err)
+
+	require.Len(t, sink.records, 2)
+	require.Equal(t, "sequencer", sink.records[0].SignerName)
+	require.Equal(t, "local", sink.records[0].Method)
+	require.Equal(t, operationSignHash, sink.records[0].Operation)
+	require.Equal(t, addr, sink.records[0].Address)
+	require.Equal(t, hash, sink.records[0].Digest)
+	require.Nil(t, sink.records[0].Tx)
+	require.Equal(t, operationSignTx, sink.records[1].Operation)
+	require.Equal(t, types.LatestSignerForChainID(big.NewInt(1337)).Hash(tx), sink.records[1].Digest)
+	require.Equal(t, uint64(7), sink.records[1].Tx.Nonce)
+}
+
+func TestAuditSignFailsClosed(t *testing.T) {
+	inner := mocks.NewSigner(t)
+	inner.EXPECT().PublicAddress().Return(common.Address{})
+	sink := &memorySink{err: errTestAudit}
+	sut := NewAuditSign(signertypes.MethodLocal, "sequencer", inner, sink)
+	ctx := context.TODO()
+
+	inner.EXPECT().SignHash(mock.Anything, common.Hash{}).Return([]byte{1}, nil).Once()
+	res, err := sut.SignHash(ctx, common.Hash{})
+	require.ErrorIs(t, err, errTestAudit)
+	require.Nil(t, res)
+
+	hashes := []common.Hash{{1}, {2}}
+	inner.EXPECT().SignHashes(mock.Anything, hashes).Return([][]byte{{1}, {2}}, nil).Once()
+	batchRes, err := sut.SignHashes(ctx, hashes)
+	var batchErr *signertypes.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 2, batchErr.Failed())
+	require.Nil(t, batchRes[0])
+	require.Nil(t, batchRes[1])
+}
+
+func TestAuditSignBatchSkipsFailedItems(t *testing.T) {
+	inner := mocks.NewSigner(t)
+	inner.EXPECT().PublicAddress().Return(common.Address{})
+	sink := &memorySink{}
+	sut := NewAuditSign(signertypes.MethodLocal, "sequencer", inner, sink)
+
+	hashes := []common.Hash{{1}, {2}}
+	innerErr := &signertypes.BatchError{Errors: []error{nil, errTestAudit}}
+	inner.EXPECT().SignHashes(mock.Anything, hashes).Return([][]byte{{1}, nil}, innerErr).Once()
+	_, err := sut.SignHashes(context.TODO(), hashes)
+	require.ErrorIs(t, err, errTestAudit)
+	require.Len(t, sink.records, 1)
+	require.Equal(t, hashes[0], sink.records[0].Digest)
+}
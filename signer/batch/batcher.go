@@ -0,0 +1,103 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/time/rate"
+)
+
+// Batcher executes batches of signatures over a signer that only knows how to sign
+// one item at a time, doing a bounded parallel fan-out. The rate limit is shared
+// between all the batches executed with the same Batcher
+type Batcher struct {
+	cfg     signertypes.BatchConfig
+	limiter *rate.Limiter
+}
+
+// NewBatcher creates a Batcher based on config
+func NewBatcher(cfg signertypes.BatchConfig) *Batcher {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = signertypes.DefaultBatchConcurrency
+	}
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if cfg.RateLimit > 0 {
+		burst := int(math.Max(1, math.Min(cfg.RateLimit, float64(cfg.Concurrency))))
+		limiter = rate.NewLimiter(rate.Limit(cfg.RateLimit), burst)
+	}
+	return &Batcher{
+		cfg:     cfg,
+		limiter: limiter,
+	}
+}
+
+// Config returns the BatchConfig used by this Batcher
+func (b *Batcher) Config() signertypes.BatchConfig {
+	return b.cfg
+}
+
+// Wait blocks until the rate limiter allows one more signature
+func (b *Batcher) Wait(ctx context.Context) error {
+	return b.limiter.Wait(ctx)
+}
+
+// SignHashes signs all the hashes using signer.SignHash
+func (b *Batcher) SignHashes(ctx context.Context, signer signertypes.HashSigner,
+	hashes []common.Hash) ([][]byte, error) {
+	return FanOut(ctx, b, hashes, signer.SignHash)
+}
+
+// SignTxs signs all the txs using signer.SignTx
+func (b *Batcher) SignTxs(ctx context.Context, signer signertypes.TxSigner,
+	txs []*types.Transaction) ([]*types.Transaction, error) {
+	return FanOut(ctx, b, txs, signer.SignTx)
+}
+
+// FanOut calls fn for each item with at most Concurrency calls in flight and respecting
+// the rate limit. The result keeps the order of items. If any item fails it returns
+// a *signertypes.BatchError with the error of each item
+func FanOut[T any, R any](ctx context.Context, b *Batcher, items []T,
+	fn func(context.Context, T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	if len(items) == 0 {
+		return results, nil
+	}
+	// Each call writes only its own position of results and errs
+	errs := make([]error, len(items))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, b.cfg.Concurrency)
+	for i, item := range items {
+		if err := b.limiter.Wait(ctx); err != nil {
+			// Context is done, so the rest of items are not going to be signed
+			for j := i; j < len(items); j++ {
+				errs[j] = fmt.Errorf("item %d not signed: %w", j, err)
+			}
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, item T) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			res, err := fn(ctx, item)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = res
+		}(i, item)
+	}
+	wg.Wait()
+	if slices.ContainsFunc(errs, func(err error) bool { return err != nil }) {
+		return results, &signertypes.BatchError{Errors: errs}
+	}
+	return results, nil
+}
@@ -0,0 +1,101 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestSign = errors.New("sign fails")
+
+func TestFanOutKeepsOrder(t *testing.T) {
+	sut := NewBatcher(signertypes.BatchConfig{Concurrency: 3})
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	res, err := FanOut(context.TODO(), sut, items, func(_ context.Context, i int) (string, error) {
+		return fmt.Sprintf("item-%d", i), nil
+	})
+	require.NoError(t, err)
+	require.Len(t, res, len(items))
+	for i, item := range items {
+		require.Equal(t, fmt.Sprintf("item-%d", item), res[i])
+	}
+}
+
+func TestFanOutRespectConcurrency(t *testing.T) {
+	sut := NewBatcher(signertypes.BatchConfig{Concurrency: 2})
+	var inFlight, maxInFlight atomic.Int32
+	_, err := FanOut(context.TODO(), sut, make([]int, 10), func(_ context.Context, _ int) (int, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			prev := maxInFlight.Load()
+			if current <= prev || maxInFlight.CompareAndSwap(prev, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return 0, nil
+	})
+	require.NoError(t, err)
+	require.LessOrEqual(t, maxInFlight.Load(), int32(2))
+}
+
+func TestFanOutPerItemErrors(t *testing.T) {
+	sut := NewBatcher(signertypes.DefaultBatchConfig())
+	hashes := []common.Hash{{1}, {2}, {3}}
+	res, err := FanOut(context.TODO(), sut, hashes, func(_ context.Context, h common.Hash) ([]byte, error) {
+		if h[0] == 2 {
+			return nil, errTestSign
+		}
+		return h.Bytes(), nil
+	})
+	require.Error(t, err)
+	require.ErrorIs(t, err, errTestSign)
+	var batchErr *signertypes.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 1, batchErr.Failed())
+	require.NoError(t, batchErr.Errors[0])
+	require.ErrorIs(t, batchErr.Errors[1], errTestSign)
+	require.NotNil(t, res[0])
+	require.Nil(t, res[1])
+	require.NotNil(t, res[2])
+}
+
+func TestFanOutContextCancelled(t *testing.T) {
+	sut := NewBatcher(signertypes.BatchConfig{Concurrency: 1, RateLimit: 1})
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err := FanOut(ctx, sut, []int{1, 2}, func(_ context.Context, i int) (int, error) {
+		return i, nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// The items that fail and the ones cancelled while others are in flight are all reported
+func TestFanOutFailsAndCancelled(t *testing.T) {
+	sut := NewBatcher(signertypes.BatchConfig{Concurrency: 2, RateLimit: 1})
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	_, err := FanOut(ctx, sut, []int{1, 2, 3}, func(_ context.Context, i int) (int, error) {
+		cancel()
+		return 0, errTestSign
+	})
+	var batchErr *signertypes.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.ErrorIs(t, batchErr.Errors[0], errTestSign)
+	require.ErrorIs(t, batchErr.Errors[2], context.Canceled)
+}
+
+func TestFanOutEmpty(t *testing.T) {
+	res, err := FanOut(context.TODO(), NewBatcher(signertypes.BatchConfig{}), []int{},
+		func(_ context.Context, i int) (int, error) { return i, nil })
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
@@ -0,0 +1,202 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/batch"
+	"github.com/agglayer/go_signer/signer/clefclient"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	// FieldApprovalTimeout is the max time waiting for Clef to approve a request
+	FieldApprovalTimeout = "approvaltimeout"
+	// DefaultClefApprovalTimeout is the approval timeout if it's not set on config
+	DefaultClefApprovalTimeout = 5 * time.Minute
+)
+
+var (
+	// ErrClefSignHashNotSupported is returned by ClefSign.SignHash: Clef never signs a raw hash,
+	// use SignData, SignText or SignTypedData
+	ErrClefSignHashNotSupported = fmt.Errorf("clef doesn't sign raw hashes, use SignData, SignText or SignTypedData")
+	// ErrClefAccountNotFound is returned if Clef doesn't have the configured account
+	ErrClefAccountNotFound = fmt.Errorf("account not found on clef")
+)
+
+// ClefClienter is the client of Clef's external API (account_*)
+type ClefClienter interface {
+	AccountList(ctx context.Context) ([]common.Address, error)
+	SignTransaction(ctx context.Context, from common.Address, tx *types.Transaction,
+		chainID *big.Int) (*types.Transaction, error)
+	SignData(ctx context.Context, mimeType string, from common.Address, data []byte) ([]byte, error)
+	SignTypedData(ctx context.Context, from common.Address, typedData apitypes.TypedData) ([]byte, error)
+}
+
+// ClefConfig is the specific config of the clef method
+type ClefConfig struct {
+	// URL of Clef: http(s)://, unix:///path or an IPC path (e.g. ~/.clef/clef.ipc)
+	URL string `mapstructure:"url"`
+	// Address is the account to use, optional if Clef has only one
+	Address common.Address `mapstructure:"address"`
+	// ApprovalTimeout is the max time waiting for the approval of a request (manual or by the rules)
+	ApprovalTimeout time.Duration `mapstructure:"approvaltimeout"`
+}
+
+// NewClefConfig creates a ClefConfig (specific config) from a SignerConfig
+func NewClefConfig(cfg signertypes.SignerConfig) (ClefConfig, error) {
+	res := ClefConfig{ApprovalTimeout: DefaultClefApprovalTimeout}
+	if err := cfg.Decode(&res); err != nil {
+		return ClefConfig{}, err
+	}
+	if res.URL == "" {
+		return ClefConfig{}, fmt.Errorf("config %s: field %s is required. Err: %w",
+			signertypes.MethodClef, FieldURL, signertypes.ErrMissingConfigParam)
+	}
+	return res, nil
+}
+
+// ClefSign is a signer that uses Clef (go-ethereum external signer). Each request is
+// approved by Clef (by the operator or by its rules file)
+type ClefSign struct {
+	name    string
+	logger  signercommon.Logger
+	client  ClefClienter
+	address common.Address
+	chainID uint64
+	batcher *batch.Batcher
+}
+
+var _ signertypes.Signer = (*ClefSign)(nil)
+
+// NewClefSign creates a ClefSign. If address is zero it uses the only account of Clef
+func NewClefSign(name string, logger signercommon.Logger, client ClefClienter,
+	address common.Address, chainID uint64) *ClefSign {
+	return &ClefSign{
+		name:    name,
+		logger:  logger,
+		client:  client,
+		address: address,
+		chainID: chainID,
+		batcher: batch.NewBatcher(signertypes.DefaultBatchConfig()),
+	}
+}
+
+// NewClefSignFromConfig creates a ClefSign with a client for cfg.URL
+func NewClefSignFromConfig(name string, logger signercommon.Logger, cfg ClefConfig, chainID uint64) *ClefSign {
+	return NewClefSign(name, logger, clefclient.NewClefClient(cfg.URL, cfg.ApprovalTimeout), cfg.Address, chainID)
+}
+
+// Initialize checks that Clef has the account (or picks the only one if it's not set)
+func (e *ClefSign) Initialize(ctx context.Context) error {
+	accounts, err := e.client.AccountList(ctx)
+	if err != nil {
+		return fmt.Errorf("%s error getting account list. Err: %w", e.logPrefix(), err)
+	}
+	if e.address == zeroAddr {
+		if len(accounts) != 1 {
+			return fmt.Errorf("%s found %d accounts, please specify the account", e.logPrefix(), len(accounts))
+		}
+		e.address = accounts[0]
+		e.logger.Infof("%s Using account %v", e.logPrefix(), e.address)
+		return nil
+	}
+	if !slices.Contains(accounts, e.address) {
+		return fmt.Errorf("%s account %s. Err: %w", e.logPrefix(), e.address.Hex(), ErrClefAccountNotFound)
+	}
+	return nil
+}
+
+// Close closes the connection to Clef (if the client keeps it)
+func (e *ClefSign) Close() error {
+	if closer, ok := e.client.(clientCloser); ok {
+		closer.Close()
+	}
+	return nil
+}
+
+func (e *ClefSign) PublicAddress() common.Address {
+	return e.address
+}
+
+func (e *ClefSign) String() string {
+	return fmt.Sprintf("signer: %s[%s]: pubAddr: %s", signertypes.MethodClef, e.name, e.address.String())
+}
+
+// SignHash is not supported by Clef
+func (e *ClefSign) SignHash(context.Context, common.Hash) ([]byte, error) {
+	return nil, ErrClefSignHashNotSupported
+}
+
+// SignTx signs tx on Clef (account_signTransaction)
+func (e *ClefSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return e.SignTxForChain(ctx, 0, tx)
+}
+
+// SignTxForChain signs tx on Clef for chainID (0 means the chainID of the signer or, if it's
+// not set, the one of the tx). The signed tx is checked and a different chain returns
+// ErrChainIDMismatch (e.g. a legacy tx signed without replay protection)
+func (e *ClefSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	if chainID == 0 {
+		chainID = e.chainID
+	}
+	chainID, err := signertypes.ResolveTxChainID(tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("%s SignTx. Err: %w", e.logPrefix(), err)
+	}
+	signed, err := e.client.SignTransaction(ctx, e.address, tx, new(big.Int).SetUint64(chainID))
+	if err != nil {
+		return nil, err
+	}
+	if err := signertypes.CheckChainID(chainID, signed.ChainId()); err != nil {
+		return nil, fmt.Errorf("%s signed tx %s. Err: %w", e.logPrefix(), signed.Hash().Hex(), err)
+	}
+	return signed, nil
+}
+
+// SignAuthorization is not supported by Clef (it has no API for EIP-7702 authorizations)
+func (e *ClefSign) SignAuthorization(context.Context, types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	return types.SetCodeAuthorization{}, signertypes.ErrAuthorizationNotSupported
+}
+
+// SignData signs data on Clef (account_signData), Clef hashes it according to mimeType
+func (e *ClefSign) SignData(ctx context.Context, mimeType string, data []byte) ([]byte, error) {
+	return e.client.SignData(ctx, mimeType, e.address, data)
+}
+
+// SignText signs text with the EIP-191 personal message prefix
+func (e *ClefSign) SignText(ctx context.Context, text []byte) ([]byte, error) {
+	return e.client.SignData(ctx, clefclient.MimetypeTextPlain, e.address, text)
+}
+
+// SignTypedData signs EIP-712 typed data (account_signTypedData)
+func (e *ClefSign) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	return e.client.SignTypedData(ctx, e.address, typedData)
+}
+
+// SetBatchConfig sets the parameters used by SignTxs
+func (e *ClefSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	e.batcher = batch.NewBatcher(cfg)
+}
+
+// SignHashes is not supported by Clef
+func (e *ClefSign) SignHashes(context.Context, []common.Hash) ([][]byte, error) {
+	return nil, ErrClefSignHashNotSupported
+}
+
+// SignTxs signs a batch of txs. Clef has no batch API so it's a bounded fan-out, each tx is approved by Clef
+func (e *ClefSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	return e.batcher.SignTxs(ctx, e, txs)
+}
+
+func (e *ClefSign) logPrefix() string {
+	return fmt.Sprintf("signer: %s[%s]: ", signertypes.MethodClef, e.name)
+}
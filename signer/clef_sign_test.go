@@ -0,0 +1,91 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/agglayer/go_signer/log"
+	"github.com/agglayer/go_signer/signer/clefclient"
+	"github.com/agglayer/go_signer/signer/mocks"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClefConfig(t *testing.T) {
+	cfg, err := NewClefConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodClef,
+		Config: map[string]any{"URL": "/root/.clef/clef.ipc"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ClefConfig{URL: "/root/.clef/clef.ipc", ApprovalTimeout: DefaultClefApprovalTimeout}, cfg)
+
+	_, err = NewClefConfig(signertypes.SignerConfig{Method: signertypes.MethodClef, Config: map[string]any{}})
+	require.ErrorIs(t, err, signertypes.ErrMissingConfigParam)
+
+	signer, err := NewSigner(context.TODO(), 1, signertypes.SignerConfig{
+		Method: signertypes.MethodClef,
+		Config: map[string]any{"URL": "http://localhost:8550", "ApprovalTimeout": "1m"},
+	}, "name", log.WithFields("test", "test"))
+	require.NoError(t, err)
+	require.IsType(t, &ClefSign{}, signer)
+}
+
+func TestClefSignInitialize(t *testing.T) {
+	ctx := context.TODO()
+	logger := log.WithFields("test", "test")
+	addr1 := common.HexToAddress("0x1")
+	addr2 := common.HexToAddress("0x2")
+
+	client := mocks.NewClefClienter(t)
+	client.EXPECT().AccountList(ctx).Return([]common.Address{addr1}, nil).Once()
+	sut := NewClefSign("name", logger, client, common.Address{}, 1)
+	require.NoError(t, sut.Initialize(ctx))
+	require.Equal(t, addr1, sut.PublicAddress())
+
+	client.EXPECT().AccountList(ctx).Return([]common.Address{addr1, addr2}, nil).Twice()
+	require.Error(t, NewClefSign("name", logger, client, common.Address{}, 1).Initialize(ctx))
+	require.ErrorIs(t, NewClefSign("name", logger, client, common.HexToAddress("0x3"), 1).Initialize(ctx),
+		ErrClefAccountNotFound)
+}
+
+func TestClefSign(t *testing.T) {
+	ctx := context.TODO()
+	addr := common.HexToAddress("0x1")
+	client := mocks.NewClefClienter(t)
+	sut := NewClefSign("name", log.WithFields("test", "test"), client, addr, 1337)
+
+	_, err := sut.SignHash(ctx, common.Hash{})
+	require.ErrorIs(t, err, ErrClefSignHashNotSupported)
+	_, err = sut.SignAuthorization(ctx, types.SetCodeAuthorization{})
+	require.ErrorIs(t, err, signertypes.ErrAuthorizationNotSupported)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(1337)), key)
+	require.NoError(t, err)
+	client.EXPECT().SignTransaction(ctx, addr, tx, big.NewInt(1337)).Return(signedTx, nil).Once()
+	signed, err := sut.SignTx(ctx, tx)
+	require.NoError(t, err)
+	require.Equal(t, signedTx, signed)
+	// Clef signs the legacy tx without replay protection
+	unprotected, err := types.SignTx(tx, types.HomesteadSigner{}, key)
+	require.NoError(t, err)
+	client.EXPECT().SignTransaction(ctx, addr, tx, big.NewInt(1337)).Return(unprotected, nil).Once()
+	_, err = sut.SignTx(ctx, tx)
+	var mismatch *signertypes.ChainIDMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, big.NewInt(1337), mismatch.Expected)
+	_, err = sut.SignTx(ctx, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 1}))
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+
+	client.EXPECT().SignData(ctx, clefclient.MimetypeTextPlain, addr, []byte("hello")).
+		Return(nil, clefclient.ErrRequestDenied).Once()
+	_, err = sut.SignText(ctx, []byte("hello"))
+	require.ErrorIs(t, err, clefclient.ErrRequestDenied)
+	require.Equal(t, ErrorClassPermission, ClassifyError(err))
+}
@@ -0,0 +1,267 @@
+package clefclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agglayer/go_signer/signer/remotesignerclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const (
+	// MimetypeTextPlain signs the data with the EIP-191 personal message prefix
+	MimetypeTextPlain = "text/plain"
+	// clefRequestDenied is the message returned by Clef if the request is rejected (by the operator or the rules)
+	clefRequestDenied = "request denied"
+)
+
+var (
+	// ErrRequestDenied is returned if Clef rejects the request (by the operator or the rules)
+	ErrRequestDenied = errors.New("clef: request denied")
+	// ErrApprovalTimeout is returned if the request is not approved before the approval timeout
+	ErrApprovalTimeout = errors.New("clef: approval timeout")
+	// ErrTxTypeNotSupported is returned for the tx types that Clef's account_signTransaction can't express
+	ErrTxTypeNotSupported = errors.New("clef: tx type not supported")
+	// ErrSignedTxMismatch is returned if the tx signed by Clef is not the requested one (the
+	// operator can edit it on the approval UI) or it's not signed by the requested account
+	ErrSignedTxMismatch = errors.New("clef: signed tx differs from the request")
+)
+
+// ClefClient is a client for Clef's external API (account_*), over HTTP or IPC.
+// A sign request blocks until it's approved (by the operator or the rules file),
+// so the sign calls use approvalTimeout instead of the deadline of a normal call
+type ClefClient struct {
+	endpoint        string
+	approvalTimeout time.Duration
+
+	mu     sync.Mutex
+	client *rpc.Client
+}
+
+// signTransactionResult is the response of account_signTransaction
+type signTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+// NewClefClient creates a client for the Clef at endpoint: http(s)://, unix:///path or an IPC path.
+// The connection is established on the first call. approvalTimeout is the max time waiting for
+// the approval of a sign request, 0 means wait until ctx is done
+func NewClefClient(endpoint string, approvalTimeout time.Duration) *ClefClient {
+	return &ClefClient{
+		endpoint:        endpoint,
+		approvalTimeout: approvalTimeout,
+	}
+}
+
+// Version returns the version of the external API of Clef
+func (c *ClefClient) Version(ctx context.Context) (string, error) {
+	var res string
+	if err := c.call(ctx, &res, "account_version"); err != nil {
+		return "", fmt.Errorf("account_version fails. Err: %w", err)
+	}
+	return res, nil
+}
+
+// AccountList returns the accounts of Clef (account_list)
+func (c *ClefClient) AccountList(ctx context.Context) ([]common.Address, error) {
+	var res []common.Address
+	if err := c.call(ctx, &res, "account_list"); err != nil {
+		return nil, fmt.Errorf("account_list fails. Err: %w", err)
+	}
+	return res, nil
+}
+
+// SignTransaction signs tx with the account from (account_signTransaction). chainID is used for
+// legacy txs, the typed txs use their own. The supported types are legacy, access list,
+// dynamic fee and blob (with sidecar). The signed tx must have the signing hash of tx and be
+// signed by from, otherwise it returns ErrSignedTxMismatch
+func (c *ClefClient) SignTransaction(ctx context.Context, from common.Address, tx *types.Transaction,
+	chainID *big.Int) (*types.Transaction, error) {
+	args, err := newSendTxArgs(from, tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	var res signTransactionResult
+	if err := c.approvalCall(ctx, &res, "account_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("account_signTransaction fails. Err: %w", err)
+	}
+	if res.Tx == nil {
+		return nil, fmt.Errorf("account_signTransaction: empty response")
+	}
+	if err := checkSignedTx(from, tx, res.Tx, chainID); err != nil {
+		return nil, fmt.Errorf("account_signTransaction: %w", err)
+	}
+	return res.Tx, nil
+}
+
+// checkSignedTx checks that signed is tx (same signing hash) signed by from
+func checkSignedTx(from common.Address, tx, signed *types.Transaction, chainID *big.Int) error {
+	txChainID := tx.ChainId()
+	if tx.Type() == types.LegacyTxType {
+		txChainID = chainID
+	}
+	signer := types.LatestSignerForChainID(txChainID)
+	if signed.Type() != tx.Type() || signer.Hash(signed) != signer.Hash(tx) {
+		return fmt.Errorf("%w: signing hash %s (type %d), requested %s (type %d)", ErrSignedTxMismatch,
+			signer.Hash(signed).Hex(), signed.Type(), signer.Hash(tx).Hex(), tx.Type())
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return fmt.Errorf("%w: can't recover the sender. Err: %w", ErrSignedTxMismatch, err)
+	}
+	if sender != from {
+		return fmt.Errorf("%w: signed by %s, requested %s", ErrSignedTxMismatch, sender.Hex(), from.Hex())
+	}
+	return nil
+}
+
+// SignData signs data with the account from (account_signData). Clef hashes data according
+// to mimeType (e.g. text/plain adds the EIP-191 personal prefix). V is 0/1
+func (c *ClefClient) SignData(ctx context.Context, mimeType string, from common.Address,
+	data []byte) ([]byte, error) {
+	var res hexutil.Bytes
+	address := common.NewMixedcaseAddress(from)
+	if err := c.approvalCall(ctx, &res, "account_signData", mimeType, &address, hexutil.Encode(data)); err != nil {
+		return nil, fmt.Errorf("account_signData fails. Err: %w", err)
+	}
+	return normalizeV(res)
+}
+
+// SignTypedData signs EIP-712 typed data with the account from (account_signTypedData). V is 0/1
+func (c *ClefClient) SignTypedData(ctx context.Context, from common.Address,
+	typedData apitypes.TypedData) ([]byte, error) {
+	var res hexutil.Bytes
+	address := common.NewMixedcaseAddress(from)
+	if err := c.approvalCall(ctx, &res, "account_signTypedData", &address, typedData); err != nil {
+		return nil, fmt.Errorf("account_signTypedData fails. Err: %w", err)
+	}
+	return normalizeV(res)
+}
+
+// Close closes the connection
+func (c *ClefClient) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil {
+		c.client.Close()
+		c.client = nil
+	}
+}
+
+// approvalCall is a call that can wait for the approval of the request
+func (c *ClefClient) approvalCall(ctx context.Context, result interface{}, method string,
+	args ...interface{}) error {
+	if c.approvalTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.approvalTimeout)
+		defer cancel()
+	}
+	err := c.call(ctx, result, method, args...)
+	if err != nil && errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w (%s): %w", ErrApprovalTimeout, c.approvalTimeout, err)
+	}
+	return err
+}
+
+func (c *ClefClient) call(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	client, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	err = client.CallContext(ctx, result, method, args...)
+	if err != nil && strings.Contains(err.Error(), clefRequestDenied) {
+		return fmt.Errorf("%w: %w", ErrRequestDenied, err)
+	}
+	return err
+}
+
+func (c *ClefClient) connect(ctx context.Context) (*rpc.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil {
+		return c.client, nil
+	}
+	endpoint := c.endpoint
+	if ipcPath := remotesignerclient.IPCPath(endpoint); ipcPath != "" {
+		endpoint = ipcPath
+	}
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to clef %s. Err: %w", c.endpoint, err)
+	}
+	c.client = client
+	return client, nil
+}
+
+func newSendTxArgs(from common.Address, tx *types.Transaction, chainID *big.Int) (*apitypes.SendTxArgs, error) {
+	data := hexutil.Bytes(tx.Data())
+	var to *common.MixedcaseAddress
+	if tx.To() != nil {
+		t := common.NewMixedcaseAddress(*tx.To())
+		to = &t
+	}
+	args := &apitypes.SendTxArgs{
+		From:  common.NewMixedcaseAddress(from),
+		To:    to,
+		Gas:   hexutil.Uint64(tx.Gas()),
+		Value: hexutil.Big(*tx.Value()),
+		Nonce: hexutil.Uint64(tx.Nonce()),
+		Input: &data,
+	}
+	if chainID != nil && chainID.Sign() != 0 {
+		args.ChainID = (*hexutil.Big)(chainID)
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		return args, nil
+	case types.AccessListTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	case types.BlobTxType:
+		sidecar := tx.BlobTxSidecar()
+		if sidecar == nil {
+			return nil, fmt.Errorf("%w: blob tx without sidecar", ErrTxTypeNotSupported)
+		}
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		args.BlobFeeCap = (*hexutil.Big)(tx.BlobGasFeeCap())
+		args.BlobHashes = tx.BlobHashes()
+		args.Blobs = sidecar.Blobs
+		args.Commitments = sidecar.Commitments
+		args.Proofs = sidecar.Proofs
+	default:
+		return nil, fmt.Errorf("%w: type %d", ErrTxTypeNotSupported, tx.Type())
+	}
+	// The typed txs carry their own chain ID
+	if tx.ChainId().Sign() != 0 {
+		args.ChainID = (*hexutil.Big)(tx.ChainId())
+	}
+	accessList := tx.AccessList()
+	args.AccessList = &accessList
+	return args, nil
+}
+
+// normalizeV converts V from 27/28 (as Clef returns) to 0/1 (as the other signers)
+func normalizeV(signature []byte) ([]byte, error) {
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	return signature, nil
+}
@@ -0,0 +1,198 @@
+package clefclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestDenied = errors.New("request denied")
+
+// fakeClef implements the account_* API of Clef with a private key
+type fakeClef struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+	// approvalDelay simulates a request waiting for the approval of the operator
+	approvalDelay time.Duration
+	// edit simulates the operator editing the tx on the approval UI
+	edit func(args *apitypes.SendTxArgs)
+}
+
+func newFakeClef(t *testing.T) *fakeClef {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &fakeClef{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (f *fakeClef) Version() string {
+	return "6.1.0"
+}
+
+func (f *fakeClef) List() []common.Address {
+	return []common.Address{f.address}
+}
+
+func (f *fakeClef) SignTransaction(args apitypes.SendTxArgs, _ *string) (*signTransactionResult, error) {
+	time.Sleep(f.approvalDelay)
+	if args.From.Address() != f.address {
+		return nil, errTestDenied
+	}
+	if f.edit != nil {
+		f.edit(&args)
+	}
+	tx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID((*big.Int)(args.ChainID)), f.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &signTransactionResult{Raw: raw, Tx: signed}, nil
+}
+
+func (f *fakeClef) SignData(contentType string, addr common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if contentType != MimetypeTextPlain || addr.Address() != f.address {
+		return nil, errTestDenied
+	}
+	signature, err := crypto.Sign(accounts.TextHash(data), f.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27 // Clef returns V as 27/28
+	return signature, nil
+}
+
+func startFakeClef(t *testing.T, fake *fakeClef) (ipcPath, httpURL string) {
+	t.Helper()
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", fake))
+	// The socket path has a max length, so it can't be under the long t.TempDir()
+	dir, err := os.MkdirTemp("", "clef")
+	require.NoError(t, err)
+	ipcPath = filepath.Join(dir, "clef.ipc")
+	listener, err := net.Listen("unix", ipcPath)
+	require.NoError(t, err)
+	go server.ServeListener(listener) //nolint:errcheck
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		listener.Close()
+		server.Stop()
+		os.RemoveAll(dir)
+	})
+	return ipcPath, httpServer.URL
+}
+
+func TestClefClient(t *testing.T) {
+	fake := newFakeClef(t)
+	ipcPath, httpURL := startFakeClef(t, fake)
+	ctx := context.Background()
+	chainID := big.NewInt(1337)
+	to := common.HexToAddress("0x1234")
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(1)}),
+		types.NewTx(&types.AccessListTx{ChainID: chainID, Nonce: 2, GasPrice: big.NewInt(1), Gas: 21000, To: &to,
+			Value: big.NewInt(1)}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(2), Gas: 21000, To: &to, Value: big.NewInt(1), Data: []byte{1, 2}}),
+	}
+	for _, endpoint := range []string{ipcPath, "unix://" + ipcPath, httpURL} {
+		sut := NewClefClient(endpoint, time.Minute)
+		version, err := sut.Version(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "6.1.0", version)
+		list, err := sut.AccountList(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{fake.address}, list)
+
+		for _, tx := range txs {
+			signed, err := sut.SignTransaction(ctx, fake.address, tx, chainID)
+			require.NoError(t, err, "endpoint %s, tx type %d", endpoint, tx.Type())
+			require.Equal(t, tx.Type(), signed.Type())
+			from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			require.NoError(t, err)
+			require.Equal(t, fake.address, from)
+		}
+
+		signature, err := sut.SignData(ctx, MimetypeTextPlain, fake.address, []byte("hello"))
+		require.NoError(t, err)
+		pub, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), signature)
+		require.NoError(t, err)
+		require.Equal(t, fake.address, crypto.PubkeyToAddress(*pub))
+
+		_, err = sut.SignData(ctx, MimetypeTextPlain, common.Address{}, []byte("hello"))
+		require.ErrorIs(t, err, ErrRequestDenied)
+		sut.Close()
+	}
+}
+
+func TestClefClientSignedTxMismatch(t *testing.T) {
+	fake := newFakeClef(t)
+	_, httpURL := startFakeClef(t, fake)
+	sut := NewClefClient(httpURL, time.Minute)
+	defer sut.Close()
+	ctx := context.Background()
+	chainID := big.NewInt(1337)
+	to := common.HexToAddress("0x1234")
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2), Gas: 21000, To: &to, Value: big.NewInt(1)})
+
+	// The operator changes the value
+	fake.edit = func(args *apitypes.SendTxArgs) { args.Value = hexutil.Big(*big.NewInt(1000)) }
+	_, err := sut.SignTransaction(ctx, fake.address, tx, chainID)
+	require.ErrorIs(t, err, ErrSignedTxMismatch)
+
+	// Signed with another chain ID
+	fake.edit = func(args *apitypes.SendTxArgs) { args.ChainID = (*hexutil.Big)(big.NewInt(1)) }
+	_, err = sut.SignTransaction(ctx, fake.address, tx, chainID)
+	require.ErrorIs(t, err, ErrSignedTxMismatch)
+
+	// Signed by another key
+	fake.edit = nil
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	fake.key = otherKey
+	_, err = sut.SignTransaction(ctx, fake.address, tx, chainID)
+	require.ErrorIs(t, err, ErrSignedTxMismatch)
+	require.ErrorContains(t, err, crypto.PubkeyToAddress(otherKey.PublicKey).Hex())
+}
+
+func TestClefClientApprovalTimeout(t *testing.T) {
+	fake := newFakeClef(t)
+	fake.approvalDelay = 200 * time.Millisecond
+	_, httpURL := startFakeClef(t, fake)
+	sut := NewClefClient(httpURL, 20*time.Millisecond)
+	defer sut.Close()
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+	_, err := sut.SignTransaction(context.Background(), fake.address, tx, big.NewInt(1))
+	require.ErrorIs(t, err, ErrApprovalTimeout)
+}
+
+func TestClefClientTxTypeNotSupported(t *testing.T) {
+	sut := NewClefClient("http://localhost:8550", time.Minute)
+	tx := types.NewTx(&types.BlobTx{})
+	_, err := sut.SignTransaction(context.Background(), common.Address{}, tx, big.NewInt(1))
+	require.ErrorIs(t, err, ErrTxTypeNotSupported)
+}
@@ -0,0 +1,80 @@
+package erc4337
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// vOffset is added to V (0/1) because the accounts recover the signer with ecrecover (27/28)
+const vOffset = 27
+
+// Option sets an optional parameter of UserOpSigner
+type Option func(*UserOpSigner)
+
+// WithEIP191 signs the userOpHash with the EIP-191 personal message prefix
+// ("\x19Ethereum Signed Message:\n32"), as expected by many smart accounts (e.g. SimpleAccount)
+func WithEIP191() Option {
+	return func(s *UserOpSigner) {
+		s.eip191 = true
+	}
+}
+
+// UserOpSigner signs ERC-4337 user operations for an EntryPoint and chain with any HashSigner
+// (e.g. a KMS key created with signer.NewSigner)
+type UserOpSigner struct {
+	signer     signertypes.HashSigner
+	entryPoint common.Address
+	chainID    *big.Int
+	eip191     bool
+}
+
+// NewUserOpSigner creates a UserOpSigner for the EntryPoint at entryPoint (EntryPointV06,
+// EntryPointV07 or a custom deployment) on chainID
+func NewUserOpSigner(signer signertypes.HashSigner, entryPoint common.Address, chainID uint64,
+	opts ...Option) *UserOpSigner {
+	res := &UserOpSigner{
+		signer:     signer,
+		entryPoint: entryPoint,
+		chainID:    new(big.Int).SetUint64(chainID),
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	return res
+}
+
+// UserOpHash returns the userOpHash of op
+func (s *UserOpSigner) UserOpHash(op UserOp) common.Hash {
+	return op.Hash(s.entryPoint, s.chainID)
+}
+
+// DigestToSign returns the hash that is signed for op: the userOpHash, with the EIP-191 prefix
+// if WithEIP191 is set
+func (s *UserOpSigner) DigestToSign(op UserOp) common.Hash {
+	hash := s.UserOpHash(op)
+	if s.eip191 {
+		return common.BytesToHash(accounts.TextHash(hash.Bytes()))
+	}
+	return hash
+}
+
+// SignUserOp returns the signature [R || S || V] of op, with V 27/28. It doesn't set op.Signature
+func (s *UserOpSigner) SignUserOp(ctx context.Context, op UserOp) ([]byte, error) {
+	signature, err := s.signer.SignHash(ctx, s.DigestToSign(op))
+	if err != nil {
+		return nil, fmt.Errorf("erc4337: can't sign userOp. Err: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("erc4337: invalid signature length %d", len(signature))
+	}
+	if signature[crypto.RecoveryIDOffset] < vOffset {
+		signature[crypto.RecoveryIDOffset] += vOffset
+	}
+	return signature, nil
+}
@@ -0,0 +1,51 @@
+package erc4337
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// keySigner signs raw hashes with a private key
+type keySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (k keySigner) SignHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash.Bytes(), k.key)
+}
+
+func TestSignUserOp(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	op := &UserOperationV07{Sender: common.HexToAddress("0x1234"), Nonce: big.NewInt(1)}
+	ctx := context.Background()
+
+	for _, eip191 := range []bool{false, true} {
+		var opts []Option
+		if eip191 {
+			opts = append(opts, WithEIP191())
+		}
+		sut := NewUserOpSigner(keySigner{key: key}, EntryPointV07, 10, opts...)
+		signature, err := sut.SignUserOp(ctx, op)
+		require.NoError(t, err)
+		require.Contains(t, []byte{27, 28}, signature[crypto.RecoveryIDOffset])
+
+		digest := sut.UserOpHash(op)
+		if eip191 {
+			digest = common.BytesToHash(accounts.TextHash(digest.Bytes()))
+		}
+		require.Equal(t, digest, sut.DigestToSign(op))
+		signature[crypto.RecoveryIDOffset] -= 27
+		pub, err := crypto.SigToPub(digest.Bytes(), signature)
+		require.NoError(t, err)
+		require.Equal(t, address, crypto.PubkeyToAddress(*pub))
+	}
+}
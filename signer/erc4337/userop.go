@@ -0,0 +1,172 @@
+package erc4337
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	wordLength = 32
+	// gasFieldLength is the length of each gas field packed on a bytes32 (uint128)
+	gasFieldLength = 16
+)
+
+var (
+	// EntryPointV06 is the canonical address of the EntryPoint v0.6
+	EntryPointV06 = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	// EntryPointV07 is the canonical address of the EntryPoint v0.7
+	EntryPointV07 = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+)
+
+// UserOp is a user operation that can compute its userOpHash for an EntryPoint
+type UserOp interface {
+	// Hash returns the userOpHash: keccak256(abi.encode(keccak256(pack(op)), entryPoint, chainId))
+	Hash(entryPoint common.Address, chainID *big.Int) common.Hash
+}
+
+// UserOperationV06 is the user operation of the EntryPoint v0.6
+type UserOperationV06 struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+var _ UserOp = (*UserOperationV06)(nil)
+
+// Hash returns the userOpHash of the EntryPoint v0.6 (the signature is not part of it)
+func (op *UserOperationV06) Hash(entryPoint common.Address, chainID *big.Int) common.Hash {
+	packed := crypto.Keccak256Hash(
+		addressWord(op.Sender),
+		uintWord(op.Nonce),
+		crypto.Keccak256(op.InitCode),
+		crypto.Keccak256(op.CallData),
+		uintWord(op.CallGasLimit),
+		uintWord(op.VerificationGasLimit),
+		uintWord(op.PreVerificationGas),
+		uintWord(op.MaxFeePerGas),
+		uintWord(op.MaxPriorityFeePerGas),
+		crypto.Keccak256(op.PaymasterAndData),
+	)
+	return userOpHash(packed, entryPoint, chainID)
+}
+
+// PackedUserOperation is the user operation of the EntryPoint v0.7 as it's sent on chain
+type PackedUserOperation struct {
+	Sender   common.Address
+	Nonce    *big.Int
+	InitCode []byte
+	CallData []byte
+	// AccountGasLimits is verificationGasLimit (uint128) || callGasLimit (uint128)
+	AccountGasLimits   common.Hash
+	PreVerificationGas *big.Int
+	// GasFees is maxPriorityFeePerGas (uint128) || maxFeePerGas (uint128)
+	GasFees          common.Hash
+	PaymasterAndData []byte
+	Signature        []byte
+}
+
+var _ UserOp = (*PackedUserOperation)(nil)
+
+// Hash returns the userOpHash of the EntryPoint v0.7 (the signature is not part of it)
+func (op *PackedUserOperation) Hash(entryPoint common.Address, chainID *big.Int) common.Hash {
+	packed := crypto.Keccak256Hash(
+		addressWord(op.Sender),
+		uintWord(op.Nonce),
+		crypto.Keccak256(op.InitCode),
+		crypto.Keccak256(op.CallData),
+		op.AccountGasLimits.Bytes(),
+		uintWord(op.PreVerificationGas),
+		op.GasFees.Bytes(),
+		crypto.Keccak256(op.PaymasterAndData),
+	)
+	return userOpHash(packed, entryPoint, chainID)
+}
+
+// UserOperationV07 is the user operation of the EntryPoint v0.7 with the fields unpacked,
+// as it's used by the bundler RPC (eth_sendUserOperation)
+type UserOperationV07 struct {
+	Sender                        common.Address
+	Nonce                         *big.Int
+	Factory                       *common.Address
+	FactoryData                   []byte
+	CallData                      []byte
+	CallGasLimit                  *big.Int
+	VerificationGasLimit          *big.Int
+	PreVerificationGas            *big.Int
+	MaxFeePerGas                  *big.Int
+	MaxPriorityFeePerGas          *big.Int
+	Paymaster                     *common.Address
+	PaymasterVerificationGasLimit *big.Int
+	PaymasterPostOpGasLimit       *big.Int
+	PaymasterData                 []byte
+	Signature                     []byte
+}
+
+var _ UserOp = (*UserOperationV07)(nil)
+
+// Pack returns the PackedUserOperation of op
+func (op *UserOperationV07) Pack() *PackedUserOperation {
+	var initCode []byte
+	if op.Factory != nil {
+		initCode = append(op.Factory.Bytes(), op.FactoryData...)
+	}
+	var paymasterAndData []byte
+	if op.Paymaster != nil {
+		paymasterAndData = append(op.Paymaster.Bytes(), uint128Bytes(op.PaymasterVerificationGasLimit)...)
+		paymasterAndData = append(paymasterAndData, uint128Bytes(op.PaymasterPostOpGasLimit)...)
+		paymasterAndData = append(paymasterAndData, op.PaymasterData...)
+	}
+	return &PackedUserOperation{
+		Sender:             op.Sender,
+		Nonce:              op.Nonce,
+		InitCode:           initCode,
+		CallData:           op.CallData,
+		AccountGasLimits:   packUint128(op.VerificationGasLimit, op.CallGasLimit),
+		PreVerificationGas: op.PreVerificationGas,
+		GasFees:            packUint128(op.MaxPriorityFeePerGas, op.MaxFeePerGas),
+		PaymasterAndData:   paymasterAndData,
+		Signature:          op.Signature,
+	}
+}
+
+// Hash returns the userOpHash of the EntryPoint v0.7 (the signature is not part of it)
+func (op *UserOperationV07) Hash(entryPoint common.Address, chainID *big.Int) common.Hash {
+	return op.Pack().Hash(entryPoint, chainID)
+}
+
+func userOpHash(packed common.Hash, entryPoint common.Address, chainID *big.Int) common.Hash {
+	return crypto.Keccak256Hash(packed.Bytes(), addressWord(entryPoint), uintWord(chainID))
+}
+
+func addressWord(addr common.Address) []byte {
+	return common.LeftPadBytes(addr.Bytes(), wordLength)
+}
+
+// uintWord returns the ABI encoding of an uint256, nil is 0
+func uintWord(n *big.Int) []byte {
+	if n == nil {
+		return make([]byte, wordLength)
+	}
+	return math.U256Bytes(new(big.Int).Set(n))
+}
+
+// uint128Bytes returns n as 16 bytes big endian (it's truncated as solidity does on uint128(n))
+func uint128Bytes(n *big.Int) []byte {
+	return uintWord(n)[wordLength-gasFieldLength:]
+}
+
+// packUint128 returns high (uint128) || low (uint128)
+func packUint128(high, low *big.Int) common.Hash {
+	return common.BytesToHash(append(uint128Bytes(high), uint128Bytes(low)...))
+}
@@ -0,0 +1,105 @@
+package erc4337
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func abiArguments(t *testing.T, types ...string) abi.Arguments {
+	t.Helper()
+	res := make(abi.Arguments, len(types))
+	for i, typ := range types {
+		abiType, err := abi.NewType(typ, "", nil)
+		require.NoError(t, err)
+		res[i] = abi.Argument{Type: abiType}
+	}
+	return res
+}
+
+// expectedUserOpHash computes the userOpHash with the abi encoder, as the EntryPoint does
+func expectedUserOpHash(t *testing.T, packed []byte, entryPoint common.Address, chainID *big.Int) common.Hash {
+	t.Helper()
+	encoded, err := abiArguments(t, "bytes32", "address", "uint256").
+		Pack(crypto.Keccak256Hash(packed), entryPoint, chainID)
+	require.NoError(t, err)
+	return crypto.Keccak256Hash(encoded)
+}
+
+func keccak(data []byte) [32]byte {
+	return crypto.Keccak256Hash(data)
+}
+
+func TestUserOperationV06Hash(t *testing.T) {
+	op := &UserOperationV06{
+		Sender:               common.HexToAddress("0x1234"),
+		Nonce:                big.NewInt(7),
+		InitCode:             []byte{1, 2, 3},
+		CallData:             []byte{4, 5},
+		CallGasLimit:         big.NewInt(100000),
+		VerificationGasLimit: big.NewInt(200000),
+		PreVerificationGas:   big.NewInt(50000),
+		MaxFeePerGas:         big.NewInt(3e9),
+		MaxPriorityFeePerGas: big.NewInt(1e9),
+		PaymasterAndData:     []byte{6},
+		Signature:            []byte{0xff},
+	}
+	packed, err := abiArguments(t, "address", "uint256", "bytes32", "bytes32", "uint256", "uint256", "uint256",
+		"uint256", "uint256", "bytes32").Pack(op.Sender, op.Nonce, keccak(op.InitCode), keccak(op.CallData),
+		op.CallGasLimit, op.VerificationGasLimit, op.PreVerificationGas, op.MaxFeePerGas, op.MaxPriorityFeePerGas,
+		keccak(op.PaymasterAndData))
+	require.NoError(t, err)
+	chainID := big.NewInt(11155111)
+	require.Equal(t, expectedUserOpHash(t, packed, EntryPointV06, chainID), op.Hash(EntryPointV06, chainID))
+	// The signature is not part of the hash
+	hash := op.Hash(EntryPointV06, chainID)
+	op.Signature = nil
+	require.Equal(t, hash, op.Hash(EntryPointV06, chainID))
+	require.NotEqual(t, hash, op.Hash(EntryPointV07, chainID))
+}
+
+func TestUserOperationV07Hash(t *testing.T) {
+	factory := common.HexToAddress("0xfac7")
+	paymaster := common.HexToAddress("0x9a7a")
+	op := &UserOperationV07{
+		Sender:                        common.HexToAddress("0x1234"),
+		Nonce:                         big.NewInt(7),
+		Factory:                       &factory,
+		FactoryData:                   []byte{1, 2, 3},
+		CallData:                      []byte{4, 5},
+		CallGasLimit:                  big.NewInt(100000),
+		VerificationGasLimit:          big.NewInt(200000),
+		PreVerificationGas:            big.NewInt(50000),
+		MaxFeePerGas:                  big.NewInt(3e9),
+		MaxPriorityFeePerGas:          big.NewInt(1e9),
+		Paymaster:                     &paymaster,
+		PaymasterVerificationGasLimit: big.NewInt(30000),
+		PaymasterPostOpGasLimit:       big.NewInt(10000),
+		PaymasterData:                 []byte{6},
+	}
+	packedOp := op.Pack()
+	require.Equal(t, append(factory.Bytes(), 1, 2, 3), packedOp.InitCode)
+	require.Equal(t, common.HexToHash("0x00000000000000000000000000030d40000000000000000000000000000186a0"),
+		packedOp.AccountGasLimits)
+	require.Equal(t, common.HexToHash("0x0000000000000000000000003b9aca00000000000000000000000000b2d05e00"),
+		packedOp.GasFees)
+	require.Equal(t, common.FromHex("0x0000000000000000000000000000000000009a7a"+
+		"00000000000000000000000000007530"+"00000000000000000000000000002710"+"06"), packedOp.PaymasterAndData)
+
+	packed, err := abiArguments(t, "address", "uint256", "bytes32", "bytes32", "bytes32", "uint256", "bytes32",
+		"bytes32").Pack(op.Sender, op.Nonce, keccak(packedOp.InitCode), keccak(op.CallData),
+		[32]byte(packedOp.AccountGasLimits), op.PreVerificationGas, [32]byte(packedOp.GasFees),
+		keccak(packedOp.PaymasterAndData))
+	require.NoError(t, err)
+	chainID := big.NewInt(1)
+	require.Equal(t, expectedUserOpHash(t, packed, EntryPointV07, chainID), op.Hash(EntryPointV07, chainID))
+	require.Equal(t, packedOp.Hash(EntryPointV07, chainID), op.Hash(EntryPointV07, chainID))
+
+	// Without factory and paymaster initCode and paymasterAndData are empty
+	require.Empty(t, (&UserOperationV07{}).Pack().InitCode)
+	require.Empty(t, (&UserOperationV07{}).Pack().PaymasterAndData)
+}
@@ -0,0 +1,177 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/agglayer/go_signer/signer/clefclient"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorClass is the category of an error returned by a signer backend
+type ErrorClass string
+
+const (
+	ErrorClassNone        ErrorClass = "none"
+	ErrorClassThrottled   ErrorClass = "throttled"
+	ErrorClassUnavailable ErrorClass = "unavailable"
+	ErrorClassTimeout     ErrorClass = "timeout"
+	ErrorClassConnection  ErrorClass = "connection"
+	ErrorClassPermission  ErrorClass = "permission"
+	ErrorClassInvalid     ErrorClass = "invalid"
+	ErrorClassCanceled    ErrorClass = "canceled"
+	ErrorClassUnknown     ErrorClass = "unknown"
+)
+
+const (
+	// rpcErrorCodeLimitExceeded is the JSON-RPC code used by nodes / signers for rate limits
+	rpcErrorCodeLimitExceeded = -32005
+	// rpcErrorCodeMethodNotFound is the JSON-RPC code of a method that the server doesn't implement
+	rpcErrorCodeMethodNotFound = -32601
+)
+
+// Retryable returns true if an error of this class is transient, so it makes sense to retry
+func (c ErrorClass) Retryable() bool {
+	switch c {
+	case ErrorClassThrottled, ErrorClassUnavailable, ErrorClassTimeout, ErrorClassConnection:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c ErrorClass) String() string {
+	return string(c)
+}
+
+// awsAPIError is implemented by AWS SDK errors (smithy.APIError)
+type awsAPIError interface {
+	ErrorCode() string
+}
+
+// httpStatusCodeError is implemented by AWS SDK http errors and remotesignerclient.HTTPStatusError
+type httpStatusCodeError interface {
+	HTTPStatusCode() int
+}
+
+// rpcCodeError is implemented by remotesignerclient.RPCError
+type rpcCodeError interface {
+	RPCErrorCode() int
+}
+
+// ClassifyError returns the class of an error returned by GCP KMS, AWS KMS, a remote signer or Clef
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+	if errors.Is(err, context.Canceled) {
+		return ErrorClassCanceled
+	}
+	if errors.Is(err, clefclient.ErrRequestDenied) {
+		return ErrorClassPermission
+	}
+	if class := classifyGRPC(err); class != ErrorClassUnknown {
+		return class
+	}
+	var awsErr awsAPIError
+	if errors.As(err, &awsErr) {
+		if class := classifyAWSCode(awsErr.ErrorCode()); class != ErrorClassUnknown {
+			return class
+		}
+	}
+	var httpErr httpStatusCodeError
+	if errors.As(err, &httpErr) {
+		if class := classifyHTTPStatus(httpErr.HTTPStatusCode()); class != ErrorClassUnknown {
+			return class
+		}
+	}
+	var rpcErr rpcCodeError
+	if errors.As(err, &rpcErr) && rpcErr.RPCErrorCode() == rpcErrorCodeLimitExceeded {
+		return ErrorClassThrottled
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorClassTimeout
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return ErrorClassConnection
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return ErrorClassConnection
+	}
+	return ErrorClassUnknown
+}
+
+// IsRetryableError returns true if the error is transient (throttling, 5xx, connection reset...)
+func IsRetryableError(err error) bool {
+	return ClassifyError(err).Retryable()
+}
+
+func classifyGRPC(err error) ErrorClass {
+	st, ok := status.FromError(err)
+	if !ok || st == nil {
+		return ErrorClassUnknown
+	}
+	switch st.Code() {
+	case codes.ResourceExhausted:
+		return ErrorClassThrottled
+	case codes.Unavailable, codes.Internal, codes.Aborted:
+		return ErrorClassUnavailable
+	case codes.DeadlineExceeded:
+		return ErrorClassTimeout
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return ErrorClassPermission
+	case codes.NotFound, codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return ErrorClassInvalid
+	case codes.Canceled:
+		return ErrorClassCanceled
+	default:
+		return ErrorClassUnknown
+	}
+}
+
+func classifyAWSCode(code string) ErrorClass {
+	switch code {
+	case "ThrottlingException", "Throttling", "TooManyRequestsException",
+		"LimitExceededException", "RequestLimitExceeded":
+		return ErrorClassThrottled
+	case "KMSInternalException", "DependencyTimeoutException", "KeyUnavailableException",
+		"ServiceUnavailable", "InternalFailure":
+		return ErrorClassUnavailable
+	case "AccessDeniedException", "UnrecognizedClientException", "InvalidSignatureException",
+		"ExpiredTokenException":
+		return ErrorClassPermission
+	case "NotFoundException", "DisabledException", "KMSInvalidStateException",
+		"InvalidKeyUsageException", "IncorrectKeyException", "ValidationException", "InvalidArnException":
+		return ErrorClassInvalid
+	default:
+		return ErrorClassUnknown
+	}
+}
+
+func classifyHTTPStatus(code int) ErrorClass {
+	switch {
+	case code == http.StatusTooManyRequests:
+		return ErrorClassThrottled
+	case code == http.StatusRequestTimeout || code == http.StatusGatewayTimeout:
+		return ErrorClassTimeout
+	case code >= http.StatusInternalServerError:
+		return ErrorClassUnavailable
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrorClassPermission
+	case code >= http.StatusBadRequest:
+		return ErrorClassInvalid
+	default:
+		return ErrorClassUnknown
+	}
+}
@@ -0,0 +1,58 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/agglayer/go_signer/signer/remotesignerclient"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testAWSError struct {
+	code string
+}
+
+func (e *testAWSError) Error() string     { return "aws: " + e.code }
+func (e *testAWSError) ErrorCode() string { return e.code }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorClass
+	}{
+		{"nil", nil, ErrorClassNone},
+		{"canceled", fmt.Errorf("wrap: %w", context.Canceled), ErrorClassCanceled},
+		{"deadline", context.DeadlineExceeded, ErrorClassTimeout},
+		{"grpc resource exhausted",
+			fmt.Errorf("GCP KMS sign request failed: %w", status.Error(codes.ResourceExhausted, "quota")),
+			ErrorClassThrottled},
+		{"grpc unavailable", status.Error(codes.Unavailable, "down"), ErrorClassUnavailable},
+		{"grpc permission denied", status.Error(codes.PermissionDenied, "no"), ErrorClassPermission},
+		{"grpc not found", status.Error(codes.NotFound, "no key"), ErrorClassInvalid},
+		{"aws throttling",
+			fmt.Errorf("aws kms sign request failed: %w", &testAWSError{code: "ThrottlingException"}),
+			ErrorClassThrottled},
+		{"aws access denied", &testAWSError{code: "AccessDeniedException"}, ErrorClassPermission},
+		{"aws disabled key", &testAWSError{code: "DisabledException"}, ErrorClassInvalid},
+		{"http 503", &remotesignerclient.HTTPStatusError{StatusCode: 503}, ErrorClassUnavailable},
+		{"http 429", &remotesignerclient.HTTPStatusError{StatusCode: 429}, ErrorClassThrottled},
+		{"http 403", &remotesignerclient.HTTPStatusError{StatusCode: 403}, ErrorClassPermission},
+		{"rpc limit exceeded", &remotesignerclient.RPCError{Code: -32005}, ErrorClassThrottled},
+		{"rpc other", &remotesignerclient.RPCError{Code: -32000}, ErrorClassUnknown},
+		{"connection reset", fmt.Errorf("read: %w", syscall.ECONNRESET), ErrorClassConnection},
+		{"unknown", errors.New("bad key"), ErrorClassUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, ClassifyError(tt.err))
+		})
+	}
+	require.True(t, IsRetryableError(status.Error(codes.ResourceExhausted, "quota")))
+	require.False(t, IsRetryableError(status.Error(codes.PermissionDenied, "no")))
+}
@@ -3,9 +3,12 @@ package signer
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 
 	signercommon "github.com/agglayer/go_signer/common"
 	"github.com/agglayer/go_signer/signer/opsigneradapter"
+	"github.com/agglayer/go_signer/signer/remotesignerclient"
 	"github.com/agglayer/go_signer/signer/types"
 )
 
@@ -13,16 +16,33 @@ var (
 	ErrUnknownSignerMethod = fmt.Errorf("unknown signer method")
 )
 
+// batchConfigurable is implemented by the signers that use BatchConfig on SignHashes / SignTxs
+type batchConfigurable interface {
+	SetBatchConfig(types.BatchConfig)
+}
+
+// memoryLockable is implemented by the signers that hold a private key in memory
+type memoryLockable interface {
+	SetMemoryLock(bool)
+}
+
+// NewSigner validates cfg (see ValidateConfig) and creates a signer based on cfg.Method. chainID is the
+// default chain of SignTx, 0 means that the typed txs use their own (see types.TxSigner.SignTxForChain).
+// The optional parameters (e.g. WithMetricsRegisterer) are set using opts
 func NewSigner(ctx context.Context, chainID uint64, cfg types.SignerConfig, name string,
-	logger signercommon.Logger) (types.Signer, error) {
+	logger signercommon.Logger, opts ...SignerOption) (types.Signer, error) {
 	var (
 		res types.Signer
 		err error
 	)
+	options := newSignerOptions(opts)
 	if cfg.Method == "" {
 		logger.Warnf("No signer method specified, defaulting to local (keystore file)")
 		cfg.Method = types.MethodLocal
 	}
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, fmt.Errorf("signer %s: invalid config. Err: %w", name, err)
+	}
 	switch cfg.Method {
 	case types.MethodNone:
 		res = &NoneSign{}
@@ -32,12 +52,49 @@ func NewSigner(ctx context.Context, chainID uint64, cfg types.SignerConfig, name
 			return nil, err
 		}
 		res = NewLocalSign(name, logger, specificCfg, chainID)
+	case types.MethodHD:
+		specificCfg, err := NewHDConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		res, err = NewHDSign(name, logger, specificCfg, chainID)
+		if err != nil {
+			return nil, err
+		}
+	case types.MethodShamir:
+		specificCfg, err := NewShamirConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		res, err = NewShamirSign(name, logger, specificCfg, chainID)
+		if err != nil {
+			return nil, err
+		}
 	case types.MethodRemoteSigner:
 		specificCfg, err := NewRemoteSignerConfig(cfg)
 		if err != nil {
 			return nil, err
 		}
-		res = NewRemoteSignerSignFromConfig(name, logger, specificCfg)
+		var clientOpts []remotesignerclient.Option
+		if options.tracerProvider != nil {
+			clientOpts = append(clientOpts, remotesignerclient.WithTracerProvider(options.tracerProvider))
+		}
+		res, err = NewRemoteSignerSignFromConfigForChain(name, logger, specificCfg, chainID, clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+	case types.MethodClef:
+		specificCfg, err := NewClefConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		res = NewClefSignFromConfig(name, logger, specificCfg, chainID)
+	case types.MethodTSS:
+		specificCfg, err := NewTSSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		res = NewTSSSign(name, logger, specificCfg, chainID)
 	case types.MethodGCPKMS:
 		res, err = opsigneradapter.NewSignerAdapterFromConfig(ctx, logger, cfg, chainID)
 		if err != nil {
@@ -57,5 +114,83 @@ func NewSigner(ctx context.Context, chainID uint64, cfg types.SignerConfig, name
 	default:
 		return nil, fmt.Errorf("unknown signer method %s", cfg.Method)
 	}
+	if options.memoryLock {
+		if lockable, ok := res.(memoryLockable); ok {
+			lockable.SetMemoryLock(true)
+		}
+	}
+	if needsRetry(cfg.Method) {
+		retryCfg, err := NewRetryConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		res = NewRetrySign(name, logger, res, keyID(cfg), retryCfg)
+	}
+	if options.auditSink != nil {
+		res = NewAuditSign(cfg.Method, name, res, options.auditSink)
+	}
+	if options.metricsRegisterer != nil {
+		metrics, err := NewSignerMetrics(options.metricsRegisterer)
+		if err != nil {
+			return nil, err
+		}
+		res = NewMetricsSign(cfg.Method, name, res, metrics)
+	}
+	if options.tracerProvider != nil {
+		res = NewTracingSign(cfg.Method, name, keyID(cfg), res, options.tracerProvider)
+	}
+	if configurable, ok := res.(batchConfigurable); ok {
+		batchCfg, err := types.NewBatchConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		configurable.SetBatchConfig(batchCfg)
+	}
 	return res, nil
 }
+
+// needsRetry returns true for the methods that call a remote service, so they are wrapped by RetrySign
+func needsRetry(method types.SignMethod) bool {
+	return method == types.MethodGCPKMS || method == types.MethodAWSKMS || method == types.MethodRemoteSigner
+}
+
+// keyID returns a identifier of the key used by a signer without secrets. It's used
+// to share the rate limit between signers and on traces
+func keyID(cfg types.SignerConfig) string {
+	var id string
+	switch cfg.Method {
+	case types.MethodGCPKMS, types.MethodAWSKMS:
+		id, _ = cfg.Get(opsigneradapter.FieldKeyName)
+	case types.MethodRemoteSigner, types.MethodClef:
+		rawURL, _ := cfg.Get(FieldURL)
+		address, _ := cfg.Get(FieldAddress)
+		id = redactURL(rawURL) + "/" + address
+	case types.MethodLocal:
+		id, _ = cfg.Get(FieldPath)
+		if address, _ := cfg.Get(FieldAddress); address != "" {
+			id += "/" + address
+		}
+	case types.MethodHD:
+		id, _ = cfg.Get(FieldDerivationPath)
+	case types.MethodShamir:
+		if shamirCfg, err := NewShamirConfig(cfg); err == nil {
+			id = strings.Join(shamirCfg.Shares, ",")
+		}
+	case types.MethodTSS:
+		id, _ = cfg.Get(FieldShareFile)
+	}
+	return cfg.Method.String() + "/" + id
+}
+
+// redactURL returns the scheme, host and path of rawURL, so the credentials of the
+// userinfo (user:pass@) and the query (e.g. ?token=) are not included
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	if u.Scheme == "" {
+		return u.Path
+	}
+	return u.Scheme + "://" + u.Host + u.Path
+}
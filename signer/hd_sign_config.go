@@ -0,0 +1,132 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/hdwallet"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// FieldMnemonic is the BIP-39 mnemonic inline on the config
+	FieldMnemonic = "Mnemonic"
+	// FieldMnemonicEnv is the name of the environment variable that contains the mnemonic
+	FieldMnemonicEnv = "MnemonicEnv"
+	// FieldMnemonicFile is the path of a mnemonic encrypted with hdwallet.WriteMnemonicFile
+	FieldMnemonicFile = "MnemonicFile"
+	// FieldMnemonicPassword is the password of FieldMnemonicFile
+	FieldMnemonicPassword = "MnemonicPassword"
+	// FieldPassphrase is the optional BIP-39 passphrase
+	FieldPassphrase = "Passphrase"
+	// FieldDerivationPath is the BIP-32 path of the key (default m/44'/60'/0'/0/0)
+	FieldDerivationPath = "DerivationPath"
+)
+
+var (
+	ErrHDMnemonicSource = errors.New("exactly one of Mnemonic, MnemonicEnv or MnemonicFile must be set")
+)
+
+// HDConfig is the specific config for MethodHD
+type HDConfig struct {
+	Mnemonic       string
+	Passphrase     string
+	DerivationPath accounts.DerivationPath
+}
+
+func (c HDConfig) String() string {
+	return fmt.Sprintf("HDConfig{Mnemonic: ***, DerivationPath: %s}", c.DerivationPath.String())
+}
+
+// NewHDSignerConfig creates a generic config (SignerConfig) for an inline mnemonic
+func NewHDSignerConfig(mnemonic, derivationPath string) signertypes.SignerConfig {
+	return signertypes.SignerConfig{
+		Method: signertypes.MethodHD,
+		Config: map[string]interface{}{
+			FieldMnemonic:       mnemonic,
+			FieldDerivationPath: derivationPath,
+		},
+	}
+}
+
+// NewHDConfig creates a HDConfig from a SignerConfig. The mnemonic is read from one
+// of the sources: inline (Mnemonic), environment (MnemonicEnv) or encrypted file (MnemonicFile)
+func NewHDConfig(cfg signertypes.SignerConfig) (HDConfig, error) {
+	res := HDConfig{
+		DerivationPath: append(accounts.DerivationPath{}, accounts.DefaultBaseDerivationPath...),
+	}
+	mnemonic, err := hdMnemonic(cfg)
+	if err != nil {
+		return res, err
+	}
+	res.Mnemonic = mnemonic
+	if res.Passphrase, err = getOptional(cfg, FieldPassphrase, "", cfg.Get); err != nil {
+		return res, err
+	}
+	pathStr, err := getOptional(cfg, FieldDerivationPath, "", cfg.Get)
+	if err != nil {
+		return res, err
+	}
+	if pathStr != "" {
+		if res.DerivationPath, err = accounts.ParseDerivationPath(pathStr); err != nil {
+			return res, fmt.Errorf("config %s: field %s is not a valid derivation path. Err: %w: %w",
+				cfg.Method, FieldDerivationPath, signertypes.ErrBadConfigParams, err)
+		}
+	}
+	return res, nil
+}
+
+func hdMnemonic(cfg signertypes.SignerConfig) (string, error) {
+	inline, err := getOptional(cfg, FieldMnemonic, "", cfg.Get)
+	if err != nil {
+		return "", err
+	}
+	env, err := getOptional(cfg, FieldMnemonicEnv, "", cfg.Get)
+	if err != nil {
+		return "", err
+	}
+	file, err := getOptional(cfg, FieldMnemonicFile, "", cfg.Get)
+	if err != nil {
+		return "", err
+	}
+	sources := 0
+	for _, source := range []string{inline, env, file} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return "", fmt.Errorf("config %s: %w. Err: %w", cfg.Method, ErrHDMnemonicSource, signertypes.ErrBadConfigParams)
+	}
+	switch {
+	case env != "":
+		mnemonic, ok := os.LookupEnv(env)
+		if !ok || mnemonic == "" {
+			return "", fmt.Errorf("config %s: environment variable %s is not set. Err: %w",
+				cfg.Method, env, signertypes.ErrMissingConfigParam)
+		}
+		return mnemonic, nil
+	case file != "":
+		password, err := getOptional(cfg, FieldMnemonicPassword, "", cfg.Get)
+		if err != nil {
+			return "", err
+		}
+		return hdwallet.ReadMnemonicFile(file, password)
+	}
+	return inline, nil
+}
+
+// NewHDSign derives the key of cfg and creates a LocalSign with it
+func NewHDSign(name string, logger signercommon.Logger, cfg HDConfig, chainID uint64) (*LocalSign, error) {
+	privateKey, err := hdwallet.DerivePrivateKeyFromMnemonic(cfg.Mnemonic, cfg.Passphrase, cfg.DerivationPath)
+	if err != nil {
+		return nil, fmt.Errorf("signer %s: can't derive key %s. Err: %w", name, cfg.DerivationPath.String(), err)
+	}
+	logger.Infof("signer %s: derived key %s address %s", name, cfg.DerivationPath.String(),
+		crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	return NewLocalSignFromPrivateKey(name, logger, privateKey, chainID), nil
+}
@@ -0,0 +1,71 @@
+package signer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/agglayer/go_signer/log"
+	"github.com/agglayer/go_signer/signer/hdwallet"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testHDMnemonic = "test test test test test test test test test test test junk"
+
+func TestNewHDConfig(t *testing.T) {
+	t.Run("default derivation path", func(t *testing.T) {
+		cfg, err := NewHDConfig(NewHDSignerConfig(testHDMnemonic, ""))
+		require.NoError(t, err)
+		require.Equal(t, "m/44'/60'/0'/0/0", cfg.DerivationPath.String())
+		require.NotContains(t, cfg.String(), "junk")
+	})
+	t.Run("no mnemonic source", func(t *testing.T) {
+		_, err := NewHDConfig(signertypes.SignerConfig{Method: signertypes.MethodHD, Config: map[string]any{}})
+		require.ErrorIs(t, err, ErrHDMnemonicSource)
+	})
+	t.Run("two mnemonic sources", func(t *testing.T) {
+		cfg := NewHDSignerConfig(testHDMnemonic, "")
+		cfg.Config[FieldMnemonicEnv] = "MNEMONIC"
+		_, err := NewHDConfig(cfg)
+		require.ErrorIs(t, err, ErrHDMnemonicSource)
+	})
+	t.Run("bad derivation path", func(t *testing.T) {
+		_, err := NewHDConfig(NewHDSignerConfig(testHDMnemonic, "m/44'/x"))
+		require.ErrorIs(t, err, signertypes.ErrBadConfigParams)
+	})
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv("TEST_HD_MNEMONIC", testHDMnemonic)
+		cfg, err := NewHDConfig(signertypes.SignerConfig{
+			Method: signertypes.MethodHD,
+			Config: map[string]any{FieldMnemonicEnv: "TEST_HD_MNEMONIC"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, testHDMnemonic, cfg.Mnemonic)
+	})
+	t.Run("encrypted file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mnemonic.json")
+		require.NoError(t, hdwallet.WriteMnemonicFile(path, testHDMnemonic, "secret"))
+		cfg, err := NewHDConfig(signertypes.SignerConfig{
+			Method: signertypes.MethodHD,
+			Config: map[string]any{
+				FieldMnemonicFile:     path,
+				FieldMnemonicPassword: "secret",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, testHDMnemonic, cfg.Mnemonic)
+	})
+}
+
+func TestNewSignerHD(t *testing.T) {
+	ctx := context.TODO()
+	cfg := NewHDSignerConfig(testHDMnemonic, "m/44'/60'/0'/0/3")
+	sut, err := NewSigner(ctx, 1, cfg, "test", log.WithFields("test", "test"))
+	require.NoError(t, err)
+	require.NoError(t, sut.Initialize(ctx))
+	require.Equal(t, common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906"), sut.PublicAddress())
+	_, ok := sut.(*LocalSign)
+	require.True(t, ok)
+}
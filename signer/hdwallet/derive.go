@@ -0,0 +1,109 @@
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// masterKeySeed is the HMAC key used to derive the master key (BIP-32)
+	masterKeySeed = "Bitcoin seed"
+	keyLength     = 32
+	// HardenedOffset is added to the index of hardened derivations (the ' on paths)
+	HardenedOffset = 0x80000000
+)
+
+var (
+	ErrInvalidMnemonic = errors.New("hdwallet: invalid mnemonic")
+	// ErrInvalidChildKey is returned in the (very unlikely) case that a derived key is not valid,
+	// BIP-32 says that the next index must be used instead
+	ErrInvalidChildKey = errors.New("hdwallet: invalid derived key")
+)
+
+// extendedKey is a BIP-32 private extended key
+type extendedKey struct {
+	key       *big.Int
+	chainCode []byte
+}
+
+// NewSeed returns the BIP-39 seed for mnemonic and the optional passphrase
+func NewSeed(mnemonic, passphrase string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMnemonic, err.Error())
+	}
+	return seed, nil
+}
+
+// DerivePrivateKey derives the private key of path (e.g. m/44'/60'/0'/0/3) from a BIP-39 seed
+func DerivePrivateKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key, err := newMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range path {
+		if key, err = key.child(index); err != nil {
+			return nil, fmt.Errorf("can't derive %s. Err: %w", path.String(), err)
+		}
+	}
+	return crypto.ToECDSA(key.key.FillBytes(make([]byte, keyLength)))
+}
+
+// DerivePrivateKeyFromMnemonic derives the private key of path from a BIP-39 mnemonic
+func DerivePrivateKeyFromMnemonic(mnemonic, passphrase string,
+	path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return DerivePrivateKey(seed, path)
+}
+
+func newMasterKey(seed []byte) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte(masterKeySeed))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key := new(big.Int).SetBytes(sum[:keyLength])
+	if key.Sign() == 0 || key.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, ErrInvalidChildKey
+	}
+	return &extendedKey{key: key, chainCode: sum[keyLength:]}, nil
+}
+
+// child returns the child private key at index (CKDpriv on BIP-32)
+func (k *extendedKey) child(index uint32) (*extendedKey, error) {
+	data := make([]byte, 0, 1+keyLength+4)
+	if index >= HardenedOffset {
+		// Hardened: 0x00 || ser256(k)
+		data = append(data, 0)
+		data = append(data, k.key.FillBytes(make([]byte, keyLength))...)
+	} else {
+		// Normal: serP(point(k))
+		x, y := crypto.S256().ScalarBaseMult(k.key.FillBytes(make([]byte, keyLength)))
+		data = append(data, crypto.CompressPubkey(&ecdsa.PublicKey{Curve: crypto.S256(), X: x, Y: y})...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:keyLength])
+	if il.Cmp(n) >= 0 {
+		return nil, ErrInvalidChildKey
+	}
+	key := il.Add(il, k.key)
+	key.Mod(key, n)
+	if key.Sign() == 0 {
+		return nil, ErrInvalidChildKey
+	}
+	return &extendedKey{key: key, chainCode: sum[keyLength:]}, nil
+}
@@ -0,0 +1,50 @@
+package hdwallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// testMnemonic is the well known mnemonic of hardhat / anvil dev accounts
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestDerivePrivateKeyFromMnemonic(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"m/44'/60'/0'/0/0", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{"m/44'/60'/0'/0/1", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{"m/44'/60'/0'/0/3", "0x90F79bf6EB2c4f870365E785982E1f101E93b906"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := accounts.ParseDerivationPath(tt.path)
+			require.NoError(t, err)
+			key, err := DerivePrivateKeyFromMnemonic(testMnemonic, "", path)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, crypto.PubkeyToAddress(key.PublicKey).Hex())
+		})
+	}
+}
+
+func TestDerivePrivateKeyInvalidMnemonic(t *testing.T) {
+	_, err := DerivePrivateKeyFromMnemonic("test test test", "", accounts.DefaultBaseDerivationPath)
+	require.ErrorIs(t, err, ErrInvalidMnemonic)
+}
+
+func TestMnemonicFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mnemonic.json")
+	require.NoError(t, WriteMnemonicFile(path, testMnemonic, "secret"))
+
+	mnemonic, err := ReadMnemonicFile(path, "secret")
+	require.NoError(t, err)
+	require.Equal(t, testMnemonic, mnemonic)
+
+	_, err = ReadMnemonicFile(path, "wrong")
+	require.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package hdwallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+const filePermissions = 0o600
+
+// EncryptMnemonic encrypts mnemonic with password using the keystore v3 scheme (scrypt + AES-128-CTR)
+func EncryptMnemonic(mnemonic, password string) ([]byte, error) {
+	cryptoJSON, err := keystore.EncryptDataV3([]byte(mnemonic), []byte(password),
+		keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("hdwallet: can't encrypt mnemonic. Err: %w", err)
+	}
+	return json.Marshal(cryptoJSON)
+}
+
+// DecryptMnemonic decrypts the output of EncryptMnemonic
+func DecryptMnemonic(data []byte, password string) (string, error) {
+	var cryptoJSON keystore.CryptoJSON
+	if err := json.Unmarshal(data, &cryptoJSON); err != nil {
+		return "", fmt.Errorf("hdwallet: encrypted mnemonic has a bad format. Err: %w", err)
+	}
+	mnemonic, err := keystore.DecryptDataV3(cryptoJSON, password)
+	if err != nil {
+		return "", fmt.Errorf("hdwallet: can't decrypt mnemonic. Err: %w", err)
+	}
+	return string(mnemonic), nil
+}
+
+// WriteMnemonicFile stores mnemonic encrypted with password on path
+func WriteMnemonicFile(path, mnemonic, password string) error {
+	data, err := EncryptMnemonic(mnemonic, password)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(path), data, filePermissions)
+}
+
+// ReadMnemonicFile reads a mnemonic file created by WriteMnemonicFile
+func ReadMnemonicFile(path, password string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("hdwallet: can't read mnemonic file %s. Err: %w", path, err)
+	}
+	mnemonic, err := DecryptMnemonic(data, password)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(mnemonic), nil
+}
@@ -5,8 +5,10 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"sync"
 
 	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/batch"
 	signertypes "github.com/agglayer/go_signer/signer/types"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -17,6 +19,8 @@ import (
 const (
 	FieldPath     = "path"
 	FieldPassword = "password"
+	// FieldPasswords are the per-account passwords (address -> password) for a keystore directory
+	FieldPasswords = "passwords"
 	// expectedLengthSignatureToSign is the expected length that VerifySignature expects
 	// signature should have the 64 byte [R || S] format. (so without V)
 	expectedLengthSignatureToVerify = 64
@@ -24,18 +28,30 @@ const (
 
 var (
 	ErrNoPrivateKey = fmt.Errorf("private key is nil")
+	// ErrKeystoreDirAddress is returned if Path is a keystore directory with several accounts and no Address
+	ErrKeystoreDirAddress = fmt.Errorf("keystore directory has several accounts, field Address is required")
 )
 
 // LocalSign is a signer that uses a local keystore file
 type LocalSign struct {
+	// mu protects the key: the sign calls hold it for reading while they use the key, so
+	// Close (that wipes it) waits for them
+	mu            sync.RWMutex
 	name          string
 	logger        signercommon.Logger
 	file          signercommon.KeystoreFileConfig
 	privateKey    *ecdsa.PrivateKey
 	publicAddress common.Address
+	// keystoreDir is set if file.Path is a keystore directory
+	keystoreDir *signercommon.KeystoreDir
 
 	chainID uint64
 	auth    *bind.TransactOpts
+	batcher *batch.Batcher
+
+	// memoryLock enables locking the key in RAM on Initialize, keyLocked is true once it's locked
+	memoryLock bool
+	keyLocked  bool
 }
 
 // NewLocalSignerConfig creates a generic config  (SignerConfig)
@@ -57,17 +73,19 @@ func NewLocalConfig(cfg signertypes.SignerConfig) (signercommon.KeystoreFileConf
 	if len(cfg.Config) == 0 {
 		return signercommon.KeystoreFileConfig{}, nil
 	}
-	pathStr, ok := cfg.Config[FieldPath].(string)
-	if !ok {
-		return res, fmt.Errorf("field path is not string %v", cfg.Config[FieldPath])
+	if err := cfg.Decode(&res); err != nil {
+		return signercommon.KeystoreFileConfig{}, err
 	}
-	passStr, ok := cfg.Config[FieldPassword].(string)
-	if !ok {
-		return res, fmt.Errorf("field pass is not string")
+	if _, err := cfg.Get(FieldPath); err != nil {
+		return signercommon.KeystoreFileConfig{}, fmt.Errorf("config %s: field %s. Err: %w", cfg.Method, FieldPath, err)
+	}
+	if _, err := cfg.Get(FieldPassword); err != nil && res.Passwords == nil {
+		return signercommon.KeystoreFileConfig{}, fmt.Errorf("config %s: field %s. Err: %w",
+			cfg.Method, FieldPassword, err)
 	}
-	res = signercommon.KeystoreFileConfig{
-		Path:     pathStr,
-		Password: passStr,
+	if res.Address != "" && !common.IsHexAddress(res.Address) {
+		return signercommon.KeystoreFileConfig{}, fmt.Errorf("config %s: field %s is not an address %s. Err: %w",
+			cfg.Method, FieldAddress, res.Address, signertypes.ErrBadConfigParams)
 	}
 	return res, nil
 }
@@ -84,10 +102,12 @@ func NewLocalSign(name string, logger signercommon.Logger,
 		logger:  logger,
 		file:    file,
 		chainID: chainID,
+		batcher: batch.NewBatcher(signertypes.DefaultBatchConfig()),
 	}
 }
 
-// NewLocalSignFromPrivateKey creates a new LocalSign based on a private key
+// NewLocalSignFromPrivateKey creates a new LocalSign based on a private key. The signer owns
+// privateKey: Close wipes it
 func NewLocalSignFromPrivateKey(name string,
 	logger signercommon.Logger,
 	privateKey *ecdsa.PrivateKey,
@@ -98,25 +118,79 @@ func NewLocalSignFromPrivateKey(name string,
 		privateKey:    privateKey,
 		publicAddress: crypto.PubkeyToAddress(privateKey.PublicKey),
 		chainID:       chainID,
+		batcher:       batch.NewBatcher(signertypes.DefaultBatchConfig()),
 	}
 }
 
 // Initialize initializes the LocalSign, read key if needed
 func (e *LocalSign) Initialize(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	if err := e.initializeKey(); err != nil {
 		return fmt.Errorf("%s Initialize failed key: %w", e.logPrefix(), err)
 	}
 	if err := e.initializeAuth(); err != nil {
 		return fmt.Errorf("%s Initialize failed auth: %w", e.logPrefix(), err)
 	}
+	if err := e.lockKey(); err != nil {
+		return fmt.Errorf("%s Initialize failed memory lock: %w", e.logPrefix(), err)
+	}
+	return nil
+}
+
+// SetMemoryLock enables locking the key in RAM (mlock) on Initialize, so it's never written
+// to swap. It's only supported on Linux, on other systems Initialize fails
+func (e *LocalSign) SetMemoryLock(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.memoryLock = enabled
+}
+
+// lockKey locks the key in RAM if it's enabled by SetMemoryLock. Must be called with mu locked
+func (e *LocalSign) lockKey() error {
+	if !e.memoryLock || e.keyLocked {
+		return nil
+	}
+	if err := signercommon.LockKey(e.privateKey); err != nil {
+		return err
+	}
+	e.keyLocked = true
 	return nil
 }
 
+// Close unlocks and wipes the private key and closes the keystore directory (wiping its keys).
+// The passwords of the config are dropped. It waits for the sign calls in progress, the next ones
+// return ErrNoPrivateKey
+func (e *LocalSign) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var err error
+	if e.keyLocked {
+		if err = signercommon.UnlockKey(e.privateKey); err != nil {
+			err = fmt.Errorf("%s Close. Err: %w", e.logPrefix(), err)
+		}
+		e.keyLocked = false
+	}
+	if e.keystoreDir != nil {
+		e.keystoreDir.Close()
+		e.keystoreDir = nil
+	} else {
+		signercommon.ZeroKey(e.privateKey)
+	}
+	e.privateKey = nil
+	e.auth = nil
+	e.file.WipePasswords()
+	return err
+}
+
 func (e *LocalSign) initializeKey() error {
 	// Check if it's already initialized
 	if e.privateKey != nil {
 		return nil
 	}
+	if signercommon.IsKeystoreDir(e.file.Path) {
+		return e.initializeKeyFromDir()
+	}
 	privateKey, err := signercommon.NewKeyFromKeystore(e.file)
 	if err != nil {
 		return fmt.Errorf("%s initializeKey fails. Err: %w", e.logPrefix(), err)
@@ -126,10 +200,64 @@ func (e *LocalSign) initializeKey() error {
 	}
 	e.privateKey = privateKey
 	e.publicAddress = crypto.PubkeyToAddress(privateKey.PublicKey)
+	// The key is decrypted, the passwords are not needed anymore
+	e.file.WipePasswords()
+	return nil
+}
+
+// initializeKeyFromDir opens the keystore directory and selects the account of file.Address
+// (it can be omitted if there is only 1 account)
+func (e *LocalSign) initializeKeyFromDir() error {
+	dir, err := signercommon.NewKeystoreDir(e.file, e.logger)
+	if err != nil {
+		return fmt.Errorf("%s initializeKey fails. Err: %w", e.logPrefix(), err)
+	}
+	var address common.Address
+	if e.file.Address != "" {
+		address = common.HexToAddress(e.file.Address)
+	} else {
+		accounts := dir.Accounts()
+		if len(accounts) != 1 {
+			dir.Close()
+			return fmt.Errorf("%s initializeKey: %d accounts on %s. Err: %w",
+				e.logPrefix(), len(accounts), e.file.Path, ErrKeystoreDirAddress)
+		}
+		address = accounts[0]
+	}
+	privateKey, err := dir.Key(address)
+	if err != nil {
+		dir.Close()
+		return fmt.Errorf("%s initializeKey fails. Err: %w", e.logPrefix(), err)
+	}
+	e.keystoreDir = dir
+	e.privateKey = privateKey
+	e.publicAddress = address
+	// The keystore directory keeps its own copy of the passwords to unlock the added accounts
+	e.file.WipePasswords()
+	return nil
+}
+
+// KeystoreDir returns the keystore directory if Path is a directory (nil otherwise). It gives
+// access to the other unlocked accounts of the directory
+func (e *LocalSign) KeystoreDir() *signercommon.KeystoreDir {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.keystoreDir
+}
+
+// checkAccount fails if the account has been removed from the keystore directory.
+// Must be called with mu locked (for reading)
+func (e *LocalSign) checkAccount() error {
+	if e.keystoreDir != nil && !e.keystoreDir.Has(e.publicAddress) {
+		return fmt.Errorf("%s account %s has been removed from %s. Err: %w",
+			e.logPrefix(), e.publicAddress.Hex(), e.file.Path, signercommon.ErrAccountNotFound)
+	}
 	return nil
 }
 
 func (e *LocalSign) IsInitialized() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	return e.privateKey != nil && e.auth != nil
 }
 
@@ -150,14 +278,21 @@ func (e *LocalSign) initializeAuth() error {
 
 // SignHash signs a hash
 func (e *LocalSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	if e.privateKey == nil {
 		return nil, fmt.Errorf("%s SignHash  Err: %w", e.logPrefix(), ErrNoPrivateKey)
 	}
+	if err := e.checkAccount(); err != nil {
+		return nil, err
+	}
 	return crypto.Sign(hash.Bytes(), e.privateKey)
 }
 
 // Verify a signature
 func (e *LocalSign) Verify(hash common.Hash, signature []byte) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	if e.privateKey == nil {
 		return fmt.Errorf("%s Verify Err: %w", e.logPrefix(), ErrNoPrivateKey)
 	}
@@ -176,6 +311,8 @@ func (e *LocalSign) Verify(hash common.Hash, signature []byte) error {
 }
 
 func (e *LocalSign) PublicAddress() common.Address {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	return e.publicAddress
 }
 
@@ -183,12 +320,15 @@ func (e *LocalSign) String() string {
 	if e == nil {
 		return "LocalSign{nil}"
 	}
-	if e.IsInitialized() {
+	initialized := e.IsInitialized()
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if initialized {
 		return fmt.Sprintf("%s initialized:%t path:%s, pubAddr: %s",
-			e.logPrefix(), e.IsInitialized(), e.file, e.publicAddress.String())
+			e.logPrefix(), initialized, e.file, e.publicAddress.String())
 	} else {
 		return fmt.Sprintf("%s initialized:%t path:%s, pubAddr: ???",
-			e.logPrefix(), e.IsInitialized(), e.file)
+			e.logPrefix(), initialized, e.file)
 	}
 }
 
@@ -197,13 +337,59 @@ func (e *LocalSign) logPrefix() string {
 }
 
 func (e *LocalSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return e.SignTxForChain(ctx, 0, tx)
+}
+
+// SignTxForChain signs tx for chainID (0 means the chainID of the signer or, if it's not set, the one of the tx)
+func (e *LocalSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.privateKey == nil {
+		return nil, fmt.Errorf("%s can't signTx. Err: %w", e.logPrefix(), ErrNoPrivateKey)
+	}
 	if e.auth == nil {
 		return nil, fmt.Errorf("%s can't signTx because auth is nil", e.logPrefix())
 	}
+	if err := e.checkAccount(); err != nil {
+		return nil, err
+	}
+	if chainID == 0 {
+		chainID = e.chainID
+	}
+	chainID, err := signertypes.ResolveTxChainID(tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("%s can't signTx. Err: %w", e.logPrefix(), err)
+	}
 
-	signedTx, err := e.auth.Signer(e.publicAddress, tx)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(new(big.Int).SetUint64(chainID)), e.privateKey)
 	if err != nil {
-		return nil, fmt.Errorf("%s can't signTx because auth.Signer returns error %w", e.logPrefix(), err)
+		return nil, fmt.Errorf("%s can't signTx because types.SignTx returns error %w", e.logPrefix(), err)
 	}
 	return signedTx, nil
 }
+
+// SignAuthorization signs an EIP-7702 authorization
+func (e *LocalSign) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	signed, err := signertypes.SignAuthorizationWithHash(ctx, e, e.chainID, auth)
+	if err != nil {
+		return types.SetCodeAuthorization{}, fmt.Errorf("%s can't signAuthorization. Err: %w", e.logPrefix(), err)
+	}
+	return signed, nil
+}
+
+// SetBatchConfig sets the parameters used by SignHashes and SignTxs
+func (e *LocalSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	e.batcher = batch.NewBatcher(cfg)
+}
+
+// SignHashes signs a batch of hashes
+func (e *LocalSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	return e.batcher.SignHashes(ctx, e, hashes)
+}
+
+// SignTxs signs a batch of txs
+func (e *LocalSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	return e.batcher.SignTxs(ctx, e, txs)
+}
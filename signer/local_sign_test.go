@@ -2,13 +2,21 @@ package signer
 
 import (
 	"context"
+	"errors"
+	"math/big"
+	"os"
+	"sync"
 	"testing"
+	"time"
 
 	signercommon "github.com/agglayer/go_signer/common"
 	"github.com/agglayer/go_signer/log"
 	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 )
 
@@ -81,3 +89,232 @@ func TestNewLocalSignEmpty(t *testing.T) {
 	require.Error(t, err)
 	require.NotEmpty(t, sut.String())
 }
+
+func TestLocalSignBatch(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sut := NewLocalSignFromPrivateKey("name", log.WithFields("test", "test"), privateKey, 1)
+	sut.SetBatchConfig(signertypes.BatchConfig{Concurrency: 2})
+	ctx := context.TODO()
+	require.NoError(t, sut.Initialize(ctx))
+	hashes := []common.Hash{
+		crypto.Keccak256Hash([]byte("a")),
+		crypto.Keccak256Hash([]byte("b")),
+		crypto.Keccak256Hash([]byte("c")),
+	}
+	signatures, err := sut.SignHashes(ctx, hashes)
+	require.NoError(t, err)
+	require.Len(t, signatures, len(hashes))
+	for i, hash := range hashes {
+		require.NoError(t, sut.Verify(hash, signatures[i]))
+	}
+	txs := []*types.Transaction{
+		types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 1}),
+		types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 2}),
+	}
+	signedTxs, err := sut.SignTxs(ctx, txs)
+	require.NoError(t, err)
+	for i, signedTx := range signedTxs {
+		sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), signedTx)
+		require.NoError(t, err)
+		require.Equal(t, sut.PublicAddress(), sender)
+		require.Equal(t, txs[i].Nonce(), signedTx.Nonce())
+	}
+}
+
+func TestLocalSignTxChainIDMismatch(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sut := NewLocalSignFromPrivateKey("name", log.WithFields("test", "test"), privateKey, 1)
+	ctx := context.TODO()
+	require.NoError(t, sut.Initialize(ctx))
+	_, err = sut.SignTx(ctx, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(2), Nonce: 1}))
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+	var mismatch *signertypes.ChainIDMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, big.NewInt(1), mismatch.Expected)
+	require.Equal(t, big.NewInt(2), mismatch.Got)
+}
+
+func TestLocalSignTxForChain(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	// No default chain ID: the typed txs use their own
+	sut := NewLocalSignFromPrivateKey("name", log.WithFields("test", "test"), privateKey, 0)
+	ctx := context.TODO()
+	require.NoError(t, sut.Initialize(ctx))
+	for _, chainID := range []int64{1, 10, 1101} {
+		signed, err := sut.SignTx(ctx, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(chainID), Nonce: 1}))
+		require.NoError(t, err)
+		sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(chainID)), signed)
+		require.NoError(t, err)
+		require.Equal(t, sut.PublicAddress(), sender)
+
+		signed, err = sut.SignTxForChain(ctx, uint64(chainID), types.NewTx(&types.LegacyTx{Nonce: 1}))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(chainID), signed.ChainId())
+	}
+	_, err = sut.SignTx(ctx, types.NewTx(&types.LegacyTx{Nonce: 1}))
+	require.ErrorIs(t, err, signertypes.ErrChainIDRequired)
+	_, err = sut.SignTxForChain(ctx, 2, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1)}))
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+}
+
+func TestLocalSignAuthorization(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sut := NewLocalSignFromPrivateKey("name", log.WithFields("test", "test"), privateKey, 1)
+	ctx := context.TODO()
+	require.NoError(t, sut.Initialize(ctx))
+
+	auth, err := sut.SignAuthorization(ctx, types.SetCodeAuthorization{
+		ChainID: *uint256.NewInt(1), Address: common.HexToAddress("0x1234"), Nonce: 1})
+	require.NoError(t, err)
+	authority, err := auth.Authority()
+	require.NoError(t, err)
+	require.Equal(t, sut.PublicAddress(), authority)
+
+	tx := types.NewTx(&types.SetCodeTx{ChainID: uint256.NewInt(1), Nonce: 2, GasTipCap: uint256.NewInt(1),
+		GasFeeCap: uint256.NewInt(2), Gas: 50000, AuthList: []types.SetCodeAuthorization{auth}})
+	signed, err := sut.SignTx(ctx, tx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), signed)
+	require.NoError(t, err)
+	require.Equal(t, sut.PublicAddress(), sender)
+
+	_, err = sut.SignAuthorization(ctx, types.SetCodeAuthorization{ChainID: *uint256.NewInt(2)})
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+}
+
+func TestLocalSignClose(t *testing.T) {
+	ctx := context.TODO()
+	account, err := keystore.StoreKey(t.TempDir(), "pass", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	sut := NewLocalSign("name", log.WithFields("test", "test"),
+		signercommon.KeystoreFileConfig{Path: account.URL.Path, Password: "pass"}, 1)
+	require.NoError(t, sut.Initialize(ctx))
+	// The password is dropped once the key is decrypted
+	require.Empty(t, sut.file.Password)
+	key := sut.privateKey
+
+	require.NoError(t, sut.Close())
+	require.Zero(t, key.D.Sign())
+	require.False(t, sut.IsInitialized())
+	_, err = sut.SignHash(ctx, common.Hash{})
+	require.ErrorIs(t, err, ErrNoPrivateKey)
+	_, err = sut.SignTx(ctx, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1)}))
+	require.ErrorIs(t, err, ErrNoPrivateKey)
+	require.NoError(t, sut.Close())
+}
+
+func TestLocalSignCloseWhileSigning(t *testing.T) {
+	ctx := context.TODO()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sut := NewLocalSignFromPrivateKey("name", log.WithFields("test", "test"), key, 1)
+	require.NoError(t, sut.Initialize(ctx))
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1)})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				// Each call signs with the whole key or fails with ErrNoPrivateKey
+				if signature, err := sut.SignHash(ctx, common.Hash{1}); err == nil {
+					pub, err := crypto.SigToPub(common.Hash{1}.Bytes(), signature)
+					require.NoError(t, err)
+					require.Equal(t, sut.PublicAddress(), crypto.PubkeyToAddress(*pub))
+				} else {
+					require.ErrorIs(t, err, ErrNoPrivateKey)
+				}
+				if _, err := sut.SignTxForChain(ctx, 1, tx); err != nil {
+					require.ErrorIs(t, err, ErrNoPrivateKey)
+				}
+			}
+		}()
+	}
+	require.NoError(t, sut.Close())
+	wg.Wait()
+}
+
+func TestLocalSignKeystoreDir(t *testing.T) {
+	dir := t.TempDir()
+	account1, err := keystore.StoreKey(dir, "pass1", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	account2, err := keystore.StoreKey(dir, "pass2", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	logger := log.WithFields("test", "test")
+	ctx := context.TODO()
+	passwords := map[string]any{
+		account1.Address.Hex(): "pass1",
+		account2.Address.Hex(): "pass2",
+	}
+
+	t.Run("address is required with several accounts", func(t *testing.T) {
+		cfg, err := NewLocalConfig(signertypes.SignerConfig{
+			Method: signertypes.MethodLocal,
+			Config: map[string]any{FieldPath: dir, FieldPasswords: passwords},
+		})
+		require.NoError(t, err)
+		err = NewLocalSign("name", logger, cfg, 1).Initialize(ctx)
+		require.ErrorIs(t, err, ErrKeystoreDirAddress)
+	})
+	t.Run("locked account", func(t *testing.T) {
+		cfg := NewLocalSignerConfig(dir, "pass1")
+		cfg.Config[FieldAddress] = account2.Address.Hex()
+		localCfg, err := NewLocalConfig(cfg)
+		require.NoError(t, err)
+		err = NewLocalSign("name", logger, localCfg, 1).Initialize(ctx)
+		require.ErrorIs(t, err, signercommon.ErrAccountLocked)
+	})
+	t.Run("per-account passwords", func(t *testing.T) {
+		cfg, err := NewLocalConfig(signertypes.SignerConfig{
+			Method: signertypes.MethodLocal,
+			Config: map[string]any{
+				FieldPath:      dir,
+				FieldAddress:   account2.Address.Hex(),
+				FieldPasswords: passwords,
+			},
+		})
+		require.NoError(t, err)
+		sut := NewLocalSign("name", logger, cfg, 1)
+		require.NoError(t, sut.Initialize(ctx))
+		defer sut.Close()
+		require.Equal(t, account2.Address, sut.PublicAddress())
+		require.ElementsMatch(t, []common.Address{account1.Address, account2.Address}, sut.KeystoreDir().Unlocked())
+		_, err = sut.SignHash(ctx, common.Hash{})
+		require.NoError(t, err)
+
+		// The removed account can't sign anymore
+		require.NoError(t, os.Remove(account2.URL.Path))
+		require.Eventually(t, func() bool {
+			_, err := sut.SignHash(ctx, common.Hash{})
+			return errors.Is(err, signercommon.ErrAccountNotFound)
+		}, 10*time.Second, 100*time.Millisecond)
+
+		// Close wipes the keys of the directory
+		keystoreDir := sut.KeystoreDir()
+		key, err := keystoreDir.Key(account1.Address)
+		require.NoError(t, err)
+		require.NoError(t, sut.Close())
+		require.Zero(t, key.D.Sign())
+		require.Empty(t, keystoreDir.Unlocked())
+	})
+}
+
+func TestNewLocalConfigCaseInsensitive(t *testing.T) {
+	cfg, err := NewLocalConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodLocal,
+		Config: map[string]any{"Path": "/app/sequencer.keystore", "PASSWORD": "test"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "/app/sequencer.keystore", cfg.Path)
+	require.Equal(t, "test", cfg.Password)
+
+	_, err = NewLocalConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodLocal,
+		Config: map[string]any{"Path": "/app/sequencer.keystore"},
+	})
+	require.ErrorIs(t, err, signertypes.ErrMissingConfigParam)
+}
@@ -0,0 +1,244 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "go_signer"
+
+	labelMethod    = "method"
+	labelName      = "name"
+	labelOperation = "operation"
+	labelClass     = "class"
+
+	operationInitialize = "Initialize"
+	operationSignHash   = "SignHash"
+	operationSignTx     = "SignTx"
+	operationSignHashes = "SignHashes"
+	operationSignTxs    = "SignTxs"
+	// operationSignAuthorization is an EIP-7702 authorization
+	operationSignAuthorization = "SignAuthorization"
+)
+
+// SignerMetrics are the Prometheus collectors shared by all the MetricsSign
+// registered on the same prometheus.Registerer
+type SignerMetrics struct {
+	// signs counts the signatures requested (each item of a batch counts as one)
+	signs *prometheus.CounterVec
+	// errors counts the failed signatures by error class
+	errors *prometheus.CounterVec
+	// latency is the duration of each call (a batch is one call)
+	latency *prometheus.HistogramVec
+	// lastSuccess is the unix timestamp of the last successful signature
+	lastSuccess *prometheus.GaugeVec
+}
+
+var (
+	registeredMetrics   = map[prometheus.Registerer]*SignerMetrics{}
+	registeredMetricsMu sync.Mutex
+)
+
+// NewSignerMetrics creates the collectors and registers them on reg. If they are
+// already registered on reg it returns the existing ones
+func NewSignerMetrics(reg prometheus.Registerer) (*SignerMetrics, error) {
+	registeredMetricsMu.Lock()
+	defer registeredMetricsMu.Unlock()
+	if m, ok := registeredMetrics[reg]; ok {
+		return m, nil
+	}
+	m := &SignerMetrics{
+		signs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "signs_total",
+			Help:      "Number of signatures requested",
+		}, []string{labelMethod, labelName, labelOperation}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sign_errors_total",
+			Help:      "Number of failed signatures by error class",
+		}, []string{labelMethod, labelName, labelOperation, labelClass}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "sign_duration_seconds",
+			Help:      "Duration of the signing calls",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16), //nolint:mnd
+		}, []string{labelMethod, labelName, labelOperation}),
+		lastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "last_successful_sign_timestamp_seconds",
+			Help:      "Unix timestamp of the last successful signature",
+		}, []string{labelMethod, labelName}),
+	}
+	var err error
+	if m.signs, err = registerOrExisting(reg, m.signs); err != nil {
+		return nil, err
+	}
+	if m.errors, err = registerOrExisting(reg, m.errors); err != nil {
+		return nil, err
+	}
+	if m.latency, err = registerOrExisting(reg, m.latency); err != nil {
+		return nil, err
+	}
+	if m.lastSuccess, err = registerOrExisting(reg, m.lastSuccess); err != nil {
+		return nil, err
+	}
+	registeredMetrics[reg] = m
+	return m, nil
+}
+
+func registerOrExisting[T prometheus.Collector](reg prometheus.Registerer, c T) (T, error) {
+	err := reg.Register(c)
+	if err == nil {
+		return c, nil
+	}
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if errors.As(err, &alreadyRegistered) {
+		if existing, ok := alreadyRegistered.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+	return c, fmt.Errorf("error registering signer metrics. Err: %w", err)
+}
+
+// MetricsSign is a decorator over a signer that exports Prometheus metrics of each call
+type MetricsSign struct {
+	method  signertypes.SignMethod
+	name    string
+	signer  signertypes.Signer
+	metrics *SignerMetrics
+}
+
+var _ signertypes.Signer = (*MetricsSign)(nil)
+
+// NewMetricsSign creates a MetricsSign over signer. method and name are used as labels
+func NewMetricsSign(method signertypes.SignMethod, name string, signer signertypes.Signer,
+	metrics *SignerMetrics) *MetricsSign {
+	return &MetricsSign{
+		method:  method,
+		name:    name,
+		signer:  signer,
+		metrics: metrics,
+	}
+}
+
+// Unwrap returns the decorated signer
+func (m *MetricsSign) Unwrap() signertypes.Signer {
+	return m.signer
+}
+
+func (m *MetricsSign) Initialize(ctx context.Context) error {
+	start := time.Now()
+	err := m.signer.Initialize(ctx)
+	m.observe(operationInitialize, start, 0, []error{err})
+	return err
+}
+
+func (m *MetricsSign) PublicAddress() common.Address {
+	return m.signer.PublicAddress()
+}
+
+func (m *MetricsSign) String() string {
+	return m.signer.String()
+}
+
+// Close closes the wrapped signer
+func (m *MetricsSign) Close() error {
+	return m.signer.Close()
+}
+
+func (m *MetricsSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	start := time.Now()
+	res, err := m.signer.SignHash(ctx, hash)
+	m.observe(operationSignHash, start, 1, []error{err})
+	return res, err
+}
+
+func (m *MetricsSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	start := time.Now()
+	res, err := m.signer.SignTx(ctx, tx)
+	m.observe(operationSignTx, start, 1, []error{err})
+	return res, err
+}
+
+func (m *MetricsSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	start := time.Now()
+	res, err := m.signer.SignTxForChain(ctx, chainID, tx)
+	m.observe(operationSignTx, start, 1, []error{err})
+	return res, err
+}
+
+func (m *MetricsSign) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	start := time.Now()
+	res, err := m.signer.SignAuthorization(ctx, auth)
+	m.observe(operationSignAuthorization, start, 1, []error{err})
+	return res, err
+}
+
+// SetBatchConfig sets the batch parameters of the decorated signer
+func (m *MetricsSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	if configurable, ok := m.signer.(batchConfigurable); ok {
+		configurable.SetBatchConfig(cfg)
+	}
+}
+
+func (m *MetricsSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	start := time.Now()
+	res, err := m.signer.SignHashes(ctx, hashes)
+	m.observe(operationSignHashes, start, len(hashes), batchItemErrors(err, len(hashes)))
+	return res, err
+}
+
+func (m *MetricsSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	start := time.Now()
+	res, err := m.signer.SignTxs(ctx, txs)
+	m.observe(operationSignTxs, start, len(txs), batchItemErrors(err, len(txs)))
+	return res, err
+}
+
+// observe records a call. items is the number of signatures requested and errs the errors
+// of the call (one per item for batches)
+func (m *MetricsSign) observe(operation string, start time.Time, items int, errs []error) {
+	method := m.method.String()
+	m.metrics.latency.WithLabelValues(method, m.name, operation).Observe(time.Since(start).Seconds())
+	if items > 0 {
+		m.metrics.signs.WithLabelValues(method, m.name, operation).Add(float64(items))
+	}
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+			m.metrics.errors.WithLabelValues(method, m.name, operation, ClassifyError(err).String()).Inc()
+		}
+	}
+	if items > 0 && failed < items {
+		m.metrics.lastSuccess.WithLabelValues(method, m.name).SetToCurrentTime()
+	}
+}
+
+// batchItemErrors returns the error of each item of a batch
+func batchItemErrors(err error, items int) []error {
+	if err == nil {
+		return nil
+	}
+	var batchErr *signertypes.BatchError
+	if errors.As(err, &batchErr) && len(batchErr.Errors) == items {
+		return batchErr.Errors
+	}
+	res := make([]error, items)
+	for i := range res {
+		res[i] = err
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agglayer/go_signer/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/agglayer/go_signer/signer/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsSign(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics, err := NewSignerMetrics(reg)
+	require.NoError(t, err)
+	inner := mocks.NewSigner(t)
+	sut := NewMetricsSign(signertypes.MethodGCPKMS, "sequencer", inner, metrics)
+	ctx := context.TODO()
+
+	inner.EXPECT().SignHash(mock.Anything, common.Hash{}).Return([]byte{1}, nil).Once()
+	_, err = sut.SignHash(ctx, common.Hash{})
+	require.NoError(t, err)
+	inner.EXPECT().SignHash(mock.Anything, common.Hash{}).
+		Return(nil, status.Error(codes.ResourceExhausted, "quota")).Once()
+	_, err = sut.SignHash(ctx, common.Hash{})
+	require.Error(t, err)
+	hashes := []common.Hash{{1}, {2}}
+	inner.EXPECT().SignHashes(mock.Anything, hashes).Return([][]byte{{1}, nil},
+		&signertypes.BatchError{Errors: []error{nil, errors.New("bad key")}}).Once()
+	_, err = sut.SignHashes(ctx, hashes)
+	require.Error(t, err)
+
+	require.InDelta(t, 2, testutil.ToFloat64(
+		metrics.signs.WithLabelValues("GCP", "sequencer", operationSignHash)), 0)
+	require.InDelta(t, 2, testutil.ToFloat64(
+		metrics.signs.WithLabelValues("GCP", "sequencer", operationSignHashes)), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(
+		metrics.errors.WithLabelValues("GCP", "sequencer", operationSignHash, "throttled")), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(
+		metrics.errors.WithLabelValues("GCP", "sequencer", operationSignHashes, "unknown")), 0)
+	require.Positive(t, testutil.ToFloat64(metrics.lastSuccess.WithLabelValues("GCP", "sequencer")))
+	require.Equal(t, 2, testutil.CollectAndCount(metrics.latency))
+}
+
+func TestNewSignerMetricsReusesCollectors(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m1, err := NewSignerMetrics(reg)
+	require.NoError(t, err)
+	m2, err := NewSignerMetrics(reg)
+	require.NoError(t, err)
+	require.Same(t, m1, m2)
+}
+
+func TestNewSignerWithMetricsRegisterer(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	sut, err := NewSigner(context.TODO(), 1, NewMockSignerConfig(testPrivateKeyHex), "test",
+		log.WithFields("test", "test"), WithMetricsRegisterer(reg))
+	require.NoError(t, err)
+	require.IsType(t, &MetricsSign{}, sut)
+	ctx := context.TODO()
+	require.NoError(t, sut.Initialize(ctx))
+	_, err = sut.SignHash(ctx, common.Hash{})
+	require.NoError(t, err)
+	count, err := testutil.GatherAndCount(reg, "go_signer_signs_total")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	sut, err = NewSigner(context.TODO(), 1, NewMockSignerConfig(testPrivateKeyHex), "test",
+		log.WithFields("test", "test"))
+	require.NoError(t, err)
+	require.IsType(t, &MockSign{}, sut)
+}
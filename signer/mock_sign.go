@@ -62,6 +62,17 @@ func (e *MockSign) Initialize(ctx context.Context) error {
 	return e.localSign.Initialize(ctx)
 }
 
+// SetMemoryLock enables locking the key in RAM (see LocalSign.SetMemoryLock)
+func (e *MockSign) SetMemoryLock(enabled bool) {
+	e.localSign.SetMemoryLock(enabled)
+}
+
+// Close wipes the private key
+func (e *MockSign) Close() error {
+	e.cfg.privateKey = nil
+	return e.localSign.Close()
+}
+
 // SignHash signs a hash
 func (e *MockSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
 	e.logger.Warnf("SignHash: %s is not suitable for production!", e.String())
@@ -79,3 +90,33 @@ func (e *MockSign) SignTx(ctx context.Context, tx *goethereumtypes.Transaction)
 	e.logger.Warnf("SignTx: %s is not suitable for production!", e.String())
 	return e.localSign.SignTx(ctx, tx)
 }
+
+func (e *MockSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *goethereumtypes.Transaction) (*goethereumtypes.Transaction, error) {
+	e.logger.Warnf("SignTxForChain: %s is not suitable for production!", e.String())
+	return e.localSign.SignTxForChain(ctx, chainID, tx)
+}
+
+func (e *MockSign) SignAuthorization(ctx context.Context,
+	auth goethereumtypes.SetCodeAuthorization) (goethereumtypes.SetCodeAuthorization, error) {
+	e.logger.Warnf("SignAuthorization: %s is not suitable for production!", e.String())
+	return e.localSign.SignAuthorization(ctx, auth)
+}
+
+// SetBatchConfig sets the parameters used by SignHashes and SignTxs
+func (e *MockSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	e.localSign.SetBatchConfig(cfg)
+}
+
+// SignHashes signs a batch of hashes
+func (e *MockSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	e.logger.Warnf("SignHashes: %s is not suitable for production!", e.String())
+	return e.localSign.SignHashes(ctx, hashes)
+}
+
+// SignTxs signs a batch of txs
+func (e *MockSign) SignTxs(ctx context.Context,
+	txs []*goethereumtypes.Transaction) ([]*goethereumtypes.Transaction, error) {
+	e.logger.Warnf("SignTxs: %s is not suitable for production!", e.String())
+	return e.localSign.SignTxs(ctx, txs)
+}
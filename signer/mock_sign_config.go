@@ -3,7 +3,6 @@ package signer
 import (
 	"crypto/ecdsa"
 	"encoding/hex"
-	"errors"
 	"fmt"
 
 	signertypes "github.com/agglayer/go_signer/signer/types"
@@ -65,12 +64,14 @@ func NewMockConfig(cfg signertypes.SignerConfig) (MockSignConfigure, error) {
 	if len(cfg.Config) == 0 {
 		return MockSignConfigure{}, nil
 	}
-	privateKeyStr, err := cfg.Get(FieldMockPrivateKey)
-	if err != nil && !errors.Is(err, signertypes.ErrMissingConfigParam) {
+	var decoded struct {
+		PrivateKey string `mapstructure:"PrivateKey"`
+	}
+	if err := cfg.Decode(&decoded); err != nil {
 		return res, fmt.Errorf("config %s: error in field %s . Err: %w",
 			cfg.Method, FieldMockPrivateKey, err)
 	}
-
+	privateKeyStr := decoded.PrivateKey
 	if privateKeyStr != "" {
 		err := res.LoadPrivateKey(privateKeyStr)
 		if err != nil {
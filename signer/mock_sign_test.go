@@ -3,8 +3,10 @@ package signer
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"testing"
 
+	signercommon "github.com/agglayer/go_signer/common"
 	"github.com/agglayer/go_signer/log"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -38,3 +40,21 @@ func TestMockSignSpecificPrivateKey(t *testing.T) {
 	require.Equal(t, testPublicKeyHex, sut.PublicAddress().Hex())
 	fmt.Print("public addr: ", sut.PublicAddress())
 }
+
+func TestMockSignMemoryLock(t *testing.T) {
+	ctx := context.TODO()
+	sut, err := NewSigner(ctx, 1, NewMockSignerConfig(testPrivateKeyHex), "test-mock-signer",
+		log.WithFields("module", "test"), WithMemoryLock())
+	require.NoError(t, err)
+	err = sut.Initialize(ctx)
+	if runtime.GOOS != "linux" {
+		require.ErrorIs(t, err, signercommon.ErrMemoryLockNotSupported)
+		return
+	}
+	require.NoError(t, err)
+	_, err = sut.SignHash(ctx, common.Hash{})
+	require.NoError(t, err)
+	require.NoError(t, sut.Close())
+	_, err = sut.SignHash(ctx, common.Hash{})
+	require.ErrorIs(t, err, ErrNoPrivateKey)
+}
@@ -0,0 +1,284 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	big "math/big"
+
+	apitypes "github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/ethereum/go-ethereum/core/types"
+)
+
+// ClefClienter is an autogenerated mock type for the ClefClienter type
+type ClefClienter struct {
+	mock.Mock
+}
+
+type ClefClienter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ClefClienter) EXPECT() *ClefClienter_Expecter {
+	return &ClefClienter_Expecter{mock: &_m.Mock}
+}
+
+// AccountList provides a mock function with given fields: ctx
+func (_m *ClefClienter) AccountList(ctx context.Context) ([]common.Address, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccountList")
+	}
+
+	var r0 []common.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]common.Address, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []common.Address); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClefClienter_AccountList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccountList'
+type ClefClienter_AccountList_Call struct {
+	*mock.Call
+}
+
+// AccountList is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ClefClienter_Expecter) AccountList(ctx interface{}) *ClefClienter_AccountList_Call {
+	return &ClefClienter_AccountList_Call{Call: _e.mock.On("AccountList", ctx)}
+}
+
+func (_c *ClefClienter_AccountList_Call) Run(run func(ctx context.Context)) *ClefClienter_AccountList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ClefClienter_AccountList_Call) Return(_a0 []common.Address, _a1 error) *ClefClienter_AccountList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClefClienter_AccountList_Call) RunAndReturn(run func(context.Context) ([]common.Address, error)) *ClefClienter_AccountList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignData provides a mock function with given fields: ctx, mimeType, from, data
+func (_m *ClefClienter) SignData(ctx context.Context, mimeType string, from common.Address, data []byte) ([]byte, error) {
+	ret := _m.Called(ctx, mimeType, from, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignData")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, common.Address, []byte) ([]byte, error)); ok {
+		return rf(ctx, mimeType, from, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, common.Address, []byte) []byte); ok {
+		r0 = rf(ctx, mimeType, from, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, common.Address, []byte) error); ok {
+		r1 = rf(ctx, mimeType, from, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClefClienter_SignData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignData'
+type ClefClienter_SignData_Call struct {
+	*mock.Call
+}
+
+// SignData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mimeType string
+//   - from common.Address
+//   - data []byte
+func (_e *ClefClienter_Expecter) SignData(ctx interface{}, mimeType interface{}, from interface{}, data interface{}) *ClefClienter_SignData_Call {
+	return &ClefClienter_SignData_Call{Call: _e.mock.On("SignData", ctx, mimeType, from, data)}
+}
+
+func (_c *ClefClienter_SignData_Call) Run(run func(ctx context.Context, mimeType string, from common.Address, data []byte)) *ClefClienter_SignData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(common.Address), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *ClefClienter_SignData_Call) Return(_a0 []byte, _a1 error) *ClefClienter_SignData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClefClienter_SignData_Call) RunAndReturn(run func(context.Context, string, common.Address, []byte) ([]byte, error)) *ClefClienter_SignData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignTransaction provides a mock function with given fields: ctx, from, tx, chainID
+func (_m *ClefClienter) SignTransaction(ctx context.Context, from common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	ret := _m.Called(ctx, from, tx, chainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignTransaction")
+	}
+
+	var r0 *types.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *types.Transaction, *big.Int) (*types.Transaction, error)); ok {
+		return rf(ctx, from, tx, chainID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *types.Transaction, *big.Int) *types.Transaction); ok {
+		r0 = rf(ctx, from, tx, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, *types.Transaction, *big.Int) error); ok {
+		r1 = rf(ctx, from, tx, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClefClienter_SignTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignTransaction'
+type ClefClienter_SignTransaction_Call struct {
+	*mock.Call
+}
+
+// SignTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from common.Address
+//   - tx *types.Transaction
+//   - chainID *big.Int
+func (_e *ClefClienter_Expecter) SignTransaction(ctx interface{}, from interface{}, tx interface{}, chainID interface{}) *ClefClienter_SignTransaction_Call {
+	return &ClefClienter_SignTransaction_Call{Call: _e.mock.On("SignTransaction", ctx, from, tx, chainID)}
+}
+
+func (_c *ClefClienter_SignTransaction_Call) Run(run func(ctx context.Context, from common.Address, tx *types.Transaction, chainID *big.Int)) *ClefClienter_SignTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(*types.Transaction), args[3].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *ClefClienter_SignTransaction_Call) Return(_a0 *types.Transaction, _a1 error) *ClefClienter_SignTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClefClienter_SignTransaction_Call) RunAndReturn(run func(context.Context, common.Address, *types.Transaction, *big.Int) (*types.Transaction, error)) *ClefClienter_SignTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignTypedData provides a mock function with given fields: ctx, from, typedData
+func (_m *ClefClienter) SignTypedData(ctx context.Context, from common.Address, typedData apitypes.TypedData) ([]byte, error) {
+	ret := _m.Called(ctx, from, typedData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignTypedData")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, apitypes.TypedData) ([]byte, error)); ok {
+		return rf(ctx, from, typedData)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, apitypes.TypedData) []byte); ok {
+		r0 = rf(ctx, from, typedData)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, apitypes.TypedData) error); ok {
+		r1 = rf(ctx, from, typedData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClefClienter_SignTypedData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignTypedData'
+type ClefClienter_SignTypedData_Call struct {
+	*mock.Call
+}
+
+// SignTypedData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from common.Address
+//   - typedData apitypes.TypedData
+func (_e *ClefClienter_Expecter) SignTypedData(ctx interface{}, from interface{}, typedData interface{}) *ClefClienter_SignTypedData_Call {
+	return &ClefClienter_SignTypedData_Call{Call: _e.mock.On("SignTypedData", ctx, from, typedData)}
+}
+
+func (_c *ClefClienter_SignTypedData_Call) Run(run func(ctx context.Context, from common.Address, typedData apitypes.TypedData)) *ClefClienter_SignTypedData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(apitypes.TypedData))
+	})
+	return _c
+}
+
+func (_c *ClefClienter_SignTypedData_Call) Return(_a0 []byte, _a1 error) *ClefClienter_SignTypedData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClefClienter_SignTypedData_Call) RunAndReturn(run func(context.Context, common.Address, apitypes.TypedData) ([]byte, error)) *ClefClienter_SignTypedData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewClefClienter creates a new instance of ClefClienter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClefClienter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ClefClienter {
+	mock := &ClefClienter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
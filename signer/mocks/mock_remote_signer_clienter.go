@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	big "math/big"
 
 	common "github.com/ethereum/go-ethereum/common"
 
@@ -83,6 +84,64 @@ func (_c *RemoteSignerClienter_EthAccounts_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// EthChainID provides a mock function with given fields: ctx
+func (_m *RemoteSignerClienter) EthChainID(ctx context.Context) (*big.Int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EthChainID")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*big.Int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoteSignerClienter_EthChainID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EthChainID'
+type RemoteSignerClienter_EthChainID_Call struct {
+	*mock.Call
+}
+
+// EthChainID is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RemoteSignerClienter_Expecter) EthChainID(ctx interface{}) *RemoteSignerClienter_EthChainID_Call {
+	return &RemoteSignerClienter_EthChainID_Call{Call: _e.mock.On("EthChainID", ctx)}
+}
+
+func (_c *RemoteSignerClienter_EthChainID_Call) Run(run func(ctx context.Context)) *RemoteSignerClienter_EthChainID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RemoteSignerClienter_EthChainID_Call) Return(_a0 *big.Int, _a1 error) *RemoteSignerClienter_EthChainID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RemoteSignerClienter_EthChainID_Call) RunAndReturn(run func(context.Context) (*big.Int, error)) *RemoteSignerClienter_EthChainID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SignHash provides a mock function with given fields: ctx, address, hashToSign
 func (_m *RemoteSignerClienter) SignHash(ctx context.Context, address common.Address, hashToSign common.Hash) ([]byte, error) {
 	ret := _m.Called(ctx, address, hashToSign)
@@ -203,6 +262,66 @@ func (_c *RemoteSignerClienter_SignTx_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// SignTxs provides a mock function with given fields: ctx, from, txs
+func (_m *RemoteSignerClienter) SignTxs(ctx context.Context, from common.Address, txs []*types.Transaction) ([]*types.Transaction, error) {
+	ret := _m.Called(ctx, from, txs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignTxs")
+	}
+
+	var r0 []*types.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []*types.Transaction) ([]*types.Transaction, error)); ok {
+		return rf(ctx, from, txs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []*types.Transaction) []*types.Transaction); ok {
+		r0 = rf(ctx, from, txs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, []*types.Transaction) error); ok {
+		r1 = rf(ctx, from, txs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoteSignerClienter_SignTxs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignTxs'
+type RemoteSignerClienter_SignTxs_Call struct {
+	*mock.Call
+}
+
+// SignTxs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from common.Address
+//   - txs []*types.Transaction
+func (_e *RemoteSignerClienter_Expecter) SignTxs(ctx interface{}, from interface{}, txs interface{}) *RemoteSignerClienter_SignTxs_Call {
+	return &RemoteSignerClienter_SignTxs_Call{Call: _e.mock.On("SignTxs", ctx, from, txs)}
+}
+
+func (_c *RemoteSignerClienter_SignTxs_Call) Run(run func(ctx context.Context, from common.Address, txs []*types.Transaction)) *RemoteSignerClienter_SignTxs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].([]*types.Transaction))
+	})
+	return _c
+}
+
+func (_c *RemoteSignerClienter_SignTxs_Call) Return(_a0 []*types.Transaction, _a1 error) *RemoteSignerClienter_SignTxs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RemoteSignerClienter_SignTxs_Call) RunAndReturn(run func(context.Context, common.Address, []*types.Transaction) ([]*types.Transaction, error)) *RemoteSignerClienter_SignTxs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewRemoteSignerClienter creates a new instance of RemoteSignerClienter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRemoteSignerClienter(t interface {
@@ -17,6 +17,11 @@ func (s *NoneSign) Initialize(ctx context.Context) error {
 	return nil
 }
 
+// Close does nothing, NoneSign has no resources
+func (s *NoneSign) Close() error {
+	return nil
+}
+
 // PublicAddress returns 0x0 address
 func (s *NoneSign) PublicAddress() common.Address {
 	return common.Address{}
@@ -36,3 +41,23 @@ func (s *NoneSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, erro
 func (s *NoneSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
 	return nil, gosignertypes.ErrNotImplemented
 }
+
+func (s *NoneSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	return nil, gosignertypes.ErrNotImplemented
+}
+
+func (s *NoneSign) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	return types.SetCodeAuthorization{}, gosignertypes.ErrNotImplemented
+}
+
+// SignHashes returns error always
+func (s *NoneSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	return nil, gosignertypes.ErrNotImplemented
+}
+
+// SignTxs returns error always
+func (s *NoneSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	return nil, gosignertypes.ErrNotImplemented
+}
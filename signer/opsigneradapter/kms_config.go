@@ -0,0 +1,92 @@
+package opsigneradapter
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	gcpkms "cloud.google.com/go/kms/apiv1"
+	gosignertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	opsignerprovider "github.com/ethereum-optimism/infra/op-signer/provider"
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/api/option"
+)
+
+const (
+	// FieldRegion is the AWS region, if not set it uses the default of the environment
+	FieldRegion = "Region"
+	// FieldEndpoint overrides the endpoint of the KMS service (e.g. a VPC endpoint or localstack)
+	FieldEndpoint = "Endpoint"
+)
+
+// KMSConfig is the specific config of the KMS methods (GCP and AWS)
+type KMSConfig struct {
+	// KeyName is the name of the key on the KMS
+	KeyName string `mapstructure:"KeyName"`
+	// Region is the AWS region, only for AWS
+	Region string `mapstructure:"Region"`
+	// Endpoint overrides the default endpoint of the KMS service
+	Endpoint string `mapstructure:"Endpoint"`
+}
+
+// NewKMSConfig creates a KMSConfig (specific config) from a SignerConfig
+func NewKMSConfig(cfg gosignertypes.SignerConfig) (KMSConfig, error) {
+	var res KMSConfig
+	if err := cfg.Decode(&res); err != nil {
+		return KMSConfig{}, err
+	}
+	if res.KeyName == "" {
+		return KMSConfig{}, fmt.Errorf("config %s: field %s is required. Err: %w",
+			cfg.Method, FieldKeyName, gosignertypes.ErrMissingConfigParam)
+	}
+	if res.Region != "" && cfg.Method != gosignertypes.MethodAWSKMS {
+		return KMSConfig{}, fmt.Errorf("config %s: field %s is only for %s. Err: %w",
+			cfg.Method, FieldRegion, gosignertypes.MethodAWSKMS, gosignertypes.ErrBadConfigParams)
+	}
+	return res, nil
+}
+
+// newSignatureProvider creates the op-signer provider of method. If Region and Endpoint are
+// not set it's the default one of op-signer (configured by the environment). The GCP client is
+// always created here to return it as the closer of the provider (nil if there is nothing to close)
+func newSignatureProvider(ctx context.Context, logger log.Logger, method gosignertypes.SignMethod,
+	cfg KMSConfig) (opsignerprovider.SignatureProvider, io.Closer, error) {
+	providerType := opsignerprovider.ProviderType(method)
+	if cfg.Region == "" && cfg.Endpoint == "" && method != gosignertypes.MethodGCPKMS {
+		provider, err := opsignerprovider.NewSignatureProvider(logger, providerType, opsignerprovider.ProviderConfig{
+			ProviderType: providerType,
+		})
+		return provider, nil, err
+	}
+	switch method {
+	case gosignertypes.MethodAWSKMS:
+		var loadOpts []func(*awsconfig.LoadOptions) error
+		if cfg.Region != "" {
+			loadOpts = append(loadOpts, awsconfig.WithRegion(cfg.Region))
+		}
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, loadOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load AWS config. Err: %w", err)
+		}
+		client := awskms.NewFromConfig(awsCfg, func(o *awskms.Options) {
+			if cfg.Endpoint != "" {
+				o.BaseEndpoint = aws.String(cfg.Endpoint)
+			}
+		})
+		return opsignerprovider.NewAWSKMSSignatureProviderWithClient(logger, client), nil, nil
+	case gosignertypes.MethodGCPKMS:
+		var clientOpts []option.ClientOption
+		if cfg.Endpoint != "" {
+			clientOpts = append(clientOpts, option.WithEndpoint(cfg.Endpoint))
+		}
+		client, err := gcpkms.NewKeyManagementClient(ctx, clientOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize GCP KMS client. Err: %w", err)
+		}
+		return opsignerprovider.NewGCPKMSSignatureProviderWithClient(logger, client), client, nil
+	}
+	return nil, nil, fmt.Errorf("method %s is not a KMS. Err: %w", method, gosignertypes.ErrUnknownMethod)
+}
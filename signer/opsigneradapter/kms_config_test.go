@@ -0,0 +1,29 @@
+package opsigneradapter
+
+import (
+	"testing"
+
+	gosignertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKMSConfig(t *testing.T) {
+	cfg, err := NewKMSConfig(gosignertypes.SignerConfig{
+		Method: gosignertypes.MethodAWSKMS,
+		Config: map[string]any{"keyname": "key", "region": "eu-west-1", "Endpoint": "http://localhost:4566"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, KMSConfig{KeyName: "key", Region: "eu-west-1", Endpoint: "http://localhost:4566"}, cfg)
+
+	_, err = NewKMSConfig(gosignertypes.SignerConfig{
+		Method: gosignertypes.MethodAWSKMS,
+		Config: map[string]any{"Region": "eu-west-1"},
+	})
+	require.ErrorIs(t, err, gosignertypes.ErrMissingConfigParam)
+
+	_, err = NewKMSConfig(gosignertypes.SignerConfig{
+		Method: gosignertypes.MethodGCPKMS,
+		Config: map[string]any{"KeyName": "key", "Region": "eu-west-1"},
+	})
+	require.ErrorIs(t, err, gosignertypes.ErrBadConfigParams)
+}
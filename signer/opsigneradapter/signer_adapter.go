@@ -3,9 +3,11 @@ package opsigneradapter
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/big"
 
 	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/batch"
 	gosignertypes "github.com/agglayer/go_signer/signer/types"
 	opsignerprovider "github.com/ethereum-optimism/infra/op-signer/provider"
 	"github.com/ethereum/go-ethereum/common"
@@ -24,6 +26,9 @@ type SignerAdapter struct {
 	logger         signercommon.Logger
 	keyName        string
 	chainID        uint64
+	batcher        *batch.Batcher
+	// closer is the KMS client created by NewSignerAdapterFromConfig (nil if there is nothing to close)
+	closer io.Closer
 }
 
 var _ gosignertypes.Signer = (*SignerAdapter)(nil)
@@ -38,23 +43,24 @@ func NewSignerAdapter(ctx context.Context, logger signercommon.Logger, opSigner
 		logger:         logger,
 		keyName:        keyName,
 		chainID:        chainID,
+		batcher:        batch.NewBatcher(gosignertypes.DefaultBatchConfig()),
 	}
 }
 
 func NewSignerAdapterFromConfig(ctx context.Context, logger signercommon.Logger,
 	cfg gosignertypes.SignerConfig, chainID uint64) (*SignerAdapter, error) {
-	opConfig := opsignerprovider.ProviderConfig{
-		ProviderType: opsignerprovider.ProviderType(cfg.Method),
-	}
-	opSigner, err := opsignerprovider.NewSignatureProvider(NewLoggerAdapter(logger), opConfig.ProviderType, opConfig)
+	kmsCfg, err := NewKMSConfig(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("error creating opSignerProvider. Err: %w", err)
+		return nil, fmt.Errorf("error getting KMS config. Err: %w", err)
 	}
-	keyName, err := cfg.Get(FieldKeyName)
+	opSigner, closer, err := newSignatureProvider(ctx, NewLoggerAdapter(logger), cfg.Method, kmsCfg)
 	if err != nil {
-		return nil, fmt.Errorf("error getting keyName from config. Err: %w", err)
+		return nil, fmt.Errorf("error creating opSignerProvider. Err: %w", err)
 	}
-	return NewSignerAdapter(ctx, logger, opSigner, opConfig.ProviderType, keyName, chainID), nil
+	res := NewSignerAdapter(ctx, logger, opSigner, opsignerprovider.ProviderType(cfg.Method), kmsCfg.KeyName,
+		chainID)
+	res.closer = closer
+	return res, nil
 }
 
 func (s *SignerAdapter) Initialize(context.Context) error {
@@ -65,6 +71,19 @@ func (s *SignerAdapter) Initialize(context.Context) error {
 	return nil
 }
 
+// Close closes the KMS client if it has been created by NewSignerAdapterFromConfig
+func (s *SignerAdapter) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	err := s.closer.Close()
+	s.closer = nil
+	if err != nil {
+		return fmt.Errorf("error closing KMS client. Err: %w", err)
+	}
+	return nil
+}
+
 func (s *SignerAdapter) PublicAddress() common.Address {
 	res, err := s.opSigner.GetPublicKey(s.ctx, s.keyName)
 	if err != nil {
@@ -87,10 +106,23 @@ func (s *SignerAdapter) SignHash(ctx context.Context, hash common.Hash) ([]byte,
 }
 
 func (s *SignerAdapter) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
-	chainID := big.NewInt(int64(s.chainID))
-	txSigner := types.LatestSignerForChainID(chainID)
+	return s.SignTxForChain(ctx, 0, tx)
+}
+
+// SignTxForChain signs tx for chainID, so the same key can sign for several chains.
+// 0 means the chainID of the adapter or, if it's not set, the one of the tx
+func (s *SignerAdapter) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	if chainID == 0 {
+		chainID = s.chainID
+	}
+	chainID, err := gosignertypes.ResolveTxChainID(tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("error signTx. Err: %w", err)
+	}
+	txSigner := types.LatestSignerForChainID(new(big.Int).SetUint64(chainID))
 	digest := txSigner.Hash(tx)
-	s.logger.Debugf("SignTx %s. chainID: %d", digest.String(), s.chainID)
+	s.logger.Debugf("SignTx %s. chainID: %d", digest.String(), chainID)
 	signature, err := s.opSigner.SignDigest(ctx, s.keyName, digest.Bytes())
 	if err != nil {
 		return nil, fmt.Errorf("error signTx opSigner.SignDigest. Err: %w ", err)
@@ -101,3 +133,28 @@ func (s *SignerAdapter) SignTx(ctx context.Context, tx *types.Transaction) (*typ
 	}
 	return signed, nil
 }
+
+// SignAuthorization signs an EIP-7702 authorization with the KMS key
+func (s *SignerAdapter) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	signed, err := gosignertypes.SignAuthorizationWithHash(ctx, s, s.chainID, auth)
+	if err != nil {
+		return types.SetCodeAuthorization{}, fmt.Errorf("error signAuthorization. Err: %w", err)
+	}
+	return signed, nil
+}
+
+// SetBatchConfig sets the parameters used by SignHashes and SignTxs
+func (s *SignerAdapter) SetBatchConfig(cfg gosignertypes.BatchConfig) {
+	s.batcher = batch.NewBatcher(cfg)
+}
+
+// SignHashes signs a batch of hashes. KMS doesn't support batching so it's a bounded fan-out
+func (s *SignerAdapter) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	return s.batcher.SignHashes(ctx, s, hashes)
+}
+
+// SignTxs signs a batch of txs. KMS doesn't support batching so it's a bounded fan-out
+func (s *SignerAdapter) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	return s.batcher.SignTxs(ctx, s, txs)
+}
@@ -0,0 +1,58 @@
+package signer
+
+import (
+	"github.com/agglayer/go_signer/signer/audit"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// signerOptions are the optional parameters of NewSigner
+type signerOptions struct {
+	metricsRegisterer prometheus.Registerer
+	tracerProvider    trace.TracerProvider
+	auditSink         audit.Sink
+	memoryLock        bool
+}
+
+// SignerOption sets an optional parameter of NewSigner
+type SignerOption func(*signerOptions)
+
+// WithMetricsRegisterer enables the Prometheus metrics for the signer, registering them on reg
+func WithMetricsRegisterer(reg prometheus.Registerer) SignerOption {
+	return func(o *signerOptions) {
+		o.metricsRegisterer = reg
+	}
+}
+
+// WithTracerProvider enables OpenTelemetry spans for each signing call. For remote signers
+// the trace context is propagated using W3C headers
+func WithTracerProvider(tp trace.TracerProvider) SignerOption {
+	return func(o *signerOptions) {
+		o.tracerProvider = tp
+	}
+}
+
+// WithAuditSink records each signature produced by the signer on sink. If a record
+// can't be written the signature is discarded and the call fails
+func WithAuditSink(sink audit.Sink) SignerOption {
+	return func(o *signerOptions) {
+		o.auditSink = sink
+	}
+}
+
+// WithMemoryLock locks in RAM (mlock) the private keys held by the signer (local, hd, shamir
+// and mock methods), so they are never written to swap. It's only supported on Linux, on
+// other systems Initialize fails. The limit of locked memory is RLIMIT_MEMLOCK
+func WithMemoryLock() SignerOption {
+	return func(o *signerOptions) {
+		o.memoryLock = true
+	}
+}
+
+func newSignerOptions(opts []SignerOption) signerOptions {
+	var res signerOptions
+	for _, opt := range opts {
+		opt(&res)
+	}
+	return res
+}
@@ -2,66 +2,153 @@ package signer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
 
 	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/batch"
 	web3signerclient "github.com/agglayer/go_signer/signer/remotesignerclient"
 	signertypes "github.com/agglayer/go_signer/signer/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
 	FieldAddress = "address"
 	FieldURL     = "url"
+	FieldTimeout = "timeout"
+	// FieldTLSCACert is the CA bundle (PEM file) to verify the remote signer
+	FieldTLSCACert = "tlscacert"
+	// FieldTLSCert and FieldTLSKey are the client certificate (PEM files) for mTLS
+	FieldTLSCert               = "tlscert"
+	FieldTLSKey                = "tlskey"
+	FieldTLSInsecureSkipVerify = "tlsinsecureskipverify"
+	FieldBearerToken           = "bearertoken"
+	FieldBasicAuthUser         = "basicauthuser"
+	FieldBasicAuthPassword     = "basicauthpassword"
+	// FieldHeaders are extra headers (name -> value) added to each request
+	FieldHeaders = "headers"
+	// FieldProxy is the URL of the proxy, by default it's taken from the environment (HTTPS_PROXY...)
+	FieldProxy = "proxy"
 )
 
-var zeroAddr common.Address
+var (
+	zeroAddr common.Address
+
+	ErrRemoteSignHashNotSupported = fmt.Errorf(
+		"remote eth_sign use EIP155 that changed the hash to sign. So you can't use this signers")
+	// ErrRemoteSignTextNotSupported is returned by SignText if the client can't sign texts
+	ErrRemoteSignTextNotSupported = fmt.Errorf("remote signer client doesn't implement SignText")
+	// ErrRemoteAuth is returned if the config has bearer and basic auth at the same time
+	ErrRemoteAuth = fmt.Errorf("fields %s and %s are exclusive", FieldBearerToken, FieldBasicAuthUser)
+)
 
 type RemoteSignerClienter interface {
 	EthAccounts(ctx context.Context) ([]common.Address, error)
+	EthChainID(ctx context.Context) (*big.Int, error)
 	SignHash(ctx context.Context, address common.Address, hashToSign common.Hash) ([]byte, error)
 	SignTx(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error)
+	SignTxs(ctx context.Context, from common.Address, txs []*types.Transaction) ([]*types.Transaction, error)
+}
+
+// textSignerClient is implemented by the clients that sign EIP-191 personal messages (eth_sign)
+type textSignerClient interface {
+	SignText(ctx context.Context, address common.Address, text []byte) ([]byte, error)
+}
+
+// clientCloser is implemented by the clients that keep connections open (e.g. ClefClient)
+type clientCloser interface {
+	Close()
 }
 
 type RemoteSignerConfig struct {
-	// URL is the url of the web3 signer
-	URL string
+	// URL is the url of the web3 signer. It can be a Unix socket (unix:///path or a geth-style IPC path)
+	URL string `mapstructure:"url"`
 	// Address is the address of the account to use, if not specified the first account (if only 1 exposed) will be used
-	Address common.Address
+	Address common.Address `mapstructure:"address"`
+	// Timeout is the timeout of each request to the web3 signer, 0 means no timeout
+	Timeout time.Duration `mapstructure:"timeout"`
+	// TLSCACert is the CA bundle to verify the web3 signer, if empty it uses the system ones
+	TLSCACert string `mapstructure:"tlscacert"`
+	// TLSCert and TLSKey are the client certificate for mTLS
+	TLSCert string `mapstructure:"tlscert"`
+	TLSKey  string `mapstructure:"tlskey"`
+	// TLSInsecureSkipVerify disables the verification of the server certificate, only for testing
+	TLSInsecureSkipVerify bool `mapstructure:"tlsinsecureskipverify"`
+	// BearerToken is sent as Authorization: Bearer
+	BearerToken string `mapstructure:"bearertoken"`
+	// BasicAuthUser and BasicAuthPassword are sent as Authorization: Basic
+	BasicAuthUser     string `mapstructure:"basicauthuser"`
+	BasicAuthPassword string `mapstructure:"basicauthpassword"`
+	// Headers are extra headers added to each request
+	Headers map[string]string `mapstructure:"headers"`
+	// Proxy is the URL of the proxy, if empty it's taken from the environment
+	Proxy string `mapstructure:"proxy"`
 }
 
 func NewRemoteSignerConfig(cfg signertypes.SignerConfig) (RemoteSignerConfig, error) {
-	var addr common.Address
-	addrField, ok := cfg.Config[FieldAddress]
-	// Field Address is optional
-	if ok {
-		s, ok := addrField.(string)
-		if !ok {
-			return RemoteSignerConfig{}, fmt.Errorf("config %s: field %s is not string %v",
-				signertypes.MethodRemoteSigner, FieldAddress, addrField)
-		}
-		if !common.IsHexAddress(s) {
-			return RemoteSignerConfig{},
-				fmt.Errorf("config %s: invalid field %s: %s", signertypes.MethodRemoteSigner, FieldAddress, s)
-		}
-		addr = common.HexToAddress(s)
+	var res RemoteSignerConfig
+	if err := cfg.Decode(&res); err != nil {
+		return RemoteSignerConfig{}, err
 	}
-	urlIntf, ok := cfg.Config[FieldURL]
 	// Field URL is mandatory
-	if !ok {
+	if res.URL == "" {
 		return RemoteSignerConfig{},
 			fmt.Errorf("config %s: field %s is not present", signertypes.MethodRemoteSigner, FieldURL)
 	}
-	urlStr, ok := urlIntf.(string)
-	if !ok {
-		return RemoteSignerConfig{}, fmt.Errorf("config %s: field %s is not string %v",
-			signertypes.MethodRemoteSigner, FieldURL, urlIntf)
+	if res.BearerToken != "" && res.BasicAuthUser != "" {
+		return RemoteSignerConfig{}, fmt.Errorf("config %s: %w. Err: %w", signertypes.MethodRemoteSigner,
+			ErrRemoteAuth, signertypes.ErrBadConfigParams)
+	}
+	if res.Proxy != "" {
+		if _, err := url.Parse(res.Proxy); err != nil {
+			return RemoteSignerConfig{}, fmt.Errorf("config %s: field %s is not a URL. Err: %w: %w",
+				signertypes.MethodRemoteSigner, FieldProxy, signertypes.ErrBadConfigParams, err)
+		}
+	}
+	return res, nil
+}
+
+// ClientOptions returns the options of the web3 signer client (timeout, TLS, auth, headers and proxy).
+// It loads the TLS files
+func (c RemoteSignerConfig) ClientOptions() ([]web3signerclient.Option, error) {
+	var res []web3signerclient.Option
+	if c.Timeout > 0 {
+		res = append(res, web3signerclient.WithTimeout(c.Timeout))
+	}
+	if c.TLSCACert != "" || c.TLSCert != "" || c.TLSKey != "" || c.TLSInsecureSkipVerify {
+		tlsConfig, err := web3signerclient.NewTLSConfig(c.TLSCACert, c.TLSCert, c.TLSKey, c.TLSInsecureSkipVerify)
+		if err != nil {
+			return nil, fmt.Errorf("config %s: TLS. Err: %w", signertypes.MethodRemoteSigner, err)
+		}
+		res = append(res, web3signerclient.WithTLSConfig(tlsConfig))
+	}
+	if c.BearerToken != "" {
+		res = append(res, web3signerclient.WithBearerToken(c.BearerToken))
 	}
-	return RemoteSignerConfig{
-		URL:     urlStr,
-		Address: addr,
-	}, nil
+	if c.BasicAuthUser != "" {
+		res = append(res, web3signerclient.WithBasicAuth(c.BasicAuthUser, c.BasicAuthPassword))
+	}
+	if len(c.Headers) > 0 {
+		headers := http.Header{}
+		for key, value := range c.Headers {
+			headers.Set(key, value)
+		}
+		res = append(res, web3signerclient.WithHeaders(headers))
+	}
+	if c.Proxy != "" {
+		proxy, err := url.Parse(c.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("config %s: field %s. Err: %w", signertypes.MethodRemoteSigner, FieldProxy, err)
+		}
+		res = append(res, web3signerclient.WithProxy(proxy))
+	}
+	return res, nil
 }
 
 type RemoteSignerSign struct {
@@ -69,24 +156,63 @@ type RemoteSignerSign struct {
 	logger  signercommon.Logger
 	client  RemoteSignerClienter
 	address common.Address
+	// chainID is the chain of the txs, 0 means it's not checked
+	chainID uint64
+	batcher *batch.Batcher
+	// initErr is the error of the config, returned by Initialize (see NewRemoteSignerSignFromConfig)
+	initErr error
 }
 
+// NewRemoteSignerSign creates a RemoteSignerSign that doesn't check the chain ID
+// (see NewRemoteSignerSignForChain)
 func NewRemoteSignerSign(name string, logger signercommon.Logger, client RemoteSignerClienter,
 	address common.Address) *RemoteSignerSign {
+	return NewRemoteSignerSignForChain(name, logger, client, address, 0)
+}
+
+// NewRemoteSignerSignForChain creates a RemoteSignerSign. chainID is checked against the remote
+// signer on Initialize and against each tx (0 disables the checks)
+func NewRemoteSignerSignForChain(name string, logger signercommon.Logger, client RemoteSignerClienter,
+	address common.Address, chainID uint64) *RemoteSignerSign {
 	return &RemoteSignerSign{
 		name:    name,
 		logger:  logger,
 		client:  client,
 		address: address,
+		chainID: chainID,
+		batcher: batch.NewBatcher(signertypes.DefaultBatchConfig()),
 	}
 }
 
-func NewRemoteSignerSignFromConfig(name string, logger signercommon.Logger, cfg RemoteSignerConfig) *RemoteSignerSign {
-	client := web3signerclient.NewRemoteSignerClient(cfg.URL)
-	return NewRemoteSignerSign(name, logger, client, cfg.Address)
+// NewRemoteSignerSignFromConfig creates a RemoteSignerSign with a client configured by cfg that
+// doesn't check the chain ID. clientOpts are applied after the ones of cfg. An invalid cfg (e.g.
+// the TLS files) is returned by Initialize, NewRemoteSignerSignFromConfigForChain returns it
+func NewRemoteSignerSignFromConfig(name string, logger signercommon.Logger, cfg RemoteSignerConfig,
+	clientOpts ...web3signerclient.Option) *RemoteSignerSign {
+	res, err := NewRemoteSignerSignFromConfigForChain(name, logger, cfg, 0, clientOpts...)
+	if err != nil {
+		return &RemoteSignerSign{name: name, logger: logger, address: cfg.Address, initErr: err,
+			batcher: batch.NewBatcher(signertypes.DefaultBatchConfig())}
+	}
+	return res
+}
+
+// NewRemoteSignerSignFromConfigForChain creates a RemoteSignerSign with a client configured by cfg
+// that checks chainID (see NewRemoteSignerSignForChain). clientOpts are applied after the ones of cfg
+func NewRemoteSignerSignFromConfigForChain(name string, logger signercommon.Logger, cfg RemoteSignerConfig,
+	chainID uint64, clientOpts ...web3signerclient.Option) (*RemoteSignerSign, error) {
+	cfgOpts, err := cfg.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	client := web3signerclient.NewRemoteSignerClient(cfg.URL, append(cfgOpts, clientOpts...)...)
+	return NewRemoteSignerSignForChain(name, logger, client, cfg.Address, chainID), nil
 }
 
 func (e *RemoteSignerSign) Initialize(ctx context.Context) error {
+	if e.initErr != nil {
+		return fmt.Errorf("%s invalid config. Err: %w", e.logPrefix(), e.initErr)
+	}
 	if e.client == nil {
 		return fmt.Errorf("%s client is nil", e.logPrefix())
 	}
@@ -108,15 +234,180 @@ func (e *RemoteSignerSign) Initialize(ctx context.Context) error {
 		e.logger.Infof("%s Using account %v", e.logPrefix(), accounts[0])
 		e.address = accounts[0]
 	}
+	return e.checkRemoteChainID(ctx)
+}
+
+// checkRemoteChainID verifies that the remote signer uses chainID (eth_chainId). If the
+// remote signer doesn't implement eth_chainId it's only logged, the txs are still checked
+func (e *RemoteSignerSign) checkRemoteChainID(ctx context.Context) error {
+	if e.chainID == 0 {
+		return nil
+	}
+	remoteChainID, err := e.client.EthChainID(ctx)
+	if err != nil {
+		var rpcErr rpcCodeError
+		if errors.As(err, &rpcErr) && rpcErr.RPCErrorCode() == rpcErrorCodeMethodNotFound {
+			e.logger.Warnf("%s remote signer doesn't support eth_chainId, can't check chain ID %d",
+				e.logPrefix(), e.chainID)
+			return nil
+		}
+		return fmt.Errorf("%s error getting chain ID of remote signer. Err: %w", e.logPrefix(), err)
+	}
+	if err := signertypes.CheckChainID(e.chainID, remoteChainID); err != nil {
+		return fmt.Errorf("%s remote signer. Err: %w", e.logPrefix(), err)
+	}
+	return nil
+}
+
+// checkSignedChainID verifies that the remote signer has signed tx for chainID (0 skips it). A legacy tx
+// doesn't carry the chain ID until it's signed, so it's the only way to detect a mismatch
+func (e *RemoteSignerSign) checkSignedChainID(tx *types.Transaction, chainID uint64) error {
+	if chainID == 0 {
+		return nil
+	}
+	if err := signertypes.CheckChainID(chainID, tx.ChainId()); err != nil {
+		return fmt.Errorf("%s signed tx %s. Err: %w", e.logPrefix(), tx.Hash().Hex(), err)
+	}
 	return nil
 }
 
 func (e *RemoteSignerSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
-	return nil, fmt.Errorf("remote eth_sign use EIP155 that changed the hash to sign. So you can't use this signers")
+	return nil, ErrRemoteSignHashNotSupported
+}
+
+// SignText signs text with the EIP-191 personal message prefix (eth_sign), e.g. for
+// safe.SignEthSign. V is 0/1
+func (e *RemoteSignerSign) SignText(ctx context.Context, text []byte) ([]byte, error) {
+	client, ok := e.client.(textSignerClient)
+	if !ok {
+		return nil, ErrRemoteSignTextNotSupported
+	}
+	signature, err := client.SignText(ctx, e.address, text)
+	if err != nil {
+		return nil, err
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("%s SignText: invalid signature length %d", e.logPrefix(), len(signature))
+	}
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	return signature, nil
 }
 
 func (e *RemoteSignerSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
-	return e.client.SignTx(ctx, e.address, tx)
+	return e.SignTxForChain(ctx, 0, tx)
+}
+
+// SignTxForChain signs tx for chainID (0 means the chainID of the signer or, if it's not set, the one
+// of the tx). The remote signer signs with the chain it's configured with, so the signed tx is
+// checked and a different chain returns ErrChainIDMismatch
+func (e *RemoteSignerSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	if chainID == 0 {
+		chainID = e.chainID
+	}
+	if err := signertypes.CheckTxChainID(tx, chainID); err != nil {
+		return nil, fmt.Errorf("%s SignTx. Err: %w", e.logPrefix(), err)
+	}
+	signed, err := e.client.SignTx(ctx, e.address, tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.checkSignedChainID(signed, chainID); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// SignAuthorization is not supported: the remote signer can't sign the raw digest (see ErrRemoteSignHashNotSupported)
+func (e *RemoteSignerSign) SignAuthorization(context.Context,
+	types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	return types.SetCodeAuthorization{}, signertypes.ErrAuthorizationNotSupported
+}
+
+// SetBatchConfig sets the parameters used by SignTxs
+func (e *RemoteSignerSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	e.batcher = batch.NewBatcher(cfg)
+}
+
+func (e *RemoteSignerSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	return nil, ErrRemoteSignHashNotSupported
+}
+
+// SignTxs signs a batch of txs using JSON-RPC batch requests. Each request contains
+// at most BatchConcurrency txs. The txs with a wrong chain ID are not sent
+func (e *RemoteSignerSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	results := make([]*types.Transaction, len(txs))
+	errs := make([]error, len(txs))
+	failed := false
+	// pending are the positions of the txs to send
+	pending := make([]int, 0, len(txs))
+	for i, tx := range txs {
+		if err := signertypes.CheckTxChainID(tx, e.chainID); err != nil {
+			errs[i] = fmt.Errorf("%s SignTxs. Err: %w", e.logPrefix(), err)
+			failed = true
+			continue
+		}
+		pending = append(pending, i)
+	}
+	chunkSize := e.batcher.Config().Concurrency
+chunks:
+	for start := 0; start < len(pending); start += chunkSize {
+		positions := pending[start:min(start+chunkSize, len(pending))]
+		chunk := make([]*types.Transaction, len(positions))
+		for i, pos := range positions {
+			chunk[i] = txs[pos]
+			if err := e.batcher.Wait(ctx); err != nil {
+				// Context is done, so the rest of txs are not going to be signed
+				for _, rest := range pending[start:] {
+					errs[rest] = fmt.Errorf("%s SignTxs: tx %d not signed waiting rate limit: %w",
+						e.logPrefix(), rest, err)
+				}
+				failed = true
+				break chunks
+			}
+		}
+		signed, err := e.client.SignTxs(ctx, e.address, chunk)
+		chunkErrs := make([]error, len(chunk))
+		var batchErr *signertypes.BatchError
+		switch {
+		case err == nil:
+		case errors.As(err, &batchErr) && len(batchErr.Errors) == len(chunk):
+			chunkErrs = batchErr.Errors
+		default:
+			// The whole request has failed
+			for i := range chunkErrs {
+				chunkErrs[i] = err
+			}
+		}
+		if len(signed) != len(chunk) {
+			signed = make([]*types.Transaction, len(chunk))
+		}
+		for i, pos := range positions {
+			if chunkErrs[i] == nil && signed[i] != nil {
+				chunkErrs[i] = e.checkSignedChainID(signed[i], e.chainID)
+			}
+			if chunkErrs[i] != nil {
+				errs[pos] = chunkErrs[i]
+				failed = true
+				continue
+			}
+			results[pos] = signed[i]
+		}
+	}
+	if failed {
+		return results, &signertypes.BatchError{Errors: errs}
+	}
+	return results, nil
+}
+
+// Close closes the connections of the client (if it keeps them)
+func (e *RemoteSignerSign) Close() error {
+	if closer, ok := e.client.(clientCloser); ok {
+		closer.Close()
+	}
+	return nil
 }
 
 func (e *RemoteSignerSign) PublicAddress() common.Address {
@@ -2,14 +2,30 @@ package signer
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/agglayer/go_signer/log"
 	"github.com/agglayer/go_signer/signer/mocks"
+	"github.com/agglayer/go_signer/signer/remotesignerclient"
+	"github.com/agglayer/go_signer/signer/safe"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
+var errTestRemote = errors.New("remote fails")
+
 func TestFailsCantSetAddressToUse(t *testing.T) {
 	mockRemoteSignerClient := mocks.NewRemoteSignerClienter(t)
 	ctx := context.TODO()
@@ -47,3 +63,232 @@ func TestFailsSetAddressToUse(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, publicAddr, sut.PublicAddress())
 }
+
+func TestRemoteSignTxsChunks(t *testing.T) {
+	mockRemoteSignerClient := mocks.NewRemoteSignerClienter(t)
+	ctx := context.TODO()
+	publicAddr := common.HexToAddress("0x1234")
+	sut := NewRemoteSignerSign("name", log.WithFields("test", "test"), mockRemoteSignerClient, publicAddr)
+	sut.SetBatchConfig(signertypes.BatchConfig{Concurrency: 2})
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1}),
+		types.NewTx(&types.LegacyTx{Nonce: 2}),
+		types.NewTx(&types.LegacyTx{Nonce: 3}),
+	}
+	mockRemoteSignerClient.EXPECT().SignTxs(ctx, publicAddr, txs[0:2]).Return(txs[0:2], nil).Once()
+	mockRemoteSignerClient.EXPECT().SignTxs(ctx, publicAddr, txs[2:3]).
+		Return(nil, &signertypes.BatchError{Errors: []error{errTestRemote}}).Once()
+	res, err := sut.SignTxs(ctx, txs)
+	require.ErrorIs(t, err, errTestRemote)
+	var batchErr *signertypes.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Len(t, batchErr.Errors, 3)
+	require.Equal(t, 1, batchErr.Failed())
+	require.Len(t, res, 3)
+	require.Equal(t, txs[0], res[0])
+	require.Equal(t, txs[1], res[1])
+	require.Nil(t, res[2])
+}
+
+func TestRemoteInitializeChainID(t *testing.T) {
+	ctx := context.TODO()
+	logger := log.WithFields("test", "test")
+	publicAddr := common.HexToAddress("0x1234")
+	client := mocks.NewRemoteSignerClienter(t)
+
+	client.EXPECT().EthChainID(ctx).Return(big.NewInt(1), nil).Once()
+	require.NoError(t, NewRemoteSignerSignForChain("name", logger, client, publicAddr, 1).Initialize(ctx))
+
+	client.EXPECT().EthChainID(ctx).Return(big.NewInt(2), nil).Once()
+	err := NewRemoteSignerSignForChain("name", logger, client, publicAddr, 1).Initialize(ctx)
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+
+	// A remote signer without eth_chainId is accepted
+	client.EXPECT().EthChainID(ctx).Return(nil, &remotesignerclient.RPCError{Code: -32601}).Once()
+	require.NoError(t, NewRemoteSignerSignForChain("name", logger, client, publicAddr, 1).Initialize(ctx))
+
+	client.EXPECT().EthChainID(ctx).Return(nil, errTestRemote).Once()
+	err = NewRemoteSignerSignForChain("name", logger, client, publicAddr, 1).Initialize(ctx)
+	require.ErrorIs(t, err, errTestRemote)
+}
+
+func TestRemoteSignTxsRateLimitPartial(t *testing.T) {
+	client := mocks.NewRemoteSignerClienter(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	publicAddr := common.HexToAddress("0x1234")
+	sut := NewRemoteSignerSign("name", log.WithFields("test", "test"), client, publicAddr)
+	// 1 tx per request and per second: the second tx can't be sent before the deadline
+	sut.SetBatchConfig(signertypes.BatchConfig{Concurrency: 1, RateLimit: 1})
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1}),
+		types.NewTx(&types.LegacyTx{Nonce: 2}),
+		types.NewTx(&types.LegacyTx{Nonce: 3}),
+	}
+	client.EXPECT().SignTxs(ctx, publicAddr, txs[0:1]).Return(txs[0:1], nil).Once()
+	res, err := sut.SignTxs(ctx, txs)
+	var batchErr *signertypes.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 2, batchErr.Failed())
+	require.NoError(t, batchErr.Errors[0])
+	require.Len(t, res, len(txs))
+	require.Equal(t, txs[0], res[0])
+	require.Nil(t, res[1])
+	require.Nil(t, res[2])
+}
+
+func TestRemoteSignTxChainID(t *testing.T) {
+	client := mocks.NewRemoteSignerClienter(t)
+	ctx := context.TODO()
+	publicAddr := common.HexToAddress("0x1234")
+	sut := NewRemoteSignerSignForChain("name", log.WithFields("test", "test"), client, publicAddr, 1)
+	wrongTx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(2), Nonce: 1})
+	okTx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 2})
+
+	_, err := sut.SignTx(ctx, wrongTx)
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+
+	// The remote signer returns a tx signed for another chain
+	client.EXPECT().SignTx(ctx, publicAddr, okTx).Return(wrongTx, nil).Once()
+	_, err = sut.SignTx(ctx, okTx)
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+
+	// Only okTx is sent to the remote signer
+	client.EXPECT().SignTxs(ctx, publicAddr, []*types.Transaction{okTx}).
+		Return([]*types.Transaction{okTx}, nil).Once()
+	res, err := sut.SignTxs(ctx, []*types.Transaction{wrongTx, okTx})
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+	var batchErr *signertypes.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 1, batchErr.Failed())
+	require.Nil(t, res[0])
+	require.Equal(t, okTx, res[1])
+}
+
+func TestRemoteSignTxForChain(t *testing.T) {
+	client := mocks.NewRemoteSignerClienter(t)
+	ctx := context.TODO()
+	publicAddr := common.HexToAddress("0x1234")
+	sut := NewRemoteSignerSign("name", log.WithFields("test", "test"), client, publicAddr)
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(10), Nonce: 1})
+
+	client.EXPECT().SignTx(ctx, publicAddr, tx).Return(tx, nil).Once()
+	signed, err := sut.SignTxForChain(ctx, 10, tx)
+	require.NoError(t, err)
+	require.Equal(t, tx, signed)
+
+	_, err = sut.SignTxForChain(ctx, 1, tx)
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+}
+
+func TestRemoteSignHashesNotSupported(t *testing.T) {
+	sut := NewRemoteSignerSign("name", log.WithFields("test", "test"), mocks.NewRemoteSignerClienter(t),
+		common.HexToAddress("0x1234"))
+	_, err := sut.SignHashes(context.TODO(), []common.Hash{{}})
+	require.ErrorIs(t, err, ErrRemoteSignHashNotSupported)
+}
+
+func TestNewRemoteSignerConfig(t *testing.T) {
+	cfg, err := NewRemoteSignerConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodRemoteSigner,
+		Config: map[string]any{
+			"URL":     "http://localhost:9001",
+			"Address": "0x71C7656EC7ab88b098defB751B7401B5f6d8976F",
+			"Timeout": "5s",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, RemoteSignerConfig{
+		URL:     "http://localhost:9001",
+		Address: common.HexToAddress("0x71C7656EC7ab88b098defB751B7401B5f6d8976F"),
+		Timeout: 5 * time.Second,
+	}, cfg, 0)
+
+	_, err = NewRemoteSignerConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodRemoteSigner,
+		Config: map[string]any{"URL": "http://localhost:9001", "Timeout": "soon"},
+	})
+	require.ErrorIs(t, err, signertypes.ErrBadConfigParams)
+}
+
+func TestRemoteSignerConfigClientOptions(t *testing.T) {
+	cfg, err := NewRemoteSignerConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodRemoteSigner,
+		Config: map[string]any{
+			"URL":                   "https://localhost:9001",
+			"TLSInsecureSkipVerify": "true",
+			"BearerToken":           "token",
+			"Headers":               map[string]any{"x-api-key": "key"},
+			"Proxy":                 "http://proxy:3128",
+		},
+	})
+	require.NoError(t, err)
+	require.True(t, cfg.TLSInsecureSkipVerify)
+	require.Equal(t, map[string]string{"x-api-key": "key"}, cfg.Headers)
+	opts, err := cfg.ClientOptions()
+	require.NoError(t, err)
+	require.Len(t, opts, 4)
+
+	_, err = NewRemoteSignerConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodRemoteSigner,
+		Config: map[string]any{"URL": "https://localhost:9001", "BearerToken": "token", "BasicAuthUser": "user"},
+	})
+	require.ErrorIs(t, err, ErrRemoteAuth)
+
+	cfg.TLSCert = "client.crt"
+	_, err = NewRemoteSignerSignFromConfigForChain("name", log.WithFields("test", "test"), cfg, 1)
+	require.Error(t, err)
+	// The constructor without chain ID returns the config error on Initialize
+	sut := NewRemoteSignerSignFromConfig("name", log.WithFields("test", "test"), cfg)
+	require.ErrorContains(t, sut.Initialize(context.TODO()), "invalid config")
+	require.NoError(t, sut.Close())
+}
+
+func TestRemoteSignTextForSafe(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	// A remote signer that implements eth_sign (EIP-191 prefix, V 27/28)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params []hexutil.Bytes `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Method != "eth_sign" ||
+			len(request.Params) != 2 || common.BytesToAddress(request.Params[0]) != owner {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		signature, err := crypto.Sign(accounts.TextHash(request.Params[1]), key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		signature[crypto.RecoveryIDOffset] += 27
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"%s"}`, request.ID, hexutil.Encode(signature))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	sut := NewRemoteSignerSign("name", log.WithFields("test", "test"),
+		remotesignerclient.NewRemoteSignerClient(server.URL), owner)
+
+	signature, err := sut.SignText(ctx, []byte("hello"))
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), signature)
+	require.NoError(t, err)
+	require.Equal(t, owner, crypto.PubkeyToAddress(*pub))
+
+	// A Safe owner on a remote signer signs with eth_sign (V 31/32)
+	safeTxHash := common.HexToHash("0x1234")
+	ownerSignature, err := safe.SignEthSign(ctx, sut, safeTxHash)
+	require.NoError(t, err)
+	recovered, err := safe.RecoverOwner(safeTxHash, ownerSignature.Signature)
+	require.NoError(t, err)
+	require.Equal(t, owner, recovered)
+
+	// The client doesn't implement SignText
+	_, err = NewRemoteSignerSign("name", log.WithFields("test", "test"), mocks.NewRemoteSignerClienter(t),
+		owner).SignText(ctx, []byte("hello"))
+	require.ErrorIs(t, err, ErrRemoteSignTextNotSupported)
+}
@@ -2,37 +2,161 @@ package remotesignerclient
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/0xPolygon/cdk-rpc/rpc"
 	"github.com/agglayer/go_signer/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rlp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
+const tracerName = "github.com/agglayer/go_signer/signer/remotesignerclient"
+
+// HTTPStatusError is returned when the remote signer answers with a status code different from 200
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("invalid status code, expected: %v, found: %v", http.StatusOK, e.StatusCode)
+}
+
+// HTTPStatusCode returns the status code of the response
+func (e *HTTPStatusError) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+// RPCError is a JSON-RPC error returned by the remote signer
+type RPCError struct {
+	Code    int
+	Message string
+}
+
+func newRPCError(obj *rpc.ErrorObject) *RPCError {
+	return &RPCError{
+		Code:    obj.Code,
+		Message: obj.Message,
+	}
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("Code:%v Message:%v", e.Code, e.Message)
+}
+
+// RPCErrorCode returns the JSON-RPC error code
+func (e *RPCError) RPCErrorCode() int {
+	return e.Code
+}
+
 // RemoteSignerClient is a client for a remote signer (eth_sign and eth_signTransaction)
 type RemoteSignerClient struct {
-	url string
+	url        string
+	httpClient *http.Client
+	tracer     trace.Tracer
+	// headers are added to each request (e.g. Authorization)
+	headers http.Header
+	// ipcPath is set if url is a Unix socket (unix:///path or a geth-style IPC path)
+	ipcPath string
+
+	// ownTransport is the transport built for the TLS or proxy options (nil if it's the shared default)
+	ownTransport *http.Transport
+
+	// transport parameters, used to build httpClient
+	tracerProvider trace.TracerProvider
+	tlsConfig      *tls.Config
+	proxy          *url.URL
+	timeout        time.Duration
+}
+
+// Option sets an optional parameter of RemoteSignerClient
+type Option func(*RemoteSignerClient)
+
+// WithTracerProvider creates a span for each call and propagates the trace context
+// to the remote signer using W3C headers (traceparent / tracestate)
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *RemoteSignerClient) {
+		c.tracerProvider = tp
+		c.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// WithTimeout sets the timeout of each request to the remote signer
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *RemoteSignerClient) {
+		c.timeout = timeout
+	}
+}
+
+// NewRemoteSignerClient creates a new RemoteSignerClient. url can be an HTTP(S) URL, a Unix
+// socket (unix:///path/to/signer.sock) or a geth-style IPC path (/path/to/clef.ipc).
+// Over a Unix socket the JSON-RPC messages are sent without HTTP, as geth IPC does
+func NewRemoteSignerClient(url string, opts ...Option) *RemoteSignerClient {
+	res := &RemoteSignerClient{
+		url:     url,
+		tracer:  noop.NewTracerProvider().Tracer(tracerName),
+		headers: http.Header{},
+		ipcPath: IPCPath(url),
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	res.httpClient = &http.Client{Transport: res.newTransport(), Timeout: res.timeout}
+	return res
+}
+
+// newTransport returns the default transport or a copy with the TLS and proxy options
+func (e *RemoteSignerClient) newTransport() http.RoundTripper {
+	var transport http.RoundTripper = http.DefaultTransport
+	if e.tlsConfig != nil || e.proxy != nil {
+		custom := http.DefaultTransport.(*http.Transport).Clone()
+		if e.tlsConfig != nil {
+			custom.TLSClientConfig = e.tlsConfig
+		}
+		if e.proxy != nil {
+			custom.Proxy = http.ProxyURL(e.proxy)
+		}
+		transport = custom
+		e.ownTransport = custom
+	}
+	if e.tracerProvider != nil {
+		transport = otelhttp.NewTransport(transport,
+			otelhttp.WithTracerProvider(e.tracerProvider),
+			otelhttp.WithPropagators(propagation.TraceContext{}))
+	}
+	return transport
 }
 
-// NewRemoteSignerClient creates a new RemoteSignerClient
-func NewRemoteSignerClient(url string) *RemoteSignerClient {
-	return &RemoteSignerClient{
-		url: url,
+// Close closes the idle connections of the transport of the client. The default transport is
+// shared with the rest of the process, so it's kept. The client can be used after it
+func (e *RemoteSignerClient) Close() {
+	if e.ownTransport != nil {
+		e.ownTransport.CloseIdleConnections()
 	}
 }
 
 // EthAccounts returns the list of accounts from the remote signer
 func (e *RemoteSignerClient) EthAccounts(ctx context.Context) ([]common.Address, error) {
-	response, err := rpc.JSONRPCCallWithContext(ctx, e.url, "eth_accounts")
+	response, err := e.call(ctx, "eth_accounts")
 	if err != nil {
 		return nil, err
 	}
 
 	if response.Error != nil {
-		return nil, fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+		return nil, fmt.Errorf("eth_accounts fails. %w", newRPCError(response.Error))
 	}
 	var result []common.Address
 	err = json.Unmarshal(response.Result, &result)
@@ -43,18 +167,50 @@ func (e *RemoteSignerClient) EthAccounts(ctx context.Context) ([]common.Address,
 	return result, nil
 }
 
+// EthChainID returns the chain ID of the remote signer (eth_chainId)
+func (e *RemoteSignerClient) EthChainID(ctx context.Context) (*big.Int, error) {
+	response, err := e.call(ctx, "eth_chainId")
+	if err != nil {
+		return nil, fmt.Errorf("eth_chainId RPC call fails. Err: %w", err)
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("eth_chainId fails. %w", newRPCError(response.Error))
+	}
+	var result hexutil.Big
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		return nil, fmt.Errorf("eth_chainId unmarshal fails. Err: %w", err)
+	}
+	return result.ToInt(), nil
+}
+
+// SignText signs text with the remote signer (eth_sign), that adds the EIP-191 personal message prefix
+func (e *RemoteSignerClient) SignText(ctx context.Context, address common.Address, text []byte) ([]byte, error) {
+	response, err := e.call(ctx, "eth_sign", address, hexutil.Bytes(text))
+	if err != nil {
+		return nil, fmt.Errorf("SignText eth_sign RPC call fails. Err: %w", err)
+	}
+	if response.Error != nil {
+		return nil, fmt.Errorf("SignText fails. %w", newRPCError(response.Error))
+	}
+	var result hexutil.Bytes
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		return nil, fmt.Errorf("SignText unmarshal fails. Err: %w", err)
+	}
+	return result, nil
+}
+
 // SignHash signs a hash with the remote signer
 func (e *RemoteSignerClient) SignHash(ctx context.Context,
 	address common.Address,
 	hashToSign common.Hash) ([]byte, error) {
 	params := []interface{}{address, hashToSign}
-	response, err := rpc.JSONRPCCallWithContext(ctx, e.url, "eth_sign", params...)
+	response, err := e.call(ctx, "eth_sign", params...)
 	if err != nil {
 		return nil, fmt.Errorf("signHash eth_sign RPC call fails. Err: %w", err)
 	}
 
 	if response.Error != nil {
-		return nil, fmt.Errorf("signHash fails. Code:%v Message:%v", response.Error.Code, response.Error.Message)
+		return nil, fmt.Errorf("signHash fails. %w", newRPCError(response.Error))
 	}
 	var resultStr string
 	err = json.Unmarshal(response.Result, &resultStr)
@@ -68,6 +224,133 @@ func (e *RemoteSignerClient) SignHash(ctx context.Context,
 // SignTx signs a transaction with the remote signer
 func (e *RemoteSignerClient) SignTx(ctx context.Context,
 	from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	params := signTxParams(from, tx)
+	response, err := e.call(ctx, "eth_signTransaction", params)
+	if err != nil {
+		return nil, fmt.Errorf("SignTx eth_signTransaction RPC call fails. Err: %w", err)
+	}
+	return decodeSignTxResponse(response, tx)
+}
+
+// SignTxs signs a batch of transactions with the remote signer using a single
+// JSON-RPC batch request. The result keeps the order of txs, if some of them fails
+// it returns a *signertypes.BatchError with the error of each one
+func (e *RemoteSignerClient) SignTxs(ctx context.Context,
+	from common.Address, txs []*types.Transaction) ([]*types.Transaction, error) {
+	results := make([]*types.Transaction, len(txs))
+	if len(txs) == 0 {
+		return results, nil
+	}
+	requests := make([]rpc.Request, len(txs))
+	for i, tx := range txs {
+		params, err := json.Marshal([]interface{}{signTxParams(from, tx)})
+		if err != nil {
+			return nil, fmt.Errorf("SignTxs marshal params of tx %d fails. Err: %w", i, err)
+		}
+		requests[i] = rpc.Request{
+			JSONRPC: "2.0",
+			ID:      float64(i),
+			Method:  "eth_signTransaction",
+			Params:  params,
+		}
+	}
+	responses, err := e.batchCall(ctx, requests)
+	if err != nil {
+		return nil, fmt.Errorf("SignTxs eth_signTransaction batch RPC call fails. Err: %w", err)
+	}
+	errs := make([]error, len(txs))
+	for i := range errs {
+		errs[i] = fmt.Errorf("SignTxs: missing response for tx %d", i)
+	}
+	for _, response := range responses {
+		id, ok := response.ID.(float64)
+		if !ok || id < 0 || int(id) >= len(txs) {
+			return nil, fmt.Errorf("SignTxs: unexpected response id %v", response.ID)
+		}
+		i := int(id)
+		results[i], errs[i] = decodeSignTxResponse(response, txs[i])
+	}
+	for _, err := range errs {
+		if err != nil {
+			return results, &signertypes.BatchError{Errors: errs}
+		}
+	}
+	return results, nil
+}
+
+// call executes a JSON-RPC request. It's like rpc.JSONRPCCallWithContext but returning
+// typed errors so the caller can classify them
+func (e *RemoteSignerClient) call(ctx context.Context, method string,
+	params ...interface{}) (_ rpc.Response, err error) {
+	ctx, span := e.tracer.Start(ctx, "remotesigner "+method, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("rpc.system", "jsonrpc"), attribute.String("rpc.method", method)))
+	defer func() { endSpan(span, err) }()
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return rpc.Response{}, err
+	}
+	reqBody, err := json.Marshal(rpc.Request{JSONRPC: "2.0", ID: float64(1), Method: method, Params: paramsJSON})
+	if err != nil {
+		return rpc.Response{}, err
+	}
+	var response rpc.Response
+	if err = e.send(ctx, reqBody, &response); err != nil {
+		return rpc.Response{}, err
+	}
+	return response, nil
+}
+
+func (e *RemoteSignerClient) batchCall(ctx context.Context, requests []rpc.Request) (_ []rpc.Response, err error) {
+	ctx, span := e.tracer.Start(ctx, "remotesigner batch", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("rpc.system", "jsonrpc"), attribute.Int("rpc.batch_size", len(requests))))
+	defer func() { endSpan(span, err) }()
+	reqBody, err := json.Marshal(requests)
+	if err != nil {
+		return nil, err
+	}
+	var responses []rpc.Response
+	if err = e.send(ctx, reqBody, &responses); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// send sends a JSON-RPC request (or batch) over IPC or HTTP and decodes the response into result
+func (e *RemoteSignerClient) send(ctx context.Context, reqBody []byte, result interface{}) error {
+	if e.ipcPath != "" {
+		return e.sendIPC(ctx, reqBody, result)
+	}
+	httpReq, err := rpc.BuildJsonHttpRequestWithBody(ctx, e.url, reqBody)
+	if err != nil {
+		return err
+	}
+	return e.do(httpReq, result)
+}
+
+func (e *RemoteSignerClient) do(httpReq *http.Request, result interface{}) error {
+	for key, values := range e.headers {
+		httpReq.Header[key] = values
+	}
+	httpRes, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+	if httpRes.StatusCode != http.StatusOK {
+		return &HTTPStatusError{StatusCode: httpRes.StatusCode}
+	}
+	return json.NewDecoder(httpRes.Body).Decode(result)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func signTxParams(from common.Address, tx *types.Transaction) map[string]interface{} {
 	params := map[string]interface{}{
 		"from": from.String(),
 	}
@@ -90,27 +373,45 @@ func (e *RemoteSignerClient) SignTx(ctx context.Context,
 	}
 	// Fields maxPriorityFeePerGas and maxFeePerGas are not set because the API doesn't support them:
 	// - https://ethereum.org/en/developers/docs/apis/json-rpc/#eth_signtransaction
-	response, err := rpc.JSONRPCCallWithContext(ctx, e.url, "eth_signTransaction", params)
-	if err != nil {
-		return nil, fmt.Errorf("SignTx eth_signTransaction RPC call fails. Err: %w", err)
+	// except for a SetCodeTx (EIP-7702), that can't be expressed as a legacy tx
+	if tx.Type() == types.SetCodeTxType {
+		delete(params, "gasPrice")
+		params["type"] = hexutil.Uint64(tx.Type())
+		params["chainId"] = (*hexutil.Big)(tx.ChainId())
+		params["maxFeePerGas"] = (*hexutil.Big)(tx.GasFeeCap())
+		params["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.GasTipCap())
+		params["accessList"] = tx.AccessList()
+		params["authorizationList"] = tx.SetCodeAuthorizations()
 	}
+	return params
+}
+
+func decodeSignTxResponse(response rpc.Response, tx *types.Transaction) (*types.Transaction, error) {
 	if response.Error != nil {
-		return nil, fmt.Errorf("SignTx fails. Code:%v Message:%v", response.Error.Code, response.Error.Message)
+		return nil, fmt.Errorf("SignTx fails. %w", newRPCError(response.Error))
 	}
 	var resultStr string
-	if err = json.Unmarshal(response.Result, &resultStr); err != nil {
+	if err := json.Unmarshal(response.Result, &resultStr); err != nil {
 		return nil, fmt.Errorf("SignTx unmarshal fails. Err: %w", err)
 	}
 	log.Debugf("SignTx result: (%d) %s", len(resultStr), resultStr)
 	encodedTx := common.FromHex(resultStr)
-	var resTx *types.Transaction
-	if err = rlp.DecodeBytes(encodedTx, &resTx); err != nil {
-		return nil, fmt.Errorf("SignTx rlp.DecodeBytes fails. Err: %w", err)
+	resTx := new(types.Transaction)
+	if err := resTx.UnmarshalBinary(encodedTx); err != nil {
+		return nil, fmt.Errorf("SignTx decode tx fails. Err: %w", err)
+	}
+	// The API only signs legacy txs (see signTxParams), so other types come back as legacy and
+	// the legacy fields are compared. A SetCodeTx must come back as it is
+	var signer types.Signer = types.NewEIP155Signer(resTx.ChainId())
+	if tx.Type() == types.SetCodeTxType {
+		if resTx.Type() != tx.Type() {
+			return nil, fmt.Errorf("SignTx tx type differs: %d!=%d", tx.Type(), resTx.Type())
+		}
+		signer = types.LatestSignerForChainID(resTx.ChainId())
 	}
-	signer := types.NewEIP155Signer(resTx.ChainId())
 	// sanity check:  Just verify the signingHash
 	if signer.Hash(resTx) != signer.Hash(tx) {
 		return nil, fmt.Errorf("SignTx signingHash differs:  %s!=%s", signer.Hash(tx).String(), signer.Hash(resTx).String())
 	}
-	return resTx, err
+	return resTx, nil
 }
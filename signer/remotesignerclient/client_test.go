@@ -2,10 +2,22 @@ package remotesignerclient
 
 import (
 	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/0xPolygon/cdk-rpc/rpc"
+	signertypes "github.com/agglayer/go_signer/signer/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestWeb3SignerClientUsingDockerNoKey(t *testing.T) {
@@ -20,3 +32,172 @@ func TestWeb3SignerClientUsingDockerNoKey(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, len(addrs) > 0)
 }
+
+func TestSignTxsBatchRequest(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1)
+	txSigner := types.NewEIP155Signer(chainID)
+	txs := []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Value: big.NewInt(0)}),
+		types.NewTx(&types.LegacyTx{Nonce: 2, GasPrice: big.NewInt(1), Value: big.NewInt(0)}),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []rpc.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&requests))
+		require.Len(t, requests, len(txs))
+		responses := make([]rpc.Response, 0, len(requests))
+		// Reply in reverse order to check that the result is sorted by id
+		for i := len(requests) - 1; i >= 0; i-- {
+			response := rpc.Response{JSONRPC: "2.0", ID: requests[i].ID}
+			if i == 1 {
+				response.Error = &rpc.ErrorObject{Code: -32000, Message: "rejected"}
+			} else {
+				signedTx, err := types.SignTx(txs[i], txSigner, privateKey)
+				require.NoError(t, err)
+				encoded, err := signedTx.MarshalBinary()
+				require.NoError(t, err)
+				response.Result, err = json.Marshal(hexutil.Encode(encoded))
+				require.NoError(t, err)
+			}
+			responses = append(responses, response)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(responses))
+	}))
+	defer server.Close()
+
+	sut := NewRemoteSignerClient(server.URL)
+	res, err := sut.SignTxs(context.Background(), crypto.PubkeyToAddress(privateKey.PublicKey), txs)
+	var batchErr *signertypes.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 1, batchErr.Failed())
+	require.Error(t, batchErr.Errors[1])
+	require.Len(t, res, 2)
+	require.Equal(t, txs[0].Nonce(), res[0].Nonce())
+	require.Nil(t, res[1])
+}
+
+func TestTracerProviderPropagatesTraceContext(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		require.NoError(t, json.NewEncoder(w).Encode(rpc.Response{
+			JSONRPC: "2.0",
+			ID:      float64(1),
+			Result:  json.RawMessage(`["0x0000000000000000000000000000000000001234"]`),
+		}))
+	}))
+	defer server.Close()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	sut := NewRemoteSignerClient(server.URL, WithTracerProvider(tp))
+	accounts, err := sut.EthAccounts(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{common.HexToAddress("0x1234")}, accounts)
+	spans := recorder.Ended()
+	// The HTTP span and the JSON-RPC span
+	require.Len(t, spans, 2)
+	require.Equal(t, "remotesigner eth_accounts", spans[1].Name())
+	require.NotEmpty(t, traceparent)
+	require.Contains(t, traceparent, spans[1].SpanContext().TraceID().String())
+}
+
+func TestHTTPStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	sut := NewRemoteSignerClient(server.URL)
+	_, err := sut.EthAccounts(context.Background())
+	var statusErr *HTTPStatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, http.StatusServiceUnavailable, statusErr.HTTPStatusCode())
+}
+
+func TestEthChainID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x539"}`))
+	}))
+	defer server.Close()
+	chainID, err := NewRemoteSignerClient(server.URL).EthChainID(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1337), chainID)
+}
+
+func TestSignSetCodeTx(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1)
+	auth, err := types.SignSetCode(privateKey, types.SetCodeAuthorization{
+		ChainID: *uint256.NewInt(1), Address: common.HexToAddress("0x1234"), Nonce: 2})
+	require.NoError(t, err)
+	tx := types.NewTx(&types.SetCodeTx{ChainID: uint256.NewInt(1), Nonce: 1, GasTipCap: uint256.NewInt(1),
+		GasFeeCap: uint256.NewInt(2), Gas: 50000, AuthList: []types.SetCodeAuthorization{auth}})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request rpc.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		var params []map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(request.Params, &params))
+		require.JSONEq(t, `"0x4"`, string(params[0]["type"]))
+		require.Contains(t, params[0], "authorizationList")
+		require.NotContains(t, params[0], "gasPrice")
+		signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
+		require.NoError(t, err)
+		encoded, err := signedTx.MarshalBinary()
+		require.NoError(t, err)
+		response := rpc.Response{JSONRPC: "2.0", ID: request.ID}
+		response.Result, err = json.Marshal(hexutil.Encode(encoded))
+		require.NoError(t, err)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	signed, err := NewRemoteSignerClient(server.URL).SignTx(context.Background(), from, tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.SetCodeTxType), signed.Type())
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+}
+
+// The API only signs legacy txs, so a DynamicFeeTx comes back as a legacy tx with the same fields
+func TestSignDynamicFeeTxReturnsLegacy(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1)
+	to := common.HexToAddress("0x1234")
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2), Gas: 21000, To: &to, Value: big.NewInt(3)})
+	legacy := types.NewTx(&types.LegacyTx{Nonce: tx.Nonce(), GasPrice: tx.GasPrice(), Gas: tx.Gas(),
+		To: tx.To(), Value: tx.Value(), Data: tx.Data()})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request rpc.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		signedTx, err := types.SignTx(legacy, types.NewEIP155Signer(chainID), privateKey)
+		require.NoError(t, err)
+		encoded, err := signedTx.MarshalBinary()
+		require.NoError(t, err)
+		response := rpc.Response{JSONRPC: "2.0", ID: request.ID}
+		response.Result, err = json.Marshal(hexutil.Encode(encoded))
+		require.NoError(t, err)
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	from := crypto.PubkeyToAddress(privateKey.PublicKey)
+	client := NewRemoteSignerClient(server.URL)
+	signed, err := client.SignTx(context.Background(), from, tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.LegacyTxType), signed.Type())
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+
+	// A SetCodeTx can't come back as legacy
+	setCode := types.NewTx(&types.SetCodeTx{ChainID: uint256.NewInt(1), Nonce: 1, GasTipCap: uint256.NewInt(1),
+		GasFeeCap: uint256.NewInt(2), Gas: 21000, To: to, Value: uint256.NewInt(3)})
+	_, err = client.SignTx(context.Background(), from, setCode)
+	require.ErrorContains(t, err, "tx type differs")
+}
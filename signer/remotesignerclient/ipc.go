@@ -0,0 +1,56 @@
+package remotesignerclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// unixScheme is the prefix of a Unix socket URL
+const unixScheme = "unix://"
+
+// IPCPath returns the path of the Unix socket if url is unix:///path or a geth-style IPC
+// path (a path without scheme). It returns an empty string for a network URL (http://...)
+func IPCPath(url string) string {
+	if strings.HasPrefix(url, unixScheme) {
+		return strings.TrimPrefix(url, unixScheme)
+	}
+	if url != "" && !strings.Contains(url, "://") {
+		return url
+	}
+	return ""
+}
+
+// sendIPC sends reqBody over a new connection to the Unix socket and decodes one JSON
+// response. The connection is closed after each call, so there is no state to recover
+// if the signer restarts
+func (e *RemoteSignerClient) sendIPC(ctx context.Context, reqBody []byte, result interface{}) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", e.ipcPath)
+	if err != nil {
+		return fmt.Errorf("can't connect to IPC %s. Err: %w", e.ipcPath, err)
+	}
+	defer conn.Close()
+	deadline, hasDeadline := ctx.Deadline()
+	if e.timeout > 0 && (!hasDeadline || time.Now().Add(e.timeout).Before(deadline)) {
+		deadline, hasDeadline = time.Now().Add(e.timeout), true
+	}
+	if hasDeadline {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("can't set deadline on IPC %s. Err: %w", e.ipcPath, err)
+		}
+	}
+	// Unblock the read if ctx is canceled before the response arrives
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+	if _, err := conn.Write(reqBody); err != nil {
+		return fmt.Errorf("can't write request to IPC %s. Err: %w", e.ipcPath, err)
+	}
+	if err := json.NewDecoder(conn).Decode(result); err != nil {
+		return fmt.Errorf("can't read response from IPC %s. Err: %w", e.ipcPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package remotesignerclient
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var ipcTestAccount = common.HexToAddress("0x71C7656EC7ab88b098defB751B7401B5f6d8976F")
+
+// ipcTestService is served by a geth rpc.Server as namespace eth
+type ipcTestService struct{}
+
+func (ipcTestService) Accounts() []common.Address {
+	return []common.Address{ipcTestAccount}
+}
+
+func (ipcTestService) Sign(_ common.Address, hash hexutil.Bytes) hexutil.Bytes {
+	return append([]byte{0xff}, hash...)
+}
+
+// startIPCServer starts a geth-style IPC server and returns the socket path
+func startIPCServer(t *testing.T) string {
+	t.Helper()
+	// The socket path has a max length, so it can't be under the long t.TempDir()
+	dir, err := os.MkdirTemp("", "ipc")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "signer.ipc")
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", ipcTestService{}))
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	go server.ServeListener(listener) //nolint:errcheck
+	t.Cleanup(func() {
+		listener.Close()
+		server.Stop()
+	})
+	return path
+}
+
+func TestIPCPath(t *testing.T) {
+	require.Equal(t, "/run/signer.sock", IPCPath("unix:///run/signer.sock"))
+	require.Equal(t, "/root/.clef/clef.ipc", IPCPath("/root/.clef/clef.ipc"))
+	require.Equal(t, "", IPCPath("http://localhost:9000"))
+	require.Equal(t, "", IPCPath(""))
+}
+
+func TestIPC(t *testing.T) {
+	path := startIPCServer(t)
+	ctx := context.Background()
+	for _, url := range []string{"unix://" + path, path} {
+		sut := NewRemoteSignerClient(url, WithTimeout(time.Second))
+		accounts, err := sut.EthAccounts(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{ipcTestAccount}, accounts)
+		signature, err := sut.SignHash(ctx, ipcTestAccount, common.Hash{1})
+		require.NoError(t, err)
+		require.Equal(t, append([]byte{0xff}, common.Hash{1}.Bytes()...), signature)
+		// ipcTestService doesn't implement eth_chainId
+		_, err = sut.EthChainID(ctx)
+		var rpcErr *RPCError
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, -32601, rpcErr.RPCErrorCode())
+	}
+}
+
+func TestIPCNoSocket(t *testing.T) {
+	sut := NewRemoteSignerClient("unix:///nonexistent/signer.sock")
+	_, err := sut.EthAccounts(context.Background())
+	require.ErrorContains(t, err, "can't connect to IPC")
+}
@@ -0,0 +1,91 @@
+package remotesignerclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// ErrClientCertKey is returned if only one of the client certificate and key is set
+	ErrClientCertKey = fmt.Errorf("client certificate and key must be set together")
+	// ErrNoCACerts is returned if the CA bundle doesn't contain any PEM certificate
+	ErrNoCACerts = fmt.Errorf("no PEM certificates found on CA bundle")
+)
+
+// WithTLSConfig sets the TLS config of the connections (CA, client certificates for mTLS...)
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *RemoteSignerClient) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithProxy sends the requests through proxy. By default it's taken from the
+// environment (HTTP_PROXY, HTTPS_PROXY and NO_PROXY)
+func WithProxy(proxy *url.URL) Option {
+	return func(c *RemoteSignerClient) {
+		c.proxy = proxy
+	}
+}
+
+// WithHeaders adds headers to each request
+func WithHeaders(headers http.Header) Option {
+	return func(c *RemoteSignerClient) {
+		for key, values := range headers {
+			c.headers[http.CanonicalHeaderKey(key)] = append([]string{}, values...)
+		}
+	}
+}
+
+// WithBearerToken sets the header Authorization: Bearer token on each request
+func WithBearerToken(token string) Option {
+	return func(c *RemoteSignerClient) {
+		c.headers.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// WithBasicAuth sets the header Authorization: Basic on each request
+func WithBasicAuth(user, password string) Option {
+	return func(c *RemoteSignerClient) {
+		credentials := base64.StdEncoding.EncodeToString([]byte(user + ":" + password))
+		c.headers.Set("Authorization", "Basic "+credentials)
+	}
+}
+
+// NewTLSConfig creates a TLS config from PEM files:
+// - caFile is the CA bundle to verify the server, if empty it uses the system ones
+// - certFile and keyFile are the client certificate for mTLS, both or none must be set
+// - insecureSkipVerify disables the verification of the server certificate (only for testing)
+func NewTLSConfig(caFile, certFile, keyFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	res := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		//nolint:gosec // It's explicitly requested by config, for testing environments
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(filepath.Clean(caFile))
+		if err != nil {
+			return nil, fmt.Errorf("can't read CA bundle %s. Err: %w", caFile, err)
+		}
+		res.RootCAs = x509.NewCertPool()
+		if !res.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA bundle %s. Err: %w", caFile, ErrNoCACerts)
+		}
+	}
+	if (certFile == "") != (keyFile == "") {
+		return nil, ErrClientCertKey
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't load client certificate %s. Err: %w", certFile, err)
+		}
+		res.Certificates = []tls.Certificate{cert}
+	}
+	return res, nil
+}
@@ -0,0 +1,147 @@
+package remotesignerclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const accountsResponse = `{"jsonrpc":"2.0","id":1,"result":["0x71c7656ec7ab88b098defb751b7401b5f6d8976f"]}`
+
+func accountsHandler(check func(r *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if check != nil {
+			check(r)
+		}
+		_, _ = w.Write([]byte(accountsResponse))
+	}
+}
+
+// writeClientCert creates a self-signed client certificate and returns the paths of the PEM files
+func writeClientCert(t *testing.T, dir string) (*x509.Certificate, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return cert, certFile, keyFile
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	clientCert, certFile, keyFile := writeClientCert(t, dir)
+	server := httptest.NewUnstartedServer(accountsHandler(nil))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	t.Run("without client certificate", func(t *testing.T) {
+		tlsConfig, err := NewTLSConfig(caFile, "", "", false)
+		require.NoError(t, err)
+		_, err = NewRemoteSignerClient(server.URL, WithTLSConfig(tlsConfig)).EthAccounts(context.Background())
+		require.Error(t, err)
+	})
+	t.Run("unknown CA", func(t *testing.T) {
+		tlsConfig, err := NewTLSConfig("", certFile, keyFile, false)
+		require.NoError(t, err)
+		_, err = NewRemoteSignerClient(server.URL, WithTLSConfig(tlsConfig)).EthAccounts(context.Background())
+		require.Error(t, err)
+	})
+	t.Run("mTLS", func(t *testing.T) {
+		tlsConfig, err := NewTLSConfig(caFile, certFile, keyFile, false)
+		require.NoError(t, err)
+		addrs, err := NewRemoteSignerClient(server.URL, WithTLSConfig(tlsConfig)).EthAccounts(context.Background())
+		require.NoError(t, err)
+		require.Len(t, addrs, 1)
+	})
+}
+
+func TestNewTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, certFile, _ := writeClientCert(t, dir)
+	_, err := NewTLSConfig("", certFile, "", false)
+	require.ErrorIs(t, err, ErrClientCertKey)
+	_, err = NewTLSConfig(filepath.Join(dir, "missing.pem"), "", "", false)
+	require.Error(t, err)
+	noCerts := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(noCerts, []byte("nothing"), 0600))
+	_, err = NewTLSConfig(noCerts, "", "", false)
+	require.ErrorIs(t, err, ErrNoCACerts)
+}
+
+func TestAuthHeaders(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(accountsHandler(func(r *http.Request) { received = r.Header.Clone() }))
+	defer server.Close()
+
+	_, err := NewRemoteSignerClient(server.URL, WithBearerToken("token"),
+		WithHeaders(http.Header{"x-api-key": {"key"}})).EthAccounts(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Bearer token", received.Get("Authorization"))
+	require.Equal(t, "key", received.Get("X-Api-Key"))
+
+	_, err = NewRemoteSignerClient(server.URL, WithBasicAuth("user", "pass")).EthAccounts(context.Background())
+	require.NoError(t, err)
+	req := &http.Request{Header: received}
+	user, pass, ok := req.BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "user", user)
+	require.Equal(t, "pass", pass)
+}
+
+func TestProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(accountsHandler(func(r *http.Request) { proxied = r.URL.Host }))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	_, err = NewRemoteSignerClient("http://remote-signer.invalid:9000", WithProxy(proxyURL)).
+		EthAccounts(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "remote-signer.invalid:9000", proxied)
+}
+
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(accountsHandler(func(r *http.Request) { time.Sleep(200 * time.Millisecond) }))
+	defer server.Close()
+	_, err := NewRemoteSignerClient(server.URL, WithTimeout(20*time.Millisecond)).EthAccounts(context.Background())
+	require.Error(t, err)
+	_, err = NewRemoteSignerClient(server.URL, WithTimeout(time.Second)).EthAccounts(context.Background())
+	require.NoError(t, err)
+}
@@ -0,0 +1,384 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// FieldRetryMaxAttempts is the max number of attempts for a call (1 = no retries)
+	FieldRetryMaxAttempts = "RetryMaxAttempts"
+	// FieldRetryInitialBackoff is the backoff before the first retry, it's doubled on each retry
+	FieldRetryInitialBackoff = "RetryInitialBackoff"
+	// FieldRetryMaxBackoff is the max backoff between retries
+	FieldRetryMaxBackoff = "RetryMaxBackoff"
+	// FieldRateLimit is the max number of requests per second for the key (0 = unlimited)
+	FieldRateLimit = "RateLimit"
+	// FieldRateBurst is the max burst of requests for the key
+	FieldRateBurst = "RateBurst"
+
+	defaultRetryMaxAttempts    = 5
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+)
+
+// RetryConfig is the configuration for RetrySign
+type RetryConfig struct {
+	// MaxAttempts is the max number of attempts for a call (1 = no retries)
+	MaxAttempts int
+	// InitialBackoff is the backoff before the first retry, it's doubled on each retry
+	InitialBackoff time.Duration
+	// MaxBackoff is the max backoff between retries
+	MaxBackoff time.Duration
+	// RateLimit is the max number of requests per second for the key (0 = unlimited)
+	RateLimit float64
+	// RateBurst is the max burst of requests for the key
+	RateBurst int
+}
+
+// DefaultRetryConfig returns the RetryConfig used if nothing is set in config
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    defaultRetryMaxAttempts,
+		InitialBackoff: defaultRetryInitialBackoff,
+		MaxBackoff:     defaultRetryMaxBackoff,
+	}
+}
+
+// NewRetryConfig extracts the retry and rate limit parameters from a SignerConfig
+func NewRetryConfig(cfg signertypes.SignerConfig) (RetryConfig, error) {
+	res := DefaultRetryConfig()
+	var err error
+	if res.MaxAttempts, err = getOptional(cfg, FieldRetryMaxAttempts, res.MaxAttempts, cfg.GetInt); err != nil {
+		return res, err
+	}
+	if res.InitialBackoff, err = getOptional(cfg, FieldRetryInitialBackoff, res.InitialBackoff,
+		cfg.GetDuration); err != nil {
+		return res, err
+	}
+	if res.MaxBackoff, err = getOptional(cfg, FieldRetryMaxBackoff, res.MaxBackoff, cfg.GetDuration); err != nil {
+		return res, err
+	}
+	if res.RateLimit, err = getOptional(cfg, FieldRateLimit, res.RateLimit, cfg.GetFloat); err != nil {
+		return res, err
+	}
+	if res.RateBurst, err = getOptional(cfg, FieldRateBurst, res.RateBurst, cfg.GetInt); err != nil {
+		return res, err
+	}
+	if res.MaxAttempts < 1 {
+		return res, fmt.Errorf("config %s: field %s must be >= 1. Err: %w",
+			cfg.Method, FieldRetryMaxAttempts, signertypes.ErrBadConfigParams)
+	}
+	if res.InitialBackoff < 0 || res.MaxBackoff < res.InitialBackoff {
+		return res, fmt.Errorf("config %s: fields %s / %s must be 0 <= %s <= %s. Err: %w",
+			cfg.Method, FieldRetryInitialBackoff, FieldRetryMaxBackoff, FieldRetryInitialBackoff,
+			FieldRetryMaxBackoff, signertypes.ErrBadConfigParams)
+	}
+	if res.RateLimit < 0 || res.RateBurst < 0 {
+		return res, fmt.Errorf("config %s: fields %s and %s must be >= 0. Err: %w",
+			cfg.Method, FieldRateLimit, FieldRateBurst, signertypes.ErrBadConfigParams)
+	}
+	return res, nil
+}
+
+func getOptional[T any](cfg signertypes.SignerConfig, key string, defaultValue T,
+	getter func(string) (T, error)) (T, error) {
+	v, err := getter(key)
+	if errors.Is(err, signertypes.ErrMissingConfigParam) {
+		return defaultValue, nil
+	}
+	if err != nil {
+		return defaultValue, fmt.Errorf("config %s: error in field %s. Err: %w", cfg.Method, key, err)
+	}
+	return v, nil
+}
+
+func (c RetryConfig) String() string {
+	return fmt.Sprintf("RetryConfig{maxAttempts: %d, backoff: %s-%s, rateLimit: %.2f/s burst: %d}",
+		c.MaxAttempts, c.InitialBackoff, c.MaxBackoff, c.RateLimit, c.RateBurst)
+}
+
+// keyLimiter is the token bucket of a key and the number of RetrySign using it
+type keyLimiter struct {
+	limiter *rate.Limiter
+	refs    int
+}
+
+var (
+	// keyLimiters keeps a token bucket per key, so all the signers that use the same
+	// key share the same rate limit. An entry is removed when its last RetrySign is closed
+	keyLimiters   = map[string]*keyLimiter{}
+	keyLimitersMu sync.Mutex
+)
+
+// acquireLimiter returns the token bucket for a key, creating it if needed. If it already
+// exists its rate is updated to cfg (the last configuration wins, e.g. after a reload).
+// releaseLimiter must be called when it's not used anymore
+func acquireLimiter(key string, cfg RetryConfig) *rate.Limiter {
+	if cfg.RateLimit <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	burst := cfg.RateBurst
+	if burst <= 0 {
+		burst = max(1, int(cfg.RateLimit))
+	}
+	keyLimitersMu.Lock()
+	defer keyLimitersMu.Unlock()
+	entry, ok := keyLimiters[key]
+	if !ok {
+		entry = &keyLimiter{limiter: rate.NewLimiter(rate.Limit(cfg.RateLimit), burst)}
+		keyLimiters[key] = entry
+	} else {
+		entry.limiter.SetLimit(rate.Limit(cfg.RateLimit))
+		entry.limiter.SetBurst(burst)
+	}
+	entry.refs++
+	return entry.limiter
+}
+
+// releaseLimiter releases a token bucket returned by acquireLimiter, the entry of key
+// is removed once nobody uses it
+func releaseLimiter(key string, limiter *rate.Limiter) {
+	keyLimitersMu.Lock()
+	defer keyLimitersMu.Unlock()
+	entry, ok := keyLimiters[key]
+	if !ok || entry.limiter != limiter {
+		// It's an unlimited token bucket, it's not shared
+		return
+	}
+	entry.refs--
+	if entry.refs == 0 {
+		delete(keyLimiters, key)
+	}
+}
+
+// RetrySign is a decorator over a signer that rate limits the calls using a token bucket
+// per key and retries with exponential backoff (and jitter) the transient errors
+type RetrySign struct {
+	name    string
+	logger  signercommon.Logger
+	signer  signertypes.Signer
+	cfg     RetryConfig
+	keyID   string
+	limiter *rate.Limiter
+	// closeOnce releases the token bucket only once
+	closeOnce sync.Once
+}
+
+var _ signertypes.Signer = (*RetrySign)(nil)
+
+// NewRetrySign creates a RetrySign over signer
+// keyID identifies the key used by signer (e.g. KMS key name), signers with the same keyID
+// share the rate limit. Close releases it
+func NewRetrySign(name string, logger signercommon.Logger, signer signertypes.Signer,
+	keyID string, cfg RetryConfig) *RetrySign {
+	return &RetrySign{
+		name:    name,
+		logger:  logger,
+		signer:  signer,
+		cfg:     cfg,
+		keyID:   keyID,
+		limiter: acquireLimiter(keyID, cfg),
+	}
+}
+
+// Unwrap returns the decorated signer
+func (r *RetrySign) Unwrap() signertypes.Signer {
+	return r.signer
+}
+
+// Initialize initializes the decorated signer retrying on transient errors
+func (r *RetrySign) Initialize(ctx context.Context) error {
+	_, err := retryCall(ctx, r, "Initialize", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, r.signer.Initialize(ctx)
+	})
+	return err
+}
+
+func (r *RetrySign) PublicAddress() common.Address {
+	return r.signer.PublicAddress()
+}
+
+func (r *RetrySign) String() string {
+	return r.signer.String()
+}
+
+// Close releases the rate limit of the key and closes the wrapped signer
+func (r *RetrySign) Close() error {
+	r.closeOnce.Do(func() {
+		releaseLimiter(r.keyID, r.limiter)
+	})
+	return r.signer.Close()
+}
+
+// SignHash signs a hash retrying on transient errors
+func (r *RetrySign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return retryCall(ctx, r, "SignHash", func(ctx context.Context) ([]byte, error) {
+		return r.signer.SignHash(ctx, hash)
+	})
+}
+
+// SignTx signs a tx retrying on transient errors
+func (r *RetrySign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return retryCall(ctx, r, "SignTx", func(ctx context.Context) (*types.Transaction, error) {
+		return r.signer.SignTx(ctx, tx)
+	})
+}
+
+func (r *RetrySign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	return retryCall(ctx, r, "SignTxForChain", func(ctx context.Context) (*types.Transaction, error) {
+		return r.signer.SignTxForChain(ctx, chainID, tx)
+	})
+}
+
+func (r *RetrySign) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	return retryCall(ctx, r, "SignAuthorization", func(ctx context.Context) (types.SetCodeAuthorization, error) {
+		return r.signer.SignAuthorization(ctx, auth)
+	})
+}
+
+// SetBatchConfig sets the batch parameters of the decorated signer
+func (r *RetrySign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	if configurable, ok := r.signer.(batchConfigurable); ok {
+		configurable.SetBatchConfig(cfg)
+	}
+}
+
+// SignHashes signs a batch of hashes retrying only the items that fail with transient errors
+func (r *RetrySign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	return retryBatch(ctx, r, "SignHashes", hashes, r.signer.SignHashes)
+}
+
+// SignTxs signs a batch of txs retrying only the items that fail with transient errors
+func (r *RetrySign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	return retryBatch(ctx, r, "SignTxs", txs, r.signer.SignTxs)
+}
+
+// backoff returns the time to wait before the retry number attempt (1-based) using full jitter
+func (r *RetrySign) backoff(attempt int) time.Duration {
+	maxWait := r.cfg.InitialBackoff
+	for i := 1; i < attempt && maxWait < r.cfg.MaxBackoff; i++ {
+		maxWait *= 2
+	}
+	maxWait = min(maxWait, r.cfg.MaxBackoff)
+	if maxWait <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(maxWait) + 1)) //nolint:gosec // jitter doesn't require a secure random
+}
+
+// wait sleeps before the retry number attempt, returns error if ctx is done
+func (r *RetrySign) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(r.backoff(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *RetrySign) logPrefix() string {
+	return fmt.Sprintf("signer: retry[%s]: ", r.name)
+}
+
+func retryCall[R any](ctx context.Context, r *RetrySign, operation string,
+	fn func(context.Context) (R, error)) (R, error) {
+	var (
+		res R
+		err error
+	)
+	for attempt := 1; ; attempt++ {
+		if err = r.limiter.Wait(ctx); err != nil {
+			return res, fmt.Errorf("%s %s waiting rate limit. Err: %w", r.logPrefix(), operation, err)
+		}
+		res, err = fn(ctx)
+		if err == nil {
+			return res, nil
+		}
+		class := ClassifyError(err)
+		if !class.Retryable() || attempt >= r.cfg.MaxAttempts {
+			return res, err
+		}
+		r.logger.Warnf("%s %s attempt %d/%d fails (%s), retrying. Err: %s",
+			r.logPrefix(), operation, attempt, r.cfg.MaxAttempts, class, err.Error())
+		if waitErr := r.wait(ctx, attempt); waitErr != nil {
+			return res, err
+		}
+	}
+}
+
+func retryBatch[T any, R any](ctx context.Context, r *RetrySign, operation string, items []T,
+	fn func(context.Context, []T) ([]R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	pending := make([]int, len(items))
+	for i := range items {
+		pending[i] = i
+	}
+	for attempt := 1; len(pending) > 0; attempt++ {
+		for range pending {
+			if err := r.limiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("%s %s waiting rate limit. Err: %w", r.logPrefix(), operation, err)
+			}
+		}
+		pendingItems := make([]T, len(pending))
+		for j, i := range pending {
+			pendingItems[j] = items[i]
+		}
+		res, err := fn(ctx, pendingItems)
+		var batchErr *signertypes.BatchError
+		if err != nil && !(errors.As(err, &batchErr) && len(batchErr.Errors) == len(pending)) {
+			// The whole call fails
+			batchErr = &signertypes.BatchError{Errors: make([]error, len(pending))}
+			for j := range pending {
+				batchErr.Errors[j] = err
+			}
+		}
+		retry := make([]int, 0, len(pending))
+		for j, i := range pending {
+			var itemErr error
+			if batchErr != nil {
+				itemErr = batchErr.Errors[j]
+			}
+			errs[i] = itemErr
+			if itemErr == nil {
+				if j < len(res) {
+					results[i] = res[j]
+				}
+				continue
+			}
+			if ClassifyError(itemErr).Retryable() {
+				retry = append(retry, i)
+			}
+		}
+		if len(retry) == 0 || attempt >= r.cfg.MaxAttempts {
+			break
+		}
+		r.logger.Warnf("%s %s attempt %d/%d: %d items fail with transient errors, retrying",
+			r.logPrefix(), operation, attempt, r.cfg.MaxAttempts, len(retry))
+		if err := r.wait(ctx, attempt); err != nil {
+			break
+		}
+		pending = retry
+	}
+	for _, err := range errs {
+		if err != nil {
+			return results, &signertypes.BatchError{Errors: errs}
+		}
+	}
+	return results, nil
+}
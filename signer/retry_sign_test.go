@@ -0,0 +1,137 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agglayer/go_signer/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/agglayer/go_signer/signer/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestRetrySign(t *testing.T, maxAttempts int) (*RetrySign, *mocks.Signer) {
+	t.Helper()
+	inner := mocks.NewSigner(t)
+	cfg := RetryConfig{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}
+	return NewRetrySign("test", log.WithFields("test", "test"), inner, t.Name(), cfg), inner
+}
+
+func TestRetrySignRetriesTransientErrors(t *testing.T) {
+	sut, inner := newTestRetrySign(t, 3)
+	ctx := context.TODO()
+	hash := common.Hash{1}
+	inner.EXPECT().SignHash(mock.Anything, hash).Return(nil, status.Error(codes.ResourceExhausted, "quota")).Once()
+	inner.EXPECT().SignHash(mock.Anything, hash).Return([]byte{1, 2, 3}, nil).Once()
+	signature, err := sut.SignHash(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, signature)
+}
+
+func TestRetrySignDoesntRetryPermanentErrors(t *testing.T) {
+	sut, inner := newTestRetrySign(t, 3)
+	permanentErr := status.Error(codes.PermissionDenied, "no")
+	inner.EXPECT().SignHash(mock.Anything, common.Hash{}).Return(nil, permanentErr).Once()
+	_, err := sut.SignHash(context.TODO(), common.Hash{})
+	require.ErrorIs(t, err, permanentErr)
+}
+
+func TestRetrySignMaxAttempts(t *testing.T) {
+	sut, inner := newTestRetrySign(t, 2)
+	transientErr := status.Error(codes.Unavailable, "down")
+	inner.EXPECT().Initialize(mock.Anything).Return(transientErr).Times(2)
+	err := sut.Initialize(context.TODO())
+	require.ErrorIs(t, err, transientErr)
+}
+
+func TestRetrySignBatchRetriesOnlyFailedItems(t *testing.T) {
+	sut, inner := newTestRetrySign(t, 3)
+	hashes := []common.Hash{{1}, {2}, {3}}
+	transientErr := status.Error(codes.ResourceExhausted, "quota")
+	permanentErr := errors.New("bad key")
+	inner.EXPECT().SignHashes(mock.Anything, hashes).Return([][]byte{{1}, nil, nil},
+		&signertypes.BatchError{Errors: []error{nil, transientErr, permanentErr}}).Once()
+	inner.EXPECT().SignHashes(mock.Anything, []common.Hash{{2}}).Return([][]byte{{2}}, nil).Once()
+	res, err := sut.SignHashes(context.TODO(), hashes)
+	var batchErr *signertypes.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, 1, batchErr.Failed())
+	require.ErrorIs(t, batchErr.Errors[2], permanentErr)
+	require.Equal(t, [][]byte{{1}, {2}, nil}, res)
+}
+
+func TestNewRetryConfig(t *testing.T) {
+	cfg, err := NewRetryConfig(signertypes.SignerConfig{Method: signertypes.MethodAWSKMS})
+	require.NoError(t, err)
+	require.Equal(t, DefaultRetryConfig(), cfg)
+
+	cfg, err = NewRetryConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodAWSKMS,
+		Config: map[string]any{
+			"retrymaxattempts":    int64(3),
+			"retryinitialbackoff": "50ms",
+			"retrymaxbackoff":     "1s",
+			"ratelimit":           int64(10),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, RetryConfig{
+		MaxAttempts:    3,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		RateLimit:      10,
+	}, cfg)
+
+	_, err = NewRetryConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodAWSKMS,
+		Config: map[string]any{FieldRetryMaxAttempts: 0},
+	})
+	require.ErrorIs(t, err, signertypes.ErrBadConfigParams)
+	_, err = NewRetryConfig(signertypes.SignerConfig{
+		Method: signertypes.MethodAWSKMS,
+		Config: map[string]any{FieldRetryInitialBackoff: "10s", FieldRetryMaxBackoff: "1s"},
+	})
+	require.ErrorIs(t, err, signertypes.ErrBadConfigParams)
+}
+
+func TestLimiterForKeyIsShared(t *testing.T) {
+	cfg := RetryConfig{RateLimit: 5}
+	l1 := acquireLimiter(t.Name(), cfg)
+	l2 := acquireLimiter(t.Name(), cfg)
+	require.Same(t, l1, l2)
+	require.Equal(t, 5, l1.Burst())
+
+	// A new config (e.g. a reload) updates the shared token bucket
+	l3 := acquireLimiter(t.Name(), RetryConfig{RateLimit: 20, RateBurst: 2})
+	require.Same(t, l1, l3)
+	require.Equal(t, rate.Limit(20), l1.Limit())
+	require.Equal(t, 2, l1.Burst())
+
+	// The entry is removed when the last user releases it
+	releaseLimiter(t.Name(), l1)
+	releaseLimiter(t.Name(), l2)
+	require.Contains(t, keyLimiters, t.Name())
+	releaseLimiter(t.Name(), l3)
+	require.NotContains(t, keyLimiters, t.Name())
+}
+
+func TestRetrySignCloseReleasesLimiter(t *testing.T) {
+	inner := mocks.NewSigner(t)
+	inner.EXPECT().Close().Return(nil).Twice()
+	sut := NewRetrySign("test", log.WithFields("test", "test"), inner, t.Name(), RetryConfig{RateLimit: 5})
+	require.Contains(t, keyLimiters, t.Name())
+	require.NoError(t, sut.Close())
+	require.NoError(t, sut.Close())
+	require.NotContains(t, keyLimiters, t.Name())
+}
@@ -0,0 +1,238 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+const (
+	// DefaultRotationGracePeriod is the max time Rotate waits for the calls in-flight on the old key
+	DefaultRotationGracePeriod = 30 * time.Second
+)
+
+// AddressChange is the event sent when a RotatingSign switches to a new key
+type AddressChange struct {
+	Old  common.Address
+	New  common.Address
+	Time time.Time
+}
+
+// rotatingEntry is a signer with the calls in-flight on it
+type rotatingEntry struct {
+	signer   signertypes.Signer
+	inFlight sync.WaitGroup
+}
+
+// RotatingSign is a decorator that allows to switch to a new key (signer) without
+// restarting the process. The calls in-flight complete on the old key
+type RotatingSign struct {
+	mu          sync.RWMutex
+	name        string
+	logger      signercommon.Logger
+	current     *rotatingEntry
+	gracePeriod time.Duration
+	batchCfg    *signertypes.BatchConfig
+
+	subsMu  sync.Mutex
+	subs    map[int]chan<- AddressChange
+	nextSub int
+}
+
+var _ signertypes.Signer = (*RotatingSign)(nil)
+
+// NewRotatingSign creates a RotatingSign that starts using signer
+// gracePeriod is the max time that Rotate waits for the calls in-flight on the old key
+func NewRotatingSign(name string, logger signercommon.Logger, signer signertypes.Signer,
+	gracePeriod time.Duration) *RotatingSign {
+	return &RotatingSign{
+		name:        name,
+		logger:      logger,
+		current:     &rotatingEntry{signer: signer},
+		gracePeriod: gracePeriod,
+	}
+}
+
+// Rotate initializes newSigner and switches to it. The new calls use newSigner and
+// Rotate waits (up to gracePeriod) for the calls in-flight on the old one, that is
+// closed once they complete. The subscribers receive an AddressChange. If it fails
+// newSigner is not used and the caller must close it
+func (r *RotatingSign) Rotate(ctx context.Context, newSigner signertypes.Signer) error {
+	if err := newSigner.Initialize(ctx); err != nil {
+		return fmt.Errorf("%s can't initialize new signer. Err: %w", r.logPrefix(), err)
+	}
+	r.waitInFlight(ctx, r.swap(newSigner))
+	return nil
+}
+
+// swap switches to newSigner (already initialized), notifies the subscribers and
+// returns the old entry
+func (r *RotatingSign) swap(newSigner signertypes.Signer) *rotatingEntry {
+	r.mu.Lock()
+	if r.batchCfg != nil {
+		if configurable, ok := newSigner.(batchConfigurable); ok {
+			configurable.SetBatchConfig(*r.batchCfg)
+		}
+	}
+	old := r.current
+	r.current = &rotatingEntry{signer: newSigner}
+	r.mu.Unlock()
+
+	change := AddressChange{
+		Old:  old.signer.PublicAddress(),
+		New:  newSigner.PublicAddress(),
+		Time: time.Now(),
+	}
+	r.logger.Infof("%s rotated key from %s to %s", r.logPrefix(), change.Old.Hex(), change.New.Hex())
+	r.notify(change)
+	return old
+}
+
+// SubscribeAddressChange sends an AddressChange to ch each time the key is rotated.
+// Rotate doesn't wait for ch: if it's full the event is dropped, so ch must be buffered
+func (r *RotatingSign) SubscribeAddressChange(ch chan<- AddressChange) event.Subscription {
+	r.subsMu.Lock()
+	if r.subs == nil {
+		r.subs = map[int]chan<- AddressChange{}
+	}
+	id := r.nextSub
+	r.nextSub++
+	r.subs[id] = ch
+	r.subsMu.Unlock()
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		r.subsMu.Lock()
+		delete(r.subs, id)
+		r.subsMu.Unlock()
+		return nil
+	})
+}
+
+// notify sends change to the subscribers that are ready to receive it
+func (r *RotatingSign) notify(change AddressChange) {
+	r.subsMu.Lock()
+	defer r.subsMu.Unlock()
+	for _, ch := range r.subs {
+		select {
+		case ch <- change:
+		default:
+			r.logger.Warnf("%s subscriber not ready, address change to %s dropped", r.logPrefix(), change.New.Hex())
+		}
+	}
+}
+
+// waitInFlight waits (up to gracePeriod) for the calls in-flight on old. The old signer is
+// closed when they complete, also if it happens after returning
+func (r *RotatingSign) waitInFlight(ctx context.Context, old *rotatingEntry) {
+	done := make(chan struct{})
+	go func() {
+		old.inFlight.Wait()
+		if err := old.signer.Close(); err != nil {
+			r.logger.Warnf("%s can't close old key %s. Err: %v", r.logPrefix(), old.signer.PublicAddress().Hex(), err)
+		}
+		close(done)
+	}()
+	timer := time.NewTimer(r.gracePeriod)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		r.logger.Warnf("%s grace period %s expired with calls in-flight on old key %s",
+			r.logPrefix(), r.gracePeriod, old.signer.PublicAddress().Hex())
+	case <-ctx.Done():
+	}
+}
+
+// acquire returns the current signer and marks a call in-flight on it, release must be called
+func (r *RotatingSign) acquire() *rotatingEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry := r.current
+	entry.inFlight.Add(1)
+	return entry
+}
+
+// Unwrap returns the current signer
+func (r *RotatingSign) Unwrap() signertypes.Signer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.signer
+}
+
+func (r *RotatingSign) Initialize(ctx context.Context) error {
+	entry := r.acquire()
+	defer entry.inFlight.Done()
+	return entry.signer.Initialize(ctx)
+}
+
+// Close closes the current signer, it must not be used after it
+func (r *RotatingSign) Close() error {
+	return r.Unwrap().Close()
+}
+
+func (r *RotatingSign) PublicAddress() common.Address {
+	return r.Unwrap().PublicAddress()
+}
+
+func (r *RotatingSign) String() string {
+	return r.Unwrap().String()
+}
+
+func (r *RotatingSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	entry := r.acquire()
+	defer entry.inFlight.Done()
+	return entry.signer.SignHash(ctx, hash)
+}
+
+func (r *RotatingSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	entry := r.acquire()
+	defer entry.inFlight.Done()
+	return entry.signer.SignTx(ctx, tx)
+}
+
+func (r *RotatingSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	entry := r.acquire()
+	defer entry.inFlight.Done()
+	return entry.signer.SignTxForChain(ctx, chainID, tx)
+}
+
+func (r *RotatingSign) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	entry := r.acquire()
+	defer entry.inFlight.Done()
+	return entry.signer.SignAuthorization(ctx, auth)
+}
+
+// SetBatchConfig sets the batch parameters of the current signer and the next ones
+func (r *RotatingSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batchCfg = &cfg
+	if configurable, ok := r.current.signer.(batchConfigurable); ok {
+		configurable.SetBatchConfig(cfg)
+	}
+}
+
+func (r *RotatingSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	entry := r.acquire()
+	defer entry.inFlight.Done()
+	return entry.signer.SignHashes(ctx, hashes)
+}
+
+func (r *RotatingSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	entry := r.acquire()
+	defer entry.inFlight.Done()
+	return entry.signer.SignTxs(ctx, txs)
+}
+
+func (r *RotatingSign) logPrefix() string {
+	return fmt.Sprintf("signer: rotating[%s]: ", r.name)
+}
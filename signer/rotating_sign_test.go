@@ -0,0 +1,125 @@
+package signer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agglayer/go_signer/log"
+	"github.com/agglayer/go_signer/signer/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingSign(t *testing.T) {
+	ctx := context.TODO()
+	oldAddr := common.HexToAddress("0x1")
+	newAddr := common.HexToAddress("0x2")
+	oldSigner := mocks.NewSigner(t)
+	oldSigner.EXPECT().PublicAddress().Return(oldAddr)
+	oldSigner.EXPECT().Close().Return(nil).Once()
+	newSigner := mocks.NewSigner(t)
+	newSigner.EXPECT().PublicAddress().Return(newAddr)
+	newSigner.EXPECT().Initialize(mock.Anything).Return(nil).Once()
+	sut := NewRotatingSign("test", log.WithFields("test", "test"), oldSigner, time.Minute)
+	changes := make(chan AddressChange, 1)
+	sub := sut.SubscribeAddressChange(changes)
+	defer sub.Unsubscribe()
+
+	// A call in-flight on the old key
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	oldSigner.EXPECT().SignHash(mock.Anything, common.Hash{}).Run(func(context.Context, common.Hash) {
+		close(started)
+		<-unblock
+	}).Return([]byte{1}, nil).Once()
+	signed := make(chan []byte)
+	go func() {
+		res, _ := sut.SignHash(ctx, common.Hash{})
+		signed <- res
+	}()
+	<-started
+
+	rotated := make(chan error)
+	go func() {
+		rotated <- sut.Rotate(ctx, newSigner)
+	}()
+	change := <-changes
+	require.Equal(t, oldAddr, change.Old)
+	require.Equal(t, newAddr, change.New)
+	require.Equal(t, newAddr, sut.PublicAddress())
+
+	// New calls use the new key while Rotate waits for the old one
+	newSigner.EXPECT().SignHash(mock.Anything, common.Hash{}).Return([]byte{2}, nil).Once()
+	res, err := sut.SignHash(ctx, common.Hash{})
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, res)
+	select {
+	case <-rotated:
+		require.Fail(t, "Rotate must wait for the calls in-flight")
+	default:
+	}
+
+	close(unblock)
+	require.Equal(t, []byte{1}, <-signed)
+	require.NoError(t, <-rotated)
+}
+
+func TestRotatingSignGracePeriod(t *testing.T) {
+	ctx := context.TODO()
+	oldSigner := mocks.NewSigner(t)
+	oldSigner.EXPECT().PublicAddress().Return(common.HexToAddress("0x1"))
+	newSigner := mocks.NewSigner(t)
+	newSigner.EXPECT().PublicAddress().Return(common.HexToAddress("0x2"))
+	newSigner.EXPECT().Initialize(mock.Anything).Return(nil).Once()
+	sut := NewRotatingSign("test", log.WithFields("test", "test"), oldSigner, 10*time.Millisecond)
+
+	unblock := make(chan struct{})
+	started := make(chan struct{})
+	oldSigner.EXPECT().SignHash(mock.Anything, common.Hash{}).Run(func(context.Context, common.Hash) {
+		close(started)
+		<-unblock
+	}).Return(nil, nil).Once()
+	closed := make(chan struct{})
+	oldSigner.EXPECT().Close().Run(func() { close(closed) }).Return(nil).Once()
+	go func() {
+		_, _ = sut.SignHash(ctx, common.Hash{})
+	}()
+	<-started
+	require.NoError(t, sut.Rotate(ctx, newSigner))
+
+	// The old key is closed once the call in-flight completes
+	select {
+	case <-closed:
+		require.Fail(t, "the old signer must not be closed with calls in-flight")
+	default:
+	}
+	close(unblock)
+	<-closed
+}
+
+func TestRotatingSignInitializeFails(t *testing.T) {
+	oldSigner := mocks.NewSigner(t)
+	oldSigner.EXPECT().PublicAddress().Return(common.HexToAddress("0x1")).Maybe()
+	newSigner := mocks.NewSigner(t)
+	newSigner.EXPECT().Initialize(mock.Anything).Return(errTestAudit).Once()
+	sut := NewRotatingSign("test", log.WithFields("test", "test"), oldSigner, time.Minute)
+	require.ErrorIs(t, sut.Rotate(context.TODO(), newSigner), errTestAudit)
+	require.Equal(t, common.HexToAddress("0x1"), sut.PublicAddress())
+}
+
+func TestRotatingSignSubscriberNotReading(t *testing.T) {
+	oldSigner := mocks.NewSigner(t)
+	oldSigner.EXPECT().PublicAddress().Return(common.HexToAddress("0x1"))
+	oldSigner.EXPECT().Close().Return(nil).Once()
+	newSigner := mocks.NewSigner(t)
+	newSigner.EXPECT().PublicAddress().Return(common.HexToAddress("0x2"))
+	newSigner.EXPECT().Initialize(mock.Anything).Return(nil).Once()
+	sut := NewRotatingSign("test", log.WithFields("test", "test"), oldSigner, time.Minute)
+	// Nobody reads the unbuffered channel, Rotate doesn't wait for it
+	sub := sut.SubscribeAddressChange(make(chan AddressChange))
+	require.NoError(t, sut.Rotate(context.TODO(), newSigner))
+	sub.Unsubscribe()
+	require.Empty(t, sut.subs)
+}
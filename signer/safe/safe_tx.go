@@ -0,0 +1,82 @@
+package safe
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Operation is the kind of call that the Safe does
+type Operation uint8
+
+const (
+	OperationCall         Operation = 0
+	OperationDelegateCall Operation = 1
+
+	wordLength = 32
+)
+
+var (
+	// domainSeparatorTypeHash is keccak256("EIP712Domain(uint256 chainId,address verifyingContract)"),
+	// used by Safe >= 1.3.0
+	domainSeparatorTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	// safeTxTypeHash is the EIP-712 type hash of SafeTx
+	safeTxTypeHash = crypto.Keccak256Hash([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation," +
+		"uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+)
+
+// SafeTx is a Safe transaction (the parameters of execTransaction plus the nonce of the Safe)
+type SafeTx struct {
+	To             common.Address
+	Value          *big.Int
+	Data           []byte
+	Operation      Operation
+	SafeTxGas      *big.Int
+	BaseGas        *big.Int
+	GasPrice       *big.Int
+	GasToken       common.Address
+	RefundReceiver common.Address
+	Nonce          *big.Int
+}
+
+// DomainSeparator returns the EIP-712 domain separator of the Safe at address safe on chainID (Safe >= 1.3.0)
+func DomainSeparator(safe common.Address, chainID *big.Int) common.Hash {
+	return crypto.Keccak256Hash(domainSeparatorTypeHash.Bytes(), uintWord(chainID), addressWord(safe))
+}
+
+// StructHash returns the EIP-712 hashStruct of tx
+func (tx *SafeTx) StructHash() common.Hash {
+	return crypto.Keccak256Hash(
+		safeTxTypeHash.Bytes(),
+		addressWord(tx.To),
+		uintWord(tx.Value),
+		crypto.Keccak256(tx.Data),
+		uintWord(new(big.Int).SetUint64(uint64(tx.Operation))),
+		uintWord(tx.SafeTxGas),
+		uintWord(tx.BaseGas),
+		uintWord(tx.GasPrice),
+		addressWord(tx.GasToken),
+		addressWord(tx.RefundReceiver),
+		uintWord(tx.Nonce),
+	)
+}
+
+// Hash returns the safeTxHash that the owners sign (getTransactionHash of the Safe):
+// keccak256(0x19 || 0x01 || domainSeparator || hashStruct(tx))
+func (tx *SafeTx) Hash(safe common.Address, chainID *big.Int) common.Hash {
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, DomainSeparator(safe, chainID).Bytes(), tx.StructHash().Bytes())
+}
+
+func addressWord(addr common.Address) []byte {
+	return common.LeftPadBytes(addr.Bytes(), wordLength)
+}
+
+// uintWord returns the ABI encoding of an uint256, nil is 0
+func uintWord(n *big.Int) []byte {
+	if n == nil {
+		return make([]byte, wordLength)
+	}
+	return math.U256Bytes(new(big.Int).Set(n))
+}
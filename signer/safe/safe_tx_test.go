@@ -0,0 +1,72 @@
+package safe
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+// typedData is the SafeTx as EIP-712 typed data, as the Safe UI signs it
+func typedData(safe common.Address, chainID *big.Int, tx *SafeTx) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {{Name: "chainId", Type: "uint256"}, {Name: "verifyingContract", Type: "address"}},
+			"SafeTx": {
+				{Name: "to", Type: "address"}, {Name: "value", Type: "uint256"}, {Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"}, {Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"}, {Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"}, {Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: safe.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          tx.Value.String(),
+			"data":           hexutil.Encode(tx.Data),
+			"operation":      big.NewInt(int64(tx.Operation)).String(),
+			"safeTxGas":      tx.SafeTxGas.String(),
+			"baseGas":        tx.BaseGas.String(),
+			"gasPrice":       tx.GasPrice.String(),
+			"gasToken":       tx.GasToken.Hex(),
+			"refundReceiver": tx.RefundReceiver.Hex(),
+			"nonce":          tx.Nonce.String(),
+		},
+	}
+}
+
+func TestSafeTxHash(t *testing.T) {
+	safe := common.HexToAddress("0x5afe")
+	chainID := big.NewInt(1)
+	tx := &SafeTx{
+		To:             common.HexToAddress("0x1234"),
+		Value:          big.NewInt(1e18),
+		Data:           []byte{0xa9, 0x05, 0x9c, 0xbb},
+		Operation:      OperationDelegateCall,
+		SafeTxGas:      big.NewInt(100000),
+		BaseGas:        big.NewInt(21000),
+		GasPrice:       big.NewInt(1e9),
+		GasToken:       common.HexToAddress("0x70ce"),
+		RefundReceiver: common.HexToAddress("0x4ef0"),
+		Nonce:          big.NewInt(42),
+	}
+	expected, _, err := apitypes.TypedDataAndHash(typedData(safe, chainID, tx))
+	require.NoError(t, err)
+	require.Equal(t, common.BytesToHash(expected), tx.Hash(safe, chainID))
+	require.NotEqual(t, tx.Hash(safe, chainID), tx.Hash(safe, big.NewInt(10)))
+
+	// The nil numbers are 0
+	empty := &SafeTx{}
+	zero := &SafeTx{Value: big.NewInt(0), SafeTxGas: big.NewInt(0), BaseGas: big.NewInt(0),
+		GasPrice: big.NewInt(0), Nonce: big.NewInt(0)}
+	require.Equal(t, zero.Hash(safe, chainID), empty.Hash(safe, chainID))
+}
@@ -0,0 +1,135 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// vOffset is added to V (0/1) for an EIP-712 signature (ecrecover expects 27/28)
+	vOffset = 27
+	// ethSignVOffset is added to V (0/1) for an eth_sign signature: the Safe uses V > 30 to know
+	// that the safeTxHash has been signed with the EIP-191 personal message prefix
+	ethSignVOffset = vOffset + 4
+)
+
+var (
+	// ErrDuplicatedOwner is returned by PackSignatures if an owner has signed twice
+	ErrDuplicatedOwner = errors.New("safe: duplicated owner signature")
+	// ErrOwnerMismatch is returned if a signature doesn't recover the owner
+	ErrOwnerMismatch = errors.New("safe: signature doesn't match the owner")
+	// ErrUnsupportedSignatureType is returned for the V of contract signatures (0) and approved hashes (1)
+	ErrUnsupportedSignatureType = errors.New("safe: unsupported signature type")
+)
+
+// HashSigner is an owner that signs raw hashes (local, hd, GCP, AWS... signers)
+type HashSigner interface {
+	PublicAddress() common.Address
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// TextSigner is an owner that signs with the EIP-191 personal message prefix (e.g. clef and
+// remote signers)
+type TextSigner interface {
+	PublicAddress() common.Address
+	SignText(ctx context.Context, text []byte) ([]byte, error)
+}
+
+// OwnerSignature is the signature of a safeTxHash by an owner of the Safe
+type OwnerSignature struct {
+	Owner common.Address
+	// Signature is [R || S || V], V is 27/28 (EIP-712) or 31/32 (eth_sign)
+	Signature []byte
+}
+
+// SignEIP712 signs safeTxHash as EIP-712 typed data (V 27/28)
+func SignEIP712(ctx context.Context, signer HashSigner, safeTxHash common.Hash) (OwnerSignature, error) {
+	signature, err := signer.SignHash(ctx, safeTxHash)
+	if err != nil {
+		return OwnerSignature{}, fmt.Errorf("safe: can't sign safeTxHash. Err: %w", err)
+	}
+	return newOwnerSignature(signer.PublicAddress(), safeTxHash, signature, vOffset)
+}
+
+// SignEthSign signs safeTxHash with the EIP-191 personal message prefix, as eth_sign does (V 31/32)
+func SignEthSign(ctx context.Context, signer TextSigner, safeTxHash common.Hash) (OwnerSignature, error) {
+	signature, err := signer.SignText(ctx, safeTxHash.Bytes())
+	if err != nil {
+		return OwnerSignature{}, fmt.Errorf("safe: can't sign safeTxHash. Err: %w", err)
+	}
+	return newOwnerSignature(signer.PublicAddress(), safeTxHash, signature, ethSignVOffset)
+}
+
+// newOwnerSignature sets V of signature (0/1 or 27/28) to offset + 0/1 and checks that it recovers owner
+func newOwnerSignature(owner common.Address, safeTxHash common.Hash, signature []byte,
+	offset byte) (OwnerSignature, error) {
+	if len(signature) != crypto.SignatureLength {
+		return OwnerSignature{}, fmt.Errorf("safe: invalid signature length %d", len(signature))
+	}
+	res := slices.Clone(signature)
+	v := res[crypto.RecoveryIDOffset]
+	if v >= vOffset {
+		v -= vOffset
+	}
+	res[crypto.RecoveryIDOffset] = v + offset
+	recovered, err := RecoverOwner(safeTxHash, res)
+	if err != nil {
+		return OwnerSignature{}, err
+	}
+	if recovered != owner {
+		return OwnerSignature{}, fmt.Errorf("%w: recovered %s, owner %s", ErrOwnerMismatch, recovered.Hex(), owner.Hex())
+	}
+	return OwnerSignature{Owner: owner, Signature: res}, nil
+}
+
+// RecoverOwner returns the owner that has signed safeTxHash, as the Safe does on checkSignatures
+// for an EIP-712 (V 27/28) or eth_sign (V 31/32) signature
+func RecoverOwner(safeTxHash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("safe: invalid signature length %d", len(signature))
+	}
+	digest := safeTxHash.Bytes()
+	sig := slices.Clone(signature)
+	switch v := sig[crypto.RecoveryIDOffset]; {
+	case v == vOffset || v == vOffset+1:
+		sig[crypto.RecoveryIDOffset] = v - vOffset
+	case v == ethSignVOffset || v == ethSignVOffset+1:
+		sig[crypto.RecoveryIDOffset] = v - ethSignVOffset
+		digest = accounts.TextHash(digest)
+	default:
+		return common.Address{}, fmt.Errorf("%w: V=%d", ErrUnsupportedSignatureType, v)
+	}
+	pub, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("safe: can't recover owner. Err: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// PackSignatures returns the signatures argument of execTransaction: the signatures sorted by
+// owner (ascending, as the Safe requires) and concatenated
+func PackSignatures(signatures []OwnerSignature) ([]byte, error) {
+	sorted := slices.Clone(signatures)
+	slices.SortFunc(sorted, func(a, b OwnerSignature) int {
+		return bytes.Compare(a.Owner.Bytes(), b.Owner.Bytes())
+	})
+	res := make([]byte, 0, len(sorted)*crypto.SignatureLength)
+	for i, signature := range sorted {
+		if i > 0 && sorted[i-1].Owner == signature.Owner {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicatedOwner, signature.Owner.Hex())
+		}
+		if len(signature.Signature) != crypto.SignatureLength {
+			return nil, fmt.Errorf("safe: invalid signature length %d of owner %s",
+				len(signature.Signature), signature.Owner.Hex())
+		}
+		res = append(res, signature.Signature...)
+	}
+	return res, nil
+}
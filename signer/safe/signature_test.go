@@ -0,0 +1,99 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// keyOwner signs raw hashes and texts with a private key
+type keyOwner struct {
+	key *ecdsa.PrivateKey
+}
+
+func newKeyOwner(t *testing.T) keyOwner {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return keyOwner{key: key}
+}
+
+func (k keyOwner) PublicAddress() common.Address {
+	return crypto.PubkeyToAddress(k.key.PublicKey)
+}
+
+func (k keyOwner) SignHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash.Bytes(), k.key)
+}
+
+func (k keyOwner) SignText(_ context.Context, text []byte) ([]byte, error) {
+	return crypto.Sign(accounts.TextHash(text), k.key)
+}
+
+func TestSignAndPack(t *testing.T) {
+	ctx := context.Background()
+	safeTxHash := crypto.Keccak256Hash([]byte("safeTx"))
+	owners := []keyOwner{newKeyOwner(t), newKeyOwner(t), newKeyOwner(t)}
+
+	signatures := make([]OwnerSignature, 0, len(owners))
+	for i, owner := range owners {
+		var signature OwnerSignature
+		var err error
+		if i%2 == 0 {
+			signature, err = SignEIP712(ctx, owner, safeTxHash)
+			require.NoError(t, err)
+			require.Contains(t, []byte{27, 28}, signature.Signature[crypto.RecoveryIDOffset])
+		} else {
+			signature, err = SignEthSign(ctx, owner, safeTxHash)
+			require.NoError(t, err)
+			require.Contains(t, []byte{31, 32}, signature.Signature[crypto.RecoveryIDOffset])
+		}
+		recovered, err := RecoverOwner(safeTxHash, signature.Signature)
+		require.NoError(t, err)
+		require.Equal(t, owner.PublicAddress(), recovered)
+		signatures = append(signatures, signature)
+	}
+
+	packed, err := PackSignatures(signatures)
+	require.NoError(t, err)
+	require.Len(t, packed, len(owners)*crypto.SignatureLength)
+	// The signatures are sorted by owner
+	var prev common.Address
+	for i := 0; i < len(owners); i++ {
+		owner, err := RecoverOwner(safeTxHash, packed[i*crypto.SignatureLength:(i+1)*crypto.SignatureLength])
+		require.NoError(t, err)
+		require.Negative(t, bytes.Compare(prev.Bytes(), owner.Bytes()))
+		prev = owner
+	}
+
+	_, err = PackSignatures(append(signatures, signatures[0]))
+	require.ErrorIs(t, err, ErrDuplicatedOwner)
+}
+
+func TestSignOwnerMismatch(t *testing.T) {
+	owner := newKeyOwner(t)
+	other := newKeyOwner(t)
+	// other signs but it claims to be owner
+	_, err := newOwnerSignature(owner.PublicAddress(), common.Hash{1}, mustSign(t, other, common.Hash{1}), vOffset)
+	require.ErrorIs(t, err, ErrOwnerMismatch)
+}
+
+func TestRecoverOwnerUnsupported(t *testing.T) {
+	signature := make([]byte, crypto.SignatureLength)
+	signature[crypto.RecoveryIDOffset] = 1 // approved hash
+	_, err := RecoverOwner(common.Hash{}, signature)
+	require.ErrorIs(t, err, ErrUnsupportedSignatureType)
+}
+
+func mustSign(t *testing.T, owner keyOwner, hash common.Hash) []byte {
+	t.Helper()
+	signature, err := owner.SignHash(context.Background(), hash)
+	require.NoError(t, err)
+	return signature
+}
@@ -0,0 +1,257 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/agglayer/go_signer/signer/opsigneradapter"
+	"github.com/agglayer/go_signer/signer/remotesignerclient"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// The schemas use the canonical (documented) name of each field, the config
+// keys are case-insensitive so they match the Field constants too
+
+// retryFields are the fields of RetrySign, accepted by the methods that call a remote service
+var retryFields = []signertypes.FieldSchema{
+	{Name: FieldRetryMaxAttempts, Type: signertypes.FieldTypeInt, Description: "max attempts of each call"},
+	{Name: FieldRetryInitialBackoff, Type: signertypes.FieldTypeDuration, Description: "backoff after the first failure"},
+	{Name: FieldRetryMaxBackoff, Type: signertypes.FieldTypeDuration, Description: "max backoff between attempts"},
+	{Name: FieldRateLimit, Type: signertypes.FieldTypeFloat, Description: "max calls per second for the key"},
+	{Name: FieldRateBurst, Type: signertypes.FieldTypeInt, Description: "burst of the rate limit"},
+}
+
+// RegisterSchema sets the schema of a method, used by ValidateConfig and JSONSchema
+// (see types.RegisterSchema)
+func RegisterSchema(schema signertypes.MethodSchema) {
+	signertypes.RegisterSchema(schema)
+}
+
+// Schema returns the schema of method
+func Schema(method signertypes.SignMethod) (signertypes.MethodSchema, bool) {
+	return signertypes.Schema(method)
+}
+
+// Schemas returns the schemas of all the methods sorted by method
+func Schemas() []signertypes.MethodSchema {
+	return signertypes.Schemas()
+}
+
+// ValidateConfig checks cfg against the schema of its method and returns all the problems found
+// (errors.Join): unknown keys, wrong types, missing required fields and invalid values.
+// It's cfg.Validate(), importing this package registers the schemas of all the methods
+func ValidateConfig(cfg signertypes.SignerConfig) error {
+	return cfg.Validate()
+}
+
+// JSONSchema returns the JSON Schema (draft-07) of SignerConfig with all the registered methods
+func JSONSchema() ([]byte, error) {
+	return signertypes.JSONSchema(Schemas())
+}
+
+func init() {
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodNone,
+		Description: "no signer, just for development",
+	})
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodLocal,
+		Description: "keystore file or directory",
+		Fields: []signertypes.FieldSchema{
+			{Name: "Path", Type: signertypes.FieldTypeString, Required: true,
+				Description: "path to the keystore file or directory"},
+			{Name: "Password", Type: signertypes.FieldTypeString, Description: "password of the keystore"},
+			{Name: "Address", Type: signertypes.FieldTypeAddress,
+				Description: "account to use if Path is a keystore directory"},
+			{Name: "Passwords", Type: signertypes.FieldTypeStringMap,
+				Description: "per-account passwords (address -> password) if Path is a keystore directory"},
+		},
+	})
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodHD,
+		Description: "key derived from a BIP-39 mnemonic",
+		Fields: []signertypes.FieldSchema{
+			{Name: FieldMnemonic, Type: signertypes.FieldTypeString, Description: "mnemonic inline"},
+			{Name: FieldMnemonicEnv, Type: signertypes.FieldTypeString,
+				Description: "environment variable with the mnemonic"},
+			{Name: FieldMnemonicFile, Type: signertypes.FieldTypeString, Description: "encrypted mnemonic file"},
+			{Name: FieldMnemonicPassword, Type: signertypes.FieldTypeString, Description: "password of MnemonicFile"},
+			{Name: FieldPassphrase, Type: signertypes.FieldTypeString, Description: "BIP-39 passphrase"},
+			{Name: FieldDerivationPath, Type: signertypes.FieldTypeString, Check: checkDerivationPath,
+				Description: "BIP-32 derivation path, default m/44'/60'/0'/0/0"},
+		},
+		Check: checkHDMnemonicSource,
+	})
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodShamir,
+		Description: "key recovered in memory from k of n Shamir share files",
+		Fields: []signertypes.FieldSchema{
+			{Name: "Shares", Type: signertypes.FieldTypeStringList, Required: true,
+				Description: "paths of the share files, at least the threshold"},
+			{Name: "SharePasswords", Type: signertypes.FieldTypeStringList,
+				Description: "passwords of the share files, one per share in the same order"},
+		},
+		Check: checkShamirPasswords,
+	})
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodRemoteSigner,
+		Description: "remote signer (web3signer)",
+		Fields: append([]signertypes.FieldSchema{
+			{Name: "URL", Type: signertypes.FieldTypeString, Required: true,
+				Description: "URL of the remote signer, unix:///path or an IPC path for a Unix socket"},
+			{Name: "Address", Type: signertypes.FieldTypeAddress,
+				Description: "account to use, optional if the remote signer has only one"},
+			{Name: "Timeout", Type: signertypes.FieldTypeDuration,
+				Description: "timeout of each request to the remote signer, no timeout if not set"},
+			{Name: "TLSCACert", Type: signertypes.FieldTypeString,
+				Description: "CA bundle (PEM file) to verify the remote signer, system CAs if not set"},
+			{Name: "TLSCert", Type: signertypes.FieldTypeString, Description: "client certificate (PEM file) for mTLS"},
+			{Name: "TLSKey", Type: signertypes.FieldTypeString, Description: "client key (PEM file) for mTLS"},
+			{Name: "TLSInsecureSkipVerify", Type: signertypes.FieldTypeBool,
+				Description: "don't verify the server certificate, only for testing"},
+			{Name: "BearerToken", Type: signertypes.FieldTypeString, Description: "sent as Authorization: Bearer"},
+			{Name: "BasicAuthUser", Type: signertypes.FieldTypeString, Description: "user of basic auth"},
+			{Name: "BasicAuthPassword", Type: signertypes.FieldTypeString, Description: "password of basic auth"},
+			{Name: "Headers", Type: signertypes.FieldTypeStringMap,
+				Description: "extra headers (name -> value) added to each request"},
+			{Name: "Proxy", Type: signertypes.FieldTypeString,
+				Description: "URL of the proxy, taken from the environment (HTTPS_PROXY...) if not set"},
+		}, retryFields...),
+		Check: checkRemoteSigner,
+	})
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodClef,
+		Description: "Clef (go-ethereum external signer)",
+		Fields: []signertypes.FieldSchema{
+			{Name: "URL", Type: signertypes.FieldTypeString, Required: true,
+				Description: "URL of Clef: http(s)://, unix:///path or an IPC path"},
+			{Name: "Address", Type: signertypes.FieldTypeAddress,
+				Description: "account to use, optional if Clef has only one"},
+			{Name: "ApprovalTimeout", Type: signertypes.FieldTypeDuration,
+				Description: "max time waiting for the approval of a request, default 5m"},
+		},
+	})
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodTSS,
+		Description: "threshold ECDSA, the key is split between several parties",
+		Fields: []signertypes.FieldSchema{
+			{Name: "ShareFile", Type: signertypes.FieldTypeString, Required: true,
+				Description: "key share file of this party"},
+			{Name: "Password", Type: signertypes.FieldTypeString, Description: "password of ShareFile"},
+			{Name: "Listen", Type: signertypes.FieldTypeString, Required: true,
+				Description: "address where this party listens (host:port)"},
+			{Name: "Peers", Type: signertypes.FieldTypeStringMap,
+				Description: "addresses of the other parties (index -> host:port)"},
+			{Name: "Timeout", Type: signertypes.FieldTypeDuration,
+				Description: "max duration of a signing session, default 30s"},
+			{Name: "PaillierBits", Type: signertypes.FieldTypeInt,
+				Description: "size of the Paillier modulus of this party, default and min 2048"},
+			{Name: "TLSCACert", Type: signertypes.FieldTypeString, Required: true,
+				Description: "CA that signs the certificates of all the parties (PEM file)"},
+			{Name: "TLSCert", Type: signertypes.FieldTypeString, Required: true,
+				Description: "certificate of this party (PEM file), DNS name party-<index>"},
+			{Name: "TLSKey", Type: signertypes.FieldTypeString, Required: true,
+				Description: "private key of TLSCert (PEM file)"},
+			{Name: "ApproveAll", Type: signertypes.FieldTypeBool,
+				Description: "join all the sessions of the other parties, rejected by default"},
+		},
+		Check: checkTSSPeers,
+	})
+	kmsFields := []signertypes.FieldSchema{
+		{Name: opsigneradapter.FieldKeyName, Type: signertypes.FieldTypeString, Required: true,
+			Description: "KMS key name"},
+		{Name: opsigneradapter.FieldEndpoint, Type: signertypes.FieldTypeString,
+			Description: "endpoint of the KMS service, default one if not set"},
+	}
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodGCPKMS,
+		Description: signertypes.MethodGCPKMS.String() + " KMS",
+		Fields:      append(append([]signertypes.FieldSchema{}, kmsFields...), retryFields...),
+	})
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodAWSKMS,
+		Description: signertypes.MethodAWSKMS.String() + " KMS",
+		Fields: append(append([]signertypes.FieldSchema{
+			{Name: opsigneradapter.FieldRegion, Type: signertypes.FieldTypeString,
+				Description: "AWS region, default one of the environment if not set"},
+		}, kmsFields...), retryFields...),
+	})
+	RegisterSchema(signertypes.MethodSchema{
+		Method:      signertypes.MethodMock,
+		Description: "mock signer for unittest and debug",
+		Fields: []signertypes.FieldSchema{
+			{Name: "PrivateKey", Type: signertypes.FieldTypeString, Check: checkMockPrivateKey,
+				Description: "hex private key, random if not set"},
+		},
+	})
+}
+
+func checkDerivationPath(value any) error {
+	if _, err := accounts.ParseDerivationPath(value.(string)); err != nil {
+		return fmt.Errorf("invalid derivation path. Err: %w: %w", signertypes.ErrBadConfigParams, err)
+	}
+	return nil
+}
+
+func checkHDMnemonicSource(cfg signertypes.SignerConfig) []error {
+	sources := 0
+	for _, field := range []string{FieldMnemonic, FieldMnemonicEnv, FieldMnemonicFile} {
+		if v, err := cfg.Get(field); err == nil && v != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return []error{fmt.Errorf("config %s: %w. Err: %w", cfg.Method, ErrHDMnemonicSource,
+			signertypes.ErrBadConfigParams)}
+	}
+	return nil
+}
+
+func checkShamirPasswords(cfg signertypes.SignerConfig) []error {
+	var shamirCfg ShamirConfig
+	if err := cfg.Decode(&shamirCfg); err != nil {
+		return nil // the type of each field is checked by the schema
+	}
+	if len(shamirCfg.SharePasswords) != len(shamirCfg.Shares) {
+		return []error{fmt.Errorf("config %s: %w. Err: %w", cfg.Method, ErrShamirPasswords,
+			signertypes.ErrBadConfigParams)}
+	}
+	return nil
+}
+
+func checkRemoteSigner(cfg signertypes.SignerConfig) []error {
+	var errs []error
+	isSet := func(field string) bool {
+		v, err := cfg.Get(field)
+		return err == nil && v != ""
+	}
+	if isSet(FieldBearerToken) && isSet(FieldBasicAuthUser) {
+		errs = append(errs, fmt.Errorf("config %s: %w. Err: %w", cfg.Method, ErrRemoteAuth,
+			signertypes.ErrBadConfigParams))
+	}
+	if isSet(FieldTLSCert) != isSet(FieldTLSKey) {
+		errs = append(errs, fmt.Errorf("config %s: %w. Err: %w", cfg.Method, remotesignerclient.ErrClientCertKey,
+			signertypes.ErrBadConfigParams))
+	}
+	return errs
+}
+
+func checkTSSPeers(cfg signertypes.SignerConfig) []error {
+	var fields tssConfigFields
+	if err := cfg.Decode(&fields); err != nil {
+		return nil // the type of each field is checked by the schema
+	}
+	if _, err := parseTSSPeers(fields.Peers); err != nil {
+		return []error{fmt.Errorf("config %s: field %s. Err: %w: %w", cfg.Method, FieldPeers,
+			signertypes.ErrBadConfigParams, err)}
+	}
+	return nil
+}
+
+func checkMockPrivateKey(value any) error {
+	var mockCfg MockSignConfigure
+	if err := mockCfg.LoadPrivateKey(value.(string)); err != nil {
+		return fmt.Errorf("invalid private key. Err: %w: %w", signertypes.ErrBadConfigParams, err)
+	}
+	return nil
+}
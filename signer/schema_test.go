@@ -0,0 +1,120 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/agglayer/go_signer/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        signertypes.SignerConfig
+		expectedErrs  []error
+		errorContains []string
+	}{
+		{
+			name:   "local ok",
+			config: NewLocalSignerConfig("/app/sequencer.keystore", "test"),
+		},
+		{
+			name:   "empty config is the default local",
+			config: signertypes.SignerConfig{},
+		},
+		{
+			name: "local with viper lowercase keys",
+			config: signertypes.SignerConfig{
+				Method: signertypes.MethodLocal,
+				Config: map[string]any{"path": "/app/keystore", "password": "test", "batchconcurrency": int64(2)},
+			},
+		},
+		{
+			name: "local reports all the problems",
+			config: signertypes.SignerConfig{
+				Method: signertypes.MethodLocal,
+				Config: map[string]any{"Pasword": "test", "Address": "0x12", "BatchConcurrency": "many"},
+			},
+			expectedErrs: []error{signertypes.ErrUnknownConfigParam, signertypes.ErrMissingConfigParam,
+				signertypes.ErrBadConfigParams},
+			errorContains: []string{"did you mean Password?", "Path is required", "Address", "BatchConcurrency"},
+		},
+		{
+			name: "remote ok",
+			config: signertypes.SignerConfig{
+				Method: signertypes.MethodRemoteSigner,
+				Config: map[string]any{FieldURL: "http://localhost:9000", FieldRetryMaxBackoff: "2s"},
+			},
+		},
+		{
+			name: "retry fields are not valid for local",
+			config: signertypes.SignerConfig{
+				Method: signertypes.MethodLocal,
+				Config: map[string]any{FieldPath: "/app/keystore", FieldRetryMaxAttempts: 3},
+			},
+			expectedErrs: []error{signertypes.ErrUnknownConfigParam},
+		},
+		{
+			name:         "hd without mnemonic",
+			config:       NewHDSignerConfig("", "m/44'/x"),
+			expectedErrs: []error{ErrHDMnemonicSource, signertypes.ErrBadConfigParams},
+		},
+		{
+			name:         "mock bad private key",
+			config:       NewMockSignerConfig("0xzz"),
+			expectedErrs: []error{signertypes.ErrBadConfigParams},
+		},
+		{
+			name:         "unknown method",
+			config:       signertypes.SignerConfig{Method: "remote_eth"},
+			expectedErrs: []error{signertypes.ErrUnknownMethod},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfig(tt.config)
+			require.Equal(t, err, tt.config.Validate())
+			if len(tt.expectedErrs) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			for _, expected := range tt.expectedErrs {
+				require.ErrorIs(t, err, expected)
+			}
+			for _, contains := range tt.errorContains {
+				require.ErrorContains(t, err, contains)
+			}
+		})
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema, err := JSONSchema()
+	require.NoError(t, err)
+	var decoded struct {
+		OneOf []struct {
+			Title    string         `json:"title"`
+			Required []string       `json:"required"`
+			Props    map[string]any `json:"properties"`
+		} `json:"oneOf"`
+	}
+	require.NoError(t, json.Unmarshal(schema, &decoded))
+	methods := map[string][]string{}
+	for _, method := range decoded.OneOf {
+		methods[method.Title] = method.Required
+	}
+	require.Contains(t, methods, signertypes.MethodLocal.String())
+	require.Equal(t, []string{"Method", "URL"}, methods[signertypes.MethodRemoteSigner.String()])
+	require.Equal(t, []string{"Method", "KeyName"}, methods[signertypes.MethodGCPKMS.String()])
+}
+
+func TestNewSignerValidatesConfig(t *testing.T) {
+	cfg := NewLocalSignerConfig("/app/sequencer.keystore", "test")
+	cfg.Config["Pasword"] = "typo"
+	_, err := NewSigner(context.TODO(), 1, cfg, "test", log.WithFields("test", "test"))
+	require.ErrorIs(t, err, signertypes.ErrUnknownConfigParam)
+	require.ErrorContains(t, err, "did you mean Password?")
+}
@@ -0,0 +1,157 @@
+package shamir
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const filePermissions = 0o600
+
+var (
+	// ErrNotEnoughShares is returned by CombineKey with less shares than the threshold
+	ErrNotEnoughShares = errors.New("shamir: not enough shares")
+	// ErrSharesMismatch is returned by CombineKey for shares of different keys or a wrong share
+	ErrSharesMismatch = errors.New("shamir: shares don't belong to the same key")
+)
+
+// KeystoreShare is a share of a private key, stored as an encrypted keystore-like file
+// (WriteKeystoreShareFile). Any Threshold of the Parties shares recover the key
+type KeystoreShare struct {
+	Share
+	// Address is the address of the whole key
+	Address   common.Address
+	Threshold int
+	Parties   int
+}
+
+// keystoreShareJSON is the format of a share file, the value of the share is encrypted as a keystore v3
+type keystoreShareJSON struct {
+	Address   common.Address      `json:"address"`
+	Index     int                 `json:"index"`
+	Threshold int                 `json:"threshold"`
+	Parties   int                 `json:"parties"`
+	Crypto    keystore.CryptoJSON `json:"crypto"`
+}
+
+// SplitKey splits key in parties shares, threshold of them are needed to recover it
+func SplitKey(key *ecdsa.PrivateKey, threshold, parties int) ([]KeystoreShare, error) {
+	shares, err := Split(key.D, threshold, parties)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]KeystoreShare, len(shares))
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	for i, share := range shares {
+		res[i] = KeystoreShare{Share: share, Address: address, Threshold: threshold, Parties: parties}
+	}
+	return res, nil
+}
+
+// CombineKey recovers the private key from at least threshold shares and checks that it's the one of Address
+func CombineKey(shares []KeystoreShare) (*ecdsa.PrivateKey, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("%w: no shares", ErrNotEnoughShares)
+	}
+	first := shares[0]
+	values := make([]Share, len(shares))
+	for i, share := range shares {
+		if share.Address != first.Address || share.Threshold != first.Threshold || share.Parties != first.Parties {
+			return nil, fmt.Errorf("%w: share %d of %s and share %d of %s", ErrSharesMismatch,
+				first.Index, first.Address.Hex(), share.Index, share.Address.Hex())
+		}
+		values[i] = share.Share
+	}
+	if len(shares) < first.Threshold {
+		return nil, fmt.Errorf("%w: %d of %d", ErrNotEnoughShares, len(shares), first.Threshold)
+	}
+	secret, err := Combine(values)
+	if err != nil {
+		return nil, err
+	}
+	// The key has its own copy, the secret and its bytes are wiped
+	secretBytes := secret.FillBytes(make([]byte, 32))
+	key, err := crypto.ToECDSA(secretBytes)
+	clear(secretBytes)
+	signercommon.ZeroInt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid key. Err: %w", ErrSharesMismatch, err)
+	}
+	if address := crypto.PubkeyToAddress(key.PublicKey); address != first.Address {
+		return nil, fmt.Errorf("%w: recovered %s, expected %s", ErrSharesMismatch, address.Hex(), first.Address.Hex())
+	}
+	return key, nil
+}
+
+// EncryptKeystoreShare encodes share as JSON with its value encrypted with password
+// using the keystore v3 scheme (scrypt + AES-128-CTR)
+func EncryptKeystoreShare(share KeystoreShare, password string) ([]byte, error) {
+	if share.Value == nil {
+		return nil, fmt.Errorf("%w: share %d has no value", ErrBadShares, share.Index)
+	}
+	value := share.Value.FillBytes(make([]byte, 32))
+	defer clear(value)
+	cryptoJSON, err := keystore.EncryptDataV3(value, []byte(password),
+		keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("shamir: can't encrypt share. Err: %w", err)
+	}
+	return json.MarshalIndent(keystoreShareJSON{
+		Address:   share.Address,
+		Index:     share.Index,
+		Threshold: share.Threshold,
+		Parties:   share.Parties,
+		Crypto:    cryptoJSON,
+	}, "", "  ")
+}
+
+// DecryptKeystoreShare decodes the output of EncryptKeystoreShare
+func DecryptKeystoreShare(data []byte, password string) (KeystoreShare, error) {
+	var encoded keystoreShareJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return KeystoreShare{}, fmt.Errorf("shamir: share has a bad format. Err: %w", err)
+	}
+	if encoded.Index < 1 || encoded.Index > encoded.Parties || encoded.Threshold < 1 ||
+		encoded.Threshold > encoded.Parties {
+		return KeystoreShare{}, fmt.Errorf("%w: index %d, threshold %d, parties %d", ErrBadShares,
+			encoded.Index, encoded.Threshold, encoded.Parties)
+	}
+	value, err := keystore.DecryptDataV3(encoded.Crypto, password)
+	if err != nil {
+		return KeystoreShare{}, fmt.Errorf("shamir: can't decrypt share %d. Err: %w", encoded.Index, err)
+	}
+	defer clear(value)
+	return KeystoreShare{
+		Share:     Share{Index: encoded.Index, Value: new(big.Int).SetBytes(value)},
+		Address:   encoded.Address,
+		Threshold: encoded.Threshold,
+		Parties:   encoded.Parties,
+	}, nil
+}
+
+// WriteKeystoreShareFile stores share encrypted with password on path
+func WriteKeystoreShareFile(path string, share KeystoreShare, password string) error {
+	data, err := EncryptKeystoreShare(share, password)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(path), data, filePermissions)
+}
+
+// ReadKeystoreShareFile reads a share file created by WriteKeystoreShareFile
+func ReadKeystoreShareFile(path, password string) (KeystoreShare, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return KeystoreShare{}, fmt.Errorf("shamir: can't read share file %s. Err: %w", path, err)
+	}
+	return DecryptKeystoreShare(data, password)
+}
@@ -0,0 +1,56 @@
+package shamir
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystoreShareFiles(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	shares, err := SplitKey(key, 2, 3)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	read := make([]KeystoreShare, 0, len(shares))
+	for _, share := range shares {
+		path := filepath.Join(dir, fmt.Sprintf("share-%d.json", share.Index))
+		password := fmt.Sprintf("password%d", share.Index)
+		require.NoError(t, WriteKeystoreShareFile(path, share, password))
+		readShare, err := ReadKeystoreShareFile(path, password)
+		require.NoError(t, err)
+		require.Equal(t, share, readShare)
+		read = append(read, readShare)
+	}
+	_, err = ReadKeystoreShareFile(filepath.Join(dir, "share-1.json"), "password2")
+	require.ErrorContains(t, err, "can't decrypt")
+
+	combined, err := CombineKey([]KeystoreShare{read[2], read[0]})
+	require.NoError(t, err)
+	require.Equal(t, key.D, combined.D)
+
+	_, err = CombineKey(read[:1])
+	require.ErrorIs(t, err, ErrNotEnoughShares)
+}
+
+func TestCombineKeyMismatch(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	shares, err := SplitKey(key, 2, 3)
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherShares, err := SplitKey(other, 2, 3)
+	require.NoError(t, err)
+
+	_, err = CombineKey([]KeystoreShare{shares[0], otherShares[1]})
+	require.ErrorIs(t, err, ErrSharesMismatch)
+	// A share with a wrong value recovers another key
+	otherShares[1].Address = shares[0].Address
+	_, err = CombineKey([]KeystoreShare{shares[0], otherShares[1]})
+	require.ErrorIs(t, err, ErrSharesMismatch)
+}
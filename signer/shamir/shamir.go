@@ -0,0 +1,137 @@
+// Package shamir implements Shamir's secret sharing over the order of secp256k1, so a
+// private key can be split in n shares and any threshold of them recover it
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxParties is the max number of shares, the indexes are 1..maxParties
+const maxParties = 255
+
+var (
+	// ErrBadThreshold is returned by Split if the threshold is not between 1 and the number of parties
+	ErrBadThreshold = errors.New("shamir: threshold must be between 1 and the number of parties")
+	// ErrBadSecret is returned by Split if the secret is not in [1, order)
+	ErrBadSecret = errors.New("shamir: secret out of range")
+	// ErrBadShares is returned by Combine and LagrangeCoefficient for empty or duplicated indexes
+	ErrBadShares = errors.New("shamir: bad shares")
+)
+
+// Order is the order of secp256k1, the field of the shares
+var Order = new(big.Int).Set(crypto.S256().Params().N)
+
+// Share is the point (Index, Value) of the polynomial, Index is never 0 (the secret)
+type Share struct {
+	Index int
+	Value *big.Int
+}
+
+// Split splits secret in parties shares (indexes 1..parties), any threshold of them recover it
+func Split(secret *big.Int, threshold, parties int) ([]Share, error) {
+	if threshold < 1 || threshold > parties || parties > maxParties {
+		return nil, fmt.Errorf("%w: threshold %d, parties %d", ErrBadThreshold, threshold, parties)
+	}
+	if secret == nil || secret.Sign() <= 0 || secret.Cmp(Order) >= 0 {
+		return nil, ErrBadSecret
+	}
+	// coefficients[0] is the secret, the rest are random
+	coefficients := make([]*big.Int, threshold)
+	coefficients[0] = new(big.Int).Set(secret)
+	for i := 1; i < threshold; i++ {
+		c, err := RandomScalar()
+		if err != nil {
+			return nil, err
+		}
+		coefficients[i] = c
+	}
+	shares := make([]Share, parties)
+	for i := range shares {
+		index := i + 1
+		shares[i] = Share{Index: index, Value: evaluate(coefficients, big.NewInt(int64(index)))}
+	}
+	return shares, nil
+}
+
+// Combine recovers the secret from shares. It doesn't detect wrong shares: with less than
+// threshold shares, or a wrong one, the result is a wrong secret
+func Combine(shares []Share) (*big.Int, error) {
+	indexes := make([]int, len(shares))
+	for i, share := range shares {
+		indexes[i] = share.Index
+	}
+	secret := new(big.Int)
+	for _, share := range shares {
+		if share.Value == nil {
+			return nil, fmt.Errorf("%w: share %d has no value", ErrBadShares, share.Index)
+		}
+		lambda, err := LagrangeCoefficient(share.Index, indexes)
+		if err != nil {
+			return nil, err
+		}
+		secret.Add(secret, lambda.Mul(lambda, share.Value))
+		signercommon.ZeroInt(lambda)
+	}
+	return secret.Mod(secret, Order), nil
+}
+
+// LagrangeCoefficient returns the coefficient of the share index to interpolate the
+// secret (x=0) with the shares of indexes
+func LagrangeCoefficient(index int, indexes []int) (*big.Int, error) {
+	if index < 1 || index > maxParties {
+		return nil, fmt.Errorf("%w: index %d", ErrBadShares, index)
+	}
+	num := big.NewInt(1)
+	den := big.NewInt(1)
+	seen := make(map[int]bool, len(indexes))
+	found := false
+	for _, j := range indexes {
+		if j < 1 || j > maxParties || seen[j] {
+			return nil, fmt.Errorf("%w: index %d", ErrBadShares, j)
+		}
+		seen[j] = true
+		if j == index {
+			found = true
+			continue
+		}
+		// lambda = prod(j / (j - index))
+		num.Mul(num, big.NewInt(int64(j)))
+		den.Mul(den, big.NewInt(int64(j-index)))
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: index %d is not in the shares", ErrBadShares, index)
+	}
+	den.Mod(den, Order)
+	num.Mul(num, den.ModInverse(den, Order))
+	return num.Mod(num, Order), nil
+}
+
+// RandomScalar returns a random number in [1, order)
+func RandomScalar() (*big.Int, error) {
+	for {
+		k, err := rand.Int(rand.Reader, Order)
+		if err != nil {
+			return nil, fmt.Errorf("shamir: can't generate random number. Err: %w", err)
+		}
+		if k.Sign() > 0 {
+			return k, nil
+		}
+	}
+}
+
+// evaluate returns the polynomial with coefficients at x (Horner)
+func evaluate(coefficients []*big.Int, x *big.Int) *big.Int {
+	res := new(big.Int)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		res.Mul(res, x)
+		res.Add(res, coefficients[i])
+		res.Mod(res, Order)
+	}
+	return res
+}
@@ -0,0 +1,64 @@
+package shamir
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCombine(t *testing.T) {
+	secret, err := RandomScalar()
+	require.NoError(t, err)
+	shares, err := Split(secret, 3, 5)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+
+	// Any 3 shares recover the secret
+	for _, subset := range [][]int{{0, 1, 2}, {0, 2, 4}, {4, 3, 1}, {0, 1, 2, 3, 4}} {
+		selected := make([]Share, 0, len(subset))
+		for _, i := range subset {
+			selected = append(selected, shares[i])
+		}
+		recovered, err := Combine(selected)
+		require.NoError(t, err)
+		require.Equal(t, secret, recovered, subset)
+	}
+	// 2 shares are not enough
+	recovered, err := Combine(shares[:2])
+	require.NoError(t, err)
+	require.NotEqual(t, secret, recovered)
+
+	_, err = Combine([]Share{shares[0], shares[0], shares[1]})
+	require.ErrorIs(t, err, ErrBadShares)
+}
+
+func TestSplitErrors(t *testing.T) {
+	_, err := Split(big.NewInt(1), 0, 3)
+	require.ErrorIs(t, err, ErrBadThreshold)
+	_, err = Split(big.NewInt(1), 4, 3)
+	require.ErrorIs(t, err, ErrBadThreshold)
+	_, err = Split(big.NewInt(0), 2, 3)
+	require.ErrorIs(t, err, ErrBadSecret)
+	_, err = Split(Order, 2, 3)
+	require.ErrorIs(t, err, ErrBadSecret)
+
+	// 1 of n: all the shares are the secret
+	shares, err := Split(big.NewInt(7), 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(7), shares[1].Value)
+}
+
+func TestLagrangeCoefficient(t *testing.T) {
+	_, err := LagrangeCoefficient(3, []int{1, 2})
+	require.ErrorIs(t, err, ErrBadShares)
+	_, err = LagrangeCoefficient(0, []int{0, 1})
+	require.ErrorIs(t, err, ErrBadShares)
+	// For indexes 1, 2: lambda1 = 2, lambda2 = -1
+	lambda, err := LagrangeCoefficient(1, []int{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), lambda)
+	lambda, err = LagrangeCoefficient(2, []int{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(Order, big.NewInt(1)), lambda)
+}
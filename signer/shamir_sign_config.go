@@ -0,0 +1,85 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/shamir"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// FieldShares are the paths of the share files (shamir.WriteKeystoreShareFile)
+	FieldShares = "shares"
+	// FieldSharePasswords are the passwords of the share files, one per share in the same order
+	FieldSharePasswords = "sharepasswords"
+)
+
+var (
+	ErrShamirPasswords = errors.New("SharePasswords must have one password per share")
+)
+
+// ShamirConfig is the specific config for MethodShamir
+type ShamirConfig struct {
+	Shares         []string `mapstructure:"shares"`
+	SharePasswords []string `mapstructure:"sharepasswords"`
+}
+
+func (c ShamirConfig) String() string {
+	return fmt.Sprintf("ShamirConfig{Shares: %v, SharePasswords: ***}", c.Shares)
+}
+
+// NewShamirSignerConfig creates a generic config (SignerConfig) for the share files and their passwords
+func NewShamirSignerConfig(shares, passwords []string) signertypes.SignerConfig {
+	return signertypes.SignerConfig{
+		Method: signertypes.MethodShamir,
+		Config: map[string]interface{}{
+			FieldShares:         shares,
+			FieldSharePasswords: passwords,
+		},
+	}
+}
+
+// NewShamirConfig creates a ShamirConfig from a SignerConfig
+func NewShamirConfig(cfg signertypes.SignerConfig) (ShamirConfig, error) {
+	var res ShamirConfig
+	if err := cfg.Decode(&res); err != nil {
+		return ShamirConfig{}, err
+	}
+	if len(res.Shares) == 0 {
+		return ShamirConfig{}, fmt.Errorf("config %s: field %s is required. Err: %w",
+			cfg.Method, FieldShares, signertypes.ErrMissingConfigParam)
+	}
+	if len(res.SharePasswords) != len(res.Shares) {
+		return ShamirConfig{}, fmt.Errorf("config %s: %w (%d shares, %d passwords). Err: %w", cfg.Method,
+			ErrShamirPasswords, len(res.Shares), len(res.SharePasswords), signertypes.ErrBadConfigParams)
+	}
+	return res, nil
+}
+
+// NewShamirSign decrypts the shares of cfg, recovers the key in memory and creates a LocalSign with it
+// The decrypted shares are wiped once the key is recovered
+func NewShamirSign(name string, logger signercommon.Logger, cfg ShamirConfig, chainID uint64) (*LocalSign, error) {
+	shares := make([]shamir.KeystoreShare, len(cfg.Shares))
+	defer func() {
+		for _, share := range shares {
+			signercommon.ZeroInt(share.Value)
+		}
+	}()
+	for i, path := range cfg.Shares {
+		share, err := shamir.ReadKeystoreShareFile(path, cfg.SharePasswords[i])
+		if err != nil {
+			return nil, fmt.Errorf("signer %s: %w", name, err)
+		}
+		shares[i] = share
+	}
+	privateKey, err := shamir.CombineKey(shares)
+	if err != nil {
+		return nil, fmt.Errorf("signer %s: can't recover key from %d shares. Err: %w", name, len(shares), err)
+	}
+	logger.Infof("signer %s: recovered key from %d of %d shares, address %s", name, len(shares),
+		shares[0].Parties, crypto.PubkeyToAddress(privateKey.PublicKey).Hex())
+	return NewLocalSignFromPrivateKey(name, logger, privateKey, chainID), nil
+}
@@ -0,0 +1,71 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/agglayer/go_signer/log"
+	"github.com/agglayer/go_signer/signer/shamir"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestShares splits a new key in 2 of 3 share files, the password of share i is "password<i>"
+func writeTestShares(t *testing.T) (common.Address, []string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	shares, err := shamir.SplitKey(key, 2, 3)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	paths := make([]string, len(shares))
+	for i, share := range shares {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("share-%d.json", share.Index))
+		require.NoError(t, shamir.WriteKeystoreShareFile(paths[i], share, fmt.Sprintf("password%d", share.Index)))
+	}
+	return crypto.PubkeyToAddress(key.PublicKey), paths
+}
+
+func TestNewShamirConfig(t *testing.T) {
+	cfg := NewShamirSignerConfig([]string{"a.json", "b.json"}, []string{"pass1", "pass2"})
+	res, err := NewShamirConfig(cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.json", "b.json"}, res.Shares)
+	require.NotContains(t, res.String(), "pass1")
+	require.NoError(t, ValidateConfig(cfg))
+
+	cfg = NewShamirSignerConfig([]string{"a.json", "b.json"}, []string{"pass1"})
+	_, err = NewShamirConfig(cfg)
+	require.ErrorIs(t, err, ErrShamirPasswords)
+	require.ErrorIs(t, ValidateConfig(cfg), ErrShamirPasswords)
+
+	_, err = NewShamirConfig(signertypes.SignerConfig{Method: signertypes.MethodShamir})
+	require.ErrorIs(t, err, signertypes.ErrMissingConfigParam)
+}
+
+func TestNewSignerShamir(t *testing.T) {
+	ctx := context.Background()
+	address, paths := writeTestShares(t)
+	logger := log.WithFields("test", "test")
+	// The shares 3 and 1 (any 2 of 3)
+	sut, err := NewSigner(ctx, 1, NewShamirSignerConfig([]string{paths[2], paths[0]},
+		[]string{"password3", "password1"}), "shamir", logger)
+	require.NoError(t, err)
+	require.NoError(t, sut.Initialize(ctx))
+	require.Equal(t, address, sut.PublicAddress())
+	signature, err := sut.SignHash(ctx, common.Hash{1})
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(common.Hash{1}.Bytes(), signature)
+	require.NoError(t, err)
+	require.Equal(t, address, crypto.PubkeyToAddress(*pub))
+
+	_, err = NewSigner(ctx, 1, NewShamirSignerConfig(paths[:1], []string{"password1"}), "shamir", logger)
+	require.ErrorIs(t, err, shamir.ErrNotEnoughShares)
+	_, err = NewSigner(ctx, 1, NewShamirSignerConfig(paths[:2], []string{"password1", "password1"}),
+		"shamir", logger)
+	require.ErrorContains(t, err, "can't decrypt share 2")
+}
@@ -0,0 +1,223 @@
+package signer
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// PoolStrategy is how SignerPool chooses the next signer
+type PoolStrategy string
+
+const (
+	// PoolRoundRobin uses the signers in order
+	PoolRoundRobin PoolStrategy = "round-robin"
+	// PoolLeastInFlight uses the signer with less leases in use
+	PoolLeastInFlight PoolStrategy = "least-in-flight"
+	// PoolBalanceWeighted chooses a random signer with probability proportional to its balance
+	PoolBalanceWeighted PoolStrategy = "balance-weighted"
+)
+
+var (
+	ErrPoolEmpty           = errors.New("signer pool: no signers")
+	ErrPoolUnknownStrategy = errors.New("signer pool: unknown strategy")
+	ErrPoolNoBalance       = errors.New("signer pool: no signer with balance")
+)
+
+// BalanceProvider returns the balance of an account (it's implemented by ethclient.Client)
+type BalanceProvider interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// TxBuilder builds the tx to sign for the address from (e.g. with its nonce)
+type TxBuilder func(from common.Address) (*ethtypes.Transaction, error)
+
+// SignerPool hands out signers with different addresses following a PoolStrategy, so
+// the txs can be sent in parallel from several accounts
+type SignerPool struct {
+	mu       sync.Mutex
+	signers  []types.Signer
+	strategy PoolStrategy
+	balances BalanceProvider
+	next     int
+	inFlight []int
+}
+
+// Lease is a signer taken from the pool. It must be released when the tx
+// is done (e.g. sent) so the pool knows it's available
+type Lease struct {
+	types.Signer
+	pool  *SignerPool
+	index int
+	once  sync.Once
+}
+
+// Address returns the address of the leased signer
+func (l *Lease) Address() common.Address {
+	return l.PublicAddress()
+}
+
+// Release returns the signer to the pool. It can be called several times
+func (l *Lease) Release() {
+	l.once.Do(func() {
+		l.pool.mu.Lock()
+		l.pool.inFlight[l.index]--
+		l.pool.mu.Unlock()
+	})
+}
+
+// ParsePoolStrategy converts a string to a PoolStrategy
+func ParsePoolStrategy(s string) (PoolStrategy, error) {
+	switch strategy := PoolStrategy(s); strategy {
+	case PoolRoundRobin, PoolLeastInFlight, PoolBalanceWeighted:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrPoolUnknownStrategy, s)
+}
+
+// NewSignerPool creates a pool of signers (they must be initialized)
+// balances is only required for PoolBalanceWeighted
+func NewSignerPool(signers []types.Signer, strategy PoolStrategy, balances BalanceProvider) (*SignerPool, error) {
+	if len(signers) == 0 {
+		return nil, ErrPoolEmpty
+	}
+	if _, err := ParsePoolStrategy(string(strategy)); err != nil {
+		return nil, err
+	}
+	if strategy == PoolBalanceWeighted && balances == nil {
+		return nil, fmt.Errorf("signer pool: strategy %s requires a BalanceProvider", strategy)
+	}
+	return &SignerPool{
+		signers:  append([]types.Signer(nil), signers...),
+		strategy: strategy,
+		balances: balances,
+		inFlight: make([]int, len(signers)),
+	}, nil
+}
+
+// Acquire takes the next signer following the strategy of the pool
+func (p *SignerPool) Acquire(ctx context.Context) (*Lease, error) {
+	var (
+		index int
+		err   error
+	)
+	switch p.strategy {
+	case PoolBalanceWeighted:
+		index, err = p.balanceWeighted(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.mu.Lock()
+	case PoolLeastInFlight:
+		p.mu.Lock()
+		index = p.leastInFlight()
+	default:
+		p.mu.Lock()
+		index = p.next
+		p.next = (p.next + 1) % len(p.signers)
+	}
+	p.inFlight[index]++
+	p.mu.Unlock()
+	return &Lease{Signer: p.signers[index], pool: p, index: index}, nil
+}
+
+// SignTx signs, with the next signer, the tx built by build for its address (e.g. with its
+// nonce). It returns the signed tx and the address used
+func (p *SignerPool) SignTx(ctx context.Context, build TxBuilder) (*ethtypes.Transaction, common.Address, error) {
+	return p.sign(ctx, build, func(lease *Lease, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+		return lease.SignTx(ctx, tx)
+	})
+}
+
+// SignTxForChain is SignTx for chainID (see types.TxSigner)
+func (p *SignerPool) SignTxForChain(ctx context.Context, chainID uint64,
+	build TxBuilder) (*ethtypes.Transaction, common.Address, error) {
+	return p.sign(ctx, build, func(lease *Lease, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+		return lease.SignTxForChain(ctx, chainID, tx)
+	})
+}
+
+func (p *SignerPool) sign(ctx context.Context, build TxBuilder,
+	sign func(*Lease, *ethtypes.Transaction) (*ethtypes.Transaction, error)) (*ethtypes.Transaction,
+	common.Address, error) {
+	lease, err := p.Acquire(ctx)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	defer lease.Release()
+	tx, err := build(lease.Address())
+	if err != nil {
+		return nil, lease.Address(), fmt.Errorf("signer pool: can't build the tx for %s. Err: %w",
+			lease.Address().Hex(), err)
+	}
+	signed, err := sign(lease, tx)
+	return signed, lease.Address(), err
+}
+
+// InFlight returns the number of leases in use of each address
+func (p *SignerPool) InFlight() map[common.Address]int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	res := make(map[common.Address]int, len(p.signers))
+	for i, signer := range p.signers {
+		res[signer.PublicAddress()] = p.inFlight[i]
+	}
+	return res
+}
+
+// Len returns the number of signers in the pool
+func (p *SignerPool) Len() int {
+	return len(p.signers)
+}
+
+// leastInFlight returns the signer with less leases, on ties the first one after the last used.
+// Must be called with mu locked
+func (p *SignerPool) leastInFlight() int {
+	best := -1
+	for i := range p.signers {
+		index := (p.next + i) % len(p.signers)
+		if best == -1 || p.inFlight[index] < p.inFlight[best] {
+			best = index
+		}
+	}
+	p.next = (best + 1) % len(p.signers)
+	return best
+}
+
+func (p *SignerPool) balanceWeighted(ctx context.Context) (int, error) {
+	balances := make([]*big.Int, len(p.signers))
+	total := new(big.Int)
+	for i, signer := range p.signers {
+		balance, err := p.balances.BalanceAt(ctx, signer.PublicAddress(), nil)
+		if err != nil {
+			return 0, fmt.Errorf("signer pool: can't get balance of %s. Err: %w", signer.PublicAddress().Hex(), err)
+		}
+		if balance == nil || balance.Sign() < 0 {
+			balance = new(big.Int)
+		}
+		balances[i] = balance
+		total.Add(total, balance)
+	}
+	if total.Sign() == 0 {
+		return 0, ErrPoolNoBalance
+	}
+	// Random number in [0, total)
+	target, err := rand.Int(rand.Reader, total)
+	if err != nil {
+		return 0, fmt.Errorf("signer pool: can't choose a signer. Err: %w", err)
+	}
+	for i, balance := range balances {
+		if target.Cmp(balance) < 0 {
+			return i, nil
+		}
+		target.Sub(target, balance)
+	}
+	return len(balances) - 1, nil
+}
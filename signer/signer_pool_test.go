@@ -0,0 +1,127 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/agglayer/go_signer/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type testBalances map[common.Address]*big.Int
+
+func (b testBalances) BalanceAt(_ context.Context, account common.Address, _ *big.Int) (*big.Int, error) {
+	return b[account], nil
+}
+
+func newTestSignerPoolSigners(t *testing.T, n int) []signertypes.Signer {
+	t.Helper()
+	ctx := context.TODO()
+	cfgs := make([]NamedSignerConfig, n)
+	for i := range cfgs {
+		cfg, _ := newTestMockSignerConfig(t)
+		cfgs[i] = NamedSignerConfig{Name: string(rune('a' + i)), Signer: cfg}
+	}
+	set, err := NewSignerSet(ctx, 1, cfgs, log.WithFields("test", "test"))
+	require.NoError(t, err)
+	require.NoError(t, set.Initialize(ctx))
+	return set.Signers()
+}
+
+func TestSignerPoolRoundRobin(t *testing.T) {
+	ctx := context.TODO()
+	signers := newTestSignerPoolSigners(t, 3)
+	sut, err := NewSignerPool(signers, PoolRoundRobin, nil)
+	require.NoError(t, err)
+	nonces := map[common.Address]uint64{}
+	build := func(from common.Address) (*types.Transaction, error) {
+		nonces[from]++
+		return types.NewTx(&types.LegacyTx{Nonce: nonces[from], GasPrice: big.NewInt(1)}), nil
+	}
+	for i := 0; i < 6; i++ {
+		signed, addr, err := sut.SignTx(ctx, build)
+		require.NoError(t, err)
+		require.Equal(t, signers[i%3].PublicAddress(), addr)
+		// The tx has been built for the address of the signer
+		require.Equal(t, uint64(i/3+1), signed.Nonce())
+		from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), signed)
+		require.NoError(t, err)
+		require.Equal(t, addr, from)
+	}
+}
+
+func TestSignerPoolSignTxForChainBuildError(t *testing.T) {
+	ctx := context.TODO()
+	signers := newTestSignerPoolSigners(t, 2)
+	sut, err := NewSignerPool(signers, PoolRoundRobin, nil)
+	require.NoError(t, err)
+	errBuild := errors.New("can't get nonce")
+	_, addr, err := sut.SignTxForChain(ctx, 1, func(common.Address) (*types.Transaction, error) {
+		return nil, errBuild
+	})
+	require.ErrorIs(t, err, errBuild)
+	require.Equal(t, signers[0].PublicAddress(), addr)
+	// The lease is released on error
+	require.Zero(t, sut.InFlight()[addr])
+
+	signed, addr, err := sut.SignTxForChain(ctx, 1, func(from common.Address) (*types.Transaction, error) {
+		return types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 7, To: &from}), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, signers[1].PublicAddress(), addr)
+	require.Equal(t, addr, *signed.To())
+}
+
+func TestSignerPoolLeastInFlight(t *testing.T) {
+	ctx := context.TODO()
+	signers := newTestSignerPoolSigners(t, 2)
+	sut, err := NewSignerPool(signers, PoolLeastInFlight, nil)
+	require.NoError(t, err)
+	lease1, err := sut.Acquire(ctx)
+	require.NoError(t, err)
+	lease2, err := sut.Acquire(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, lease1.Address(), lease2.Address())
+	lease1.Release()
+	lease1.Release()
+	lease3, err := sut.Acquire(ctx)
+	require.NoError(t, err)
+	require.Equal(t, lease1.Address(), lease3.Address())
+	require.Equal(t, 1, sut.InFlight()[lease2.Address()])
+}
+
+func TestSignerPoolBalanceWeighted(t *testing.T) {
+	ctx := context.TODO()
+	signers := newTestSignerPoolSigners(t, 2)
+	_, err := NewSignerPool(signers, PoolBalanceWeighted, nil)
+	require.Error(t, err)
+
+	balances := testBalances{signers[0].PublicAddress(): big.NewInt(0), signers[1].PublicAddress(): big.NewInt(10)}
+	sut, err := NewSignerPool(signers, PoolBalanceWeighted, balances)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		lease, err := sut.Acquire(ctx)
+		require.NoError(t, err)
+		require.Equal(t, signers[1].PublicAddress(), lease.Address())
+		lease.Release()
+	}
+
+	balances[signers[1].PublicAddress()] = big.NewInt(0)
+	_, err = sut.Acquire(ctx)
+	require.ErrorIs(t, err, ErrPoolNoBalance)
+}
+
+func TestParsePoolStrategy(t *testing.T) {
+	strategy, err := ParsePoolStrategy("least-in-flight")
+	require.NoError(t, err)
+	require.Equal(t, PoolLeastInFlight, strategy)
+	_, err = ParsePoolStrategy("random")
+	require.ErrorIs(t, err, ErrPoolUnknownStrategy)
+	_, err = NewSignerPool(nil, PoolRoundRobin, nil)
+	require.ErrorIs(t, err, ErrPoolEmpty)
+}
@@ -0,0 +1,239 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrSignerNotFound   = errors.New("signer not found")
+	ErrDuplicatedSigner = errors.New("duplicated signer")
+)
+
+// NamedSignerConfig is the config of a signer of a SignerSet
+type NamedSignerConfig struct {
+	// Name of the signer, it must be unique on the set
+	Name string `mapstructure:"Name"`
+	// Signer is the config of the signer
+	Signer types.SignerConfig `mapstructure:"Signer"`
+}
+
+// SignerSet is a group of signers that can be looked up by name or by address.
+// SignTx / SignHash dispatch the call to the signer of the given address
+type SignerSet struct {
+	mu        sync.RWMutex
+	logger    signercommon.Logger
+	names     []string
+	byName    map[string]types.Signer
+	byAddress map[common.Address]string
+}
+
+// NewSignerSet creates the signers of cfgs (using NewSigner with opts). They must
+// be initialized calling Initialize. If a signer can't be created the ones already created are closed
+func NewSignerSet(ctx context.Context, chainID uint64, cfgs []NamedSignerConfig,
+	logger signercommon.Logger, opts ...SignerOption) (*SignerSet, error) {
+	res := &SignerSet{
+		logger:    logger,
+		byName:    make(map[string]types.Signer, len(cfgs)),
+		byAddress: make(map[common.Address]string, len(cfgs)),
+	}
+	for _, cfg := range cfgs {
+		if _, ok := res.byName[cfg.Name]; ok {
+			return nil, res.closeOnError(fmt.Errorf("signer set: name %s. Err: %w", cfg.Name, ErrDuplicatedSigner))
+		}
+		s, err := NewSigner(ctx, chainID, cfg.Signer, cfg.Name, logger, opts...)
+		if err != nil {
+			return nil, res.closeOnError(fmt.Errorf("signer set: can't create signer %s. Err: %w", cfg.Name, err))
+		}
+		res.byName[cfg.Name] = s
+		res.names = append(res.names, cfg.Name)
+	}
+	return res, nil
+}
+
+// closeOnError closes the signers created so far, err is returned with the errors closing them
+func (s *SignerSet) closeOnError(err error) error {
+	if errClose := s.Close(); errClose != nil {
+		return errors.Join(err, errClose)
+	}
+	return err
+}
+
+// Add adds an already created signer to the set. If it's initialized it can be
+// found by address, otherwise after calling Initialize
+func (s *SignerSet) Add(name string, signer types.Signer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.byName[name]; ok {
+		return fmt.Errorf("signer set: name %s. Err: %w", name, ErrDuplicatedSigner)
+	}
+	if err := s.indexAddress(name, signer); err != nil {
+		return err
+	}
+	s.byName[name] = signer
+	s.names = append(s.names, name)
+	return nil
+}
+
+// Initialize initializes all the signers and indexes them by address. It returns
+// all the errors found, the signers that fail can't be found by address. The set is not
+// locked while the signers are initialized (it can be a network call), so lookups don't wait for it
+func (s *SignerSet) Initialize(ctx context.Context) error {
+	s.mu.RLock()
+	names := append([]string(nil), s.names...)
+	signers := make([]types.Signer, 0, len(names))
+	for _, name := range names {
+		signers = append(signers, s.byName[name])
+	}
+	s.mu.RUnlock()
+	var errs []error
+	for i, name := range names {
+		if err := signers[i].Initialize(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("signer set: can't initialize %s. Err: %w", name, err))
+			continue
+		}
+		s.mu.Lock()
+		err := s.indexAddress(name, signers[i])
+		s.mu.Unlock()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes all the signers (also the ones added with Add). It returns all the errors found
+func (s *SignerSet) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for _, name := range s.names {
+		if err := s.byName[name].Close(); err != nil {
+			errs = append(errs, fmt.Errorf("signer set: can't close %s. Err: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// indexAddress adds the address of signer to byAddress (if it's known). Must be called with mu locked
+func (s *SignerSet) indexAddress(name string, signer types.Signer) error {
+	addr := signer.PublicAddress()
+	if addr == (common.Address{}) {
+		return nil
+	}
+	if other, ok := s.byAddress[addr]; ok && other != name {
+		return fmt.Errorf("signer set: address %s is used by %s and %s. Err: %w",
+			addr.Hex(), other, name, ErrDuplicatedSigner)
+	}
+	s.byAddress[addr] = name
+	return nil
+}
+
+// ByName returns the signer called name
+func (s *SignerSet) ByName(name string) (types.Signer, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	signer, ok := s.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("signer set: name %s. Err: %w", name, ErrSignerNotFound)
+	}
+	return signer, nil
+}
+
+// ByAddress returns the signer of addr
+func (s *SignerSet) ByAddress(addr common.Address) (types.Signer, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	name, ok := s.byAddress[addr]
+	if !ok {
+		return nil, fmt.Errorf("signer set: address %s. Err: %w", addr.Hex(), ErrSignerNotFound)
+	}
+	return s.byName[name], nil
+}
+
+// Names returns the names of the signers in the order they have been added
+func (s *SignerSet) Names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.names...)
+}
+
+// Signers returns the signers in the order they have been added (e.g. to create a SignerPool)
+func (s *SignerSet) Signers() []types.Signer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res := make([]types.Signer, 0, len(s.names))
+	for _, name := range s.names {
+		res = append(res, s.byName[name])
+	}
+	return res
+}
+
+// Addresses returns the addresses of the initialized signers
+func (s *SignerSet) Addresses() []common.Address {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res := make([]common.Address, 0, len(s.byAddress))
+	for addr := range s.byAddress {
+		res = append(res, addr)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Cmp(res[j]) < 0 })
+	return res
+}
+
+// SignTx signs tx with the signer of from
+func (s *SignerSet) SignTx(ctx context.Context, from common.Address,
+	tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+	signer, err := s.ByAddress(from)
+	if err != nil {
+		return nil, err
+	}
+	return signer.SignTx(ctx, tx)
+}
+
+// SignTxForChain signs tx for chainID with the signer of address from
+func (s *SignerSet) SignTxForChain(ctx context.Context, from common.Address, chainID uint64,
+	tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+	signer, err := s.ByAddress(from)
+	if err != nil {
+		return nil, err
+	}
+	return signer.SignTxForChain(ctx, chainID, tx)
+}
+
+// SignAuthorization signs an EIP-7702 authorization with the signer of address from (the authority)
+func (s *SignerSet) SignAuthorization(ctx context.Context, from common.Address,
+	auth ethtypes.SetCodeAuthorization) (ethtypes.SetCodeAuthorization, error) {
+	signer, err := s.ByAddress(from)
+	if err != nil {
+		return ethtypes.SetCodeAuthorization{}, err
+	}
+	return signer.SignAuthorization(ctx, auth)
+}
+
+// SignHash signs hash with the signer of from
+func (s *SignerSet) SignHash(ctx context.Context, from common.Address, hash common.Hash) ([]byte, error) {
+	signer, err := s.ByAddress(from)
+	if err != nil {
+		return nil, err
+	}
+	return signer.SignHash(ctx, hash)
+}
+
+func (s *SignerSet) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res := fmt.Sprintf("SignerSet{%d signers", len(s.names))
+	for _, name := range s.names {
+		res += ", " + name + ": " + s.byName[name].String()
+	}
+	return res + "}"
+}
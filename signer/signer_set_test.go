@@ -0,0 +1,109 @@
+package signer
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/agglayer/go_signer/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/agglayer/go_signer/signer/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMockSignerConfig(t *testing.T) (signertypes.SignerConfig, common.Address) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return NewMockSignerConfig(hex.EncodeToString(crypto.FromECDSA(key))), crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestSignerSet(t *testing.T) {
+	ctx := context.TODO()
+	logger := log.WithFields("test", "test")
+	cfg1, addr1 := newTestMockSignerConfig(t)
+	cfg2, addr2 := newTestMockSignerConfig(t)
+	sut, err := NewSignerSet(ctx, 1, []NamedSignerConfig{
+		{Name: "wallet1", Signer: cfg1},
+		{Name: "wallet2", Signer: cfg2},
+	}, logger)
+	require.NoError(t, err)
+	require.NoError(t, sut.Initialize(ctx))
+	require.Equal(t, []string{"wallet1", "wallet2"}, sut.Names())
+	require.ElementsMatch(t, []common.Address{addr1, addr2}, sut.Addresses())
+
+	signer, err := sut.ByName("wallet2")
+	require.NoError(t, err)
+	require.Equal(t, addr2, signer.PublicAddress())
+	signer, err = sut.ByAddress(addr1)
+	require.NoError(t, err)
+	require.Equal(t, addr1, signer.PublicAddress())
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1)})
+	signed, err := sut.SignTx(ctx, addr2, tx)
+	require.NoError(t, err)
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), signed)
+	require.NoError(t, err)
+	require.Equal(t, addr2, from)
+
+	_, err = sut.SignTx(ctx, common.HexToAddress("0x1234"), tx)
+	require.ErrorIs(t, err, ErrSignerNotFound)
+	_, err = sut.ByName("unknown")
+	require.ErrorIs(t, err, ErrSignerNotFound)
+
+	require.NoError(t, sut.Close())
+	_, err = sut.SignTx(ctx, addr2, tx)
+	require.Error(t, err)
+}
+
+func TestSignerSetDuplicated(t *testing.T) {
+	ctx := context.TODO()
+	logger := log.WithFields("test", "test")
+	cfg, addr := newTestMockSignerConfig(t)
+	_, err := NewSignerSet(ctx, 1, []NamedSignerConfig{
+		{Name: "wallet", Signer: cfg},
+		{Name: "wallet", Signer: cfg},
+	}, logger)
+	require.ErrorIs(t, err, ErrDuplicatedSigner)
+
+	sut, err := NewSignerSet(ctx, 1, []NamedSignerConfig{{Name: "wallet1", Signer: cfg}}, logger)
+	require.NoError(t, err)
+	require.NoError(t, sut.Initialize(ctx))
+	other := mocks.NewSigner(t)
+	other.EXPECT().PublicAddress().Return(addr)
+	require.ErrorIs(t, sut.Add("wallet2", other), ErrDuplicatedSigner)
+}
+
+func TestSignerSetInitializeUnlocked(t *testing.T) {
+	ctx := context.TODO()
+	sut, err := NewSignerSet(ctx, 1, nil, log.WithFields("test", "test"))
+	require.NoError(t, err)
+	addr := common.HexToAddress("0x1234")
+	slow := mocks.NewSigner(t)
+	slow.EXPECT().PublicAddress().Return(common.Address{}).Once()
+	// The set can be used while a signer is initializing
+	slow.EXPECT().Initialize(ctx).RunAndReturn(func(context.Context) error {
+		_, err := sut.ByName("slow")
+		return err
+	})
+	slow.EXPECT().PublicAddress().Return(addr)
+	require.NoError(t, sut.Add("slow", slow))
+	require.NoError(t, sut.Initialize(ctx))
+	_, err = sut.ByAddress(addr)
+	require.NoError(t, err)
+}
+
+func TestSignerSetCloseOnError(t *testing.T) {
+	sut, err := NewSignerSet(context.TODO(), 1, nil, log.WithFields("test", "test"))
+	require.NoError(t, err)
+	created := mocks.NewSigner(t)
+	created.EXPECT().PublicAddress().Return(common.Address{})
+	created.EXPECT().Close().Return(nil).Once()
+	require.NoError(t, sut.Add("created", created))
+	err = sut.closeOnError(ErrDuplicatedSigner)
+	require.ErrorIs(t, err, ErrDuplicatedSigner)
+}
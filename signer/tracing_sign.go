@@ -0,0 +1,187 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/agglayer/go_signer/signer"
+
+	attrSignerMethod  = "signer.method"
+	attrSignerName    = "signer.name"
+	attrSignerKey     = "signer.key"
+	attrSignerAddress = "signer.address"
+	attrTxHash        = "tx.hash"
+	attrTxChainID     = "tx.chain_id"
+	attrTxNonce       = "tx.nonce"
+	attrAuthChainID   = "authorization.chain_id"
+	attrAuthAddress   = "authorization.address"
+	attrAuthNonce     = "authorization.nonce"
+	attrBatchSize     = "batch.size"
+	attrBatchFailed   = "batch.failed"
+	attrErrorClass    = "error.class"
+)
+
+// TracingSign is a decorator over a signer that creates an OpenTelemetry span for each call.
+// The spans never contain secrets, just the method, name, key identifier and tx data
+type TracingSign struct {
+	method signertypes.SignMethod
+	name   string
+	keyID  string
+	signer signertypes.Signer
+	tracer trace.Tracer
+}
+
+var _ signertypes.Signer = (*TracingSign)(nil)
+
+// NewTracingSign creates a TracingSign over signer
+// keyID identifies the key used by signer (e.g. KMS key name), it must not contain secrets
+func NewTracingSign(method signertypes.SignMethod, name, keyID string, signer signertypes.Signer,
+	tp trace.TracerProvider) *TracingSign {
+	return &TracingSign{
+		method: method,
+		name:   name,
+		keyID:  keyID,
+		signer: signer,
+		tracer: tp.Tracer(tracerName),
+	}
+}
+
+// Unwrap returns the decorated signer
+func (t *TracingSign) Unwrap() signertypes.Signer {
+	return t.signer
+}
+
+func (t *TracingSign) Initialize(ctx context.Context) error {
+	ctx, span := t.start(ctx, operationInitialize)
+	err := t.signer.Initialize(ctx)
+	t.end(span, err)
+	return err
+}
+
+func (t *TracingSign) PublicAddress() common.Address {
+	return t.signer.PublicAddress()
+}
+
+func (t *TracingSign) String() string {
+	return t.signer.String()
+}
+
+// Close closes the wrapped signer
+func (t *TracingSign) Close() error {
+	return t.signer.Close()
+}
+
+func (t *TracingSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	ctx, span := t.start(ctx, operationSignHash)
+	res, err := t.signer.SignHash(ctx, hash)
+	t.end(span, err)
+	return res, err
+}
+
+func (t *TracingSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	ctx, span := t.start(ctx, operationSignTx, txAttributes(tx)...)
+	res, err := t.signer.SignTx(ctx, tx)
+	if res != nil {
+		span.SetAttributes(attribute.String(attrTxHash, res.Hash().Hex()))
+	}
+	t.end(span, err)
+	return res, err
+}
+
+func (t *TracingSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	attrs := txAttributes(tx)
+	if chainID != 0 {
+		attrs = append(attrs, attribute.String(attrTxChainID, strconv.FormatUint(chainID, 10)))
+	}
+	ctx, span := t.start(ctx, operationSignTx, attrs...)
+	res, err := t.signer.SignTxForChain(ctx, chainID, tx)
+	if res != nil {
+		span.SetAttributes(attribute.String(attrTxHash, res.Hash().Hex()))
+	}
+	t.end(span, err)
+	return res, err
+}
+
+func (t *TracingSign) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	ctx, span := t.start(ctx, operationSignAuthorization,
+		attribute.String(attrAuthChainID, auth.ChainID.Dec()),
+		attribute.String(attrAuthAddress, auth.Address.Hex()),
+		attribute.Int64(attrAuthNonce, int64(auth.Nonce)))
+	res, err := t.signer.SignAuthorization(ctx, auth)
+	t.end(span, err)
+	return res, err
+}
+
+// SetBatchConfig sets the batch parameters of the decorated signer
+func (t *TracingSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	if configurable, ok := t.signer.(batchConfigurable); ok {
+		configurable.SetBatchConfig(cfg)
+	}
+}
+
+func (t *TracingSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	ctx, span := t.start(ctx, operationSignHashes, attribute.Int(attrBatchSize, len(hashes)))
+	res, err := t.signer.SignHashes(ctx, hashes)
+	t.endBatch(span, err)
+	return res, err
+}
+
+func (t *TracingSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	ctx, span := t.start(ctx, operationSignTxs, attribute.Int(attrBatchSize, len(txs)))
+	res, err := t.signer.SignTxs(ctx, txs)
+	t.endBatch(span, err)
+	return res, err
+}
+
+func (t *TracingSign) start(ctx context.Context, operation string,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String(attrSignerMethod, t.method.String()),
+		attribute.String(attrSignerName, t.name),
+		attribute.String(attrSignerKey, t.keyID),
+		attribute.String(attrSignerAddress, t.signer.PublicAddress().Hex()),
+	)
+	return t.tracer.Start(ctx, "signer "+operation, trace.WithAttributes(attrs...))
+}
+
+func (t *TracingSign) end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String(attrErrorClass, ClassifyError(err).String()))
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t *TracingSign) endBatch(span trace.Span, err error) {
+	var batchErr *signertypes.BatchError
+	if errors.As(err, &batchErr) {
+		span.SetAttributes(attribute.Int(attrBatchFailed, batchErr.Failed()))
+	}
+	t.end(span, err)
+}
+
+func txAttributes(tx *types.Transaction) []attribute.KeyValue {
+	if tx == nil {
+		return nil
+	}
+	res := []attribute.KeyValue{
+		attribute.Int64(attrTxNonce, int64(tx.Nonce())),
+	}
+	if chainID := tx.ChainId(); chainID != nil {
+		res = append(res, attribute.String(attrTxChainID, chainID.String()))
+	}
+	return res
+}
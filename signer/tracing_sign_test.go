@@ -0,0 +1,76 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/agglayer/go_signer/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/agglayer/go_signer/signer/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	res := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		res[kv.Key] = kv.Value
+	}
+	return res
+}
+
+func TestTracingSign(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	inner := mocks.NewSigner(t)
+	addr := common.HexToAddress("0x1234")
+	inner.EXPECT().PublicAddress().Return(addr)
+	sut := NewTracingSign(signertypes.MethodAWSKMS, "sequencer", "AWS/key-id", inner, tp)
+	ctx := context.TODO()
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1337), Nonce: 7})
+	inner.EXPECT().SignTx(mock.Anything, tx).Return(tx, nil).Once()
+	_, err := sut.SignTx(ctx, tx)
+	require.NoError(t, err)
+	inner.EXPECT().SignHash(mock.Anything, common.Hash{}).
+		Return(nil, status.Error(grpccodes.PermissionDenied, "no")).Once()
+	_, err = sut.SignHash(ctx, common.Hash{})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "signer SignTx", spans[0].Name())
+	attrs := spanAttributes(spans[0])
+	require.Equal(t, "AWS", attrs[attrSignerMethod].AsString())
+	require.Equal(t, "sequencer", attrs[attrSignerName].AsString())
+	require.Equal(t, "AWS/key-id", attrs[attrSignerKey].AsString())
+	require.Equal(t, addr.Hex(), attrs[attrSignerAddress].AsString())
+	require.Equal(t, "1337", attrs[attrTxChainID].AsString())
+	require.Equal(t, int64(7), attrs[attrTxNonce].AsInt64())
+	require.Equal(t, tx.Hash().Hex(), attrs[attrTxHash].AsString())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	require.Equal(t, "signer SignHash", spans[1].Name())
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	require.Equal(t, "permission", spanAttributes(spans[1])[attrErrorClass].AsString())
+}
+
+func TestNewSignerWithTracerProvider(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	sut, err := NewSigner(context.TODO(), 1, NewMockSignerConfig(testPrivateKeyHex), "test",
+		log.WithFields("test", "test"), WithTracerProvider(tp))
+	require.NoError(t, err)
+	require.IsType(t, &TracingSign{}, sut)
+	require.NoError(t, sut.Initialize(context.TODO()))
+	require.Len(t, recorder.Ended(), 1)
+}
@@ -0,0 +1,66 @@
+package tss
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/agglayer/go_signer/signer/shamir"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// point is a point of secp256k1 (never the point at infinity)
+type point struct {
+	X, Y *big.Int
+}
+
+func scalarBaseMult(k *big.Int) point {
+	x, y := crypto.S256().ScalarBaseMult(scalarBytes(k))
+	return point{X: x, Y: y}
+}
+
+func (p point) mul(k *big.Int) point {
+	x, y := crypto.S256().ScalarMult(p.X, p.Y, scalarBytes(k))
+	return point{X: x, Y: y}
+}
+
+func (p point) add(other point) point {
+	x, y := crypto.S256().Add(p.X, p.Y, other.X, other.Y)
+	return point{X: x, Y: y}
+}
+
+func (p point) equal(other point) bool {
+	return p.X.Cmp(other.X) == 0 && p.Y.Cmp(other.Y) == 0
+}
+
+func (p point) isInfinity() bool {
+	return p.X.Sign() == 0 && p.Y.Sign() == 0
+}
+
+func (p point) publicKey() *ecdsa.PublicKey {
+	return &ecdsa.PublicKey{Curve: crypto.S256(), X: p.X, Y: p.Y}
+}
+
+// bytes returns the compressed encoding (33 bytes)
+func (p point) bytes() []byte {
+	return crypto.CompressPubkey(p.publicKey())
+}
+
+// decodePoint decodes a compressed point, checking that it's on the curve
+func decodePoint(data []byte) (point, error) {
+	pub, err := crypto.DecompressPubkey(data)
+	if err != nil {
+		return point{}, fmt.Errorf("tss: invalid point. Err: %w", err)
+	}
+	return point{X: pub.X, Y: pub.Y}, nil
+}
+
+// scalarBytes returns k mod order as 32 bytes
+func scalarBytes(k *big.Int) []byte {
+	return new(big.Int).Mod(k, shamir.Order).FillBytes(make([]byte, 32))
+}
+
+// generator returns G, the base point of secp256k1
+func generator() point {
+	return scalarBaseMult(one)
+}
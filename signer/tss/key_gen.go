@@ -0,0 +1,400 @@
+package tss
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/shamir"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Rounds of the distributed key generation, it runs on a session of its own. Committing to the
+// hash of the commitments first stops a party from choosing its polynomial after seeing the
+// ones of the others (and biasing the key)
+const (
+	// roundKeyGenCommit: each party i sends the hash of its commitments A_ik = a_ik*G
+	roundKeyGenCommit = iota + 1
+	// roundKeyGenShare: each party i opens its commitments, proves that it knows a_i0 and sends
+	// f_i(j) to each party j
+	roundKeyGenShare
+	// roundKeyGenConfirm: each party sends the hash of the commitments of all the parties, so
+	// a party that sent different commitments to different parties is detected
+	roundKeyGenConfirm
+)
+
+const (
+	// maxKeyGenParties is the max number of parties of a key, as the shamir shares
+	maxKeyGenParties = 255
+	// keyGenSaltLength is the size of the random salt of the hash of the commitments
+	keyGenSaltLength = 32
+	// keyGenRetryInterval is the wait before sending again to a party that is not running yet
+	keyGenRetryInterval = time.Second
+)
+
+// ErrBadKeyGen is returned by KeyGen for a wrong index, threshold or number of parties
+var ErrBadKeyGen = errors.New("tss: bad key generation parameters")
+
+type keyGenCommitPayload struct {
+	Hash hexutil.Bytes `json:"hash"`
+}
+
+type keyGenSharePayload struct {
+	Commitments []hexutil.Bytes `json:"commitments"`
+	Salt        hexutil.Bytes   `json:"salt"`
+	Proof       *schnorrProof   `json:"proof"`
+	// Share is f_i(j), only for the party j that receives the message
+	Share *hexutil.Big `json:"share"`
+}
+
+// schnorrProof proves the knowledge of x with X = x*G: R = r*G and z = r + e*x
+type schnorrProof struct {
+	R hexutil.Bytes `json:"r"`
+	Z *hexutil.Big  `json:"z"`
+}
+
+// KeyGen runs the distributed key generation of party index with the parties 1..parties, all
+// of them must run it at the same time with the same session. It's Feldman VSS: each party i
+// samples a random polynomial f_i of degree threshold-1, sends f_i(j) to each party j with the
+// commitments of its coefficients, and the key is the sum of the constant terms, so no machine
+// ever has it. The transport must keep the shares private (see Transport), and nothing else can
+// receive from it while KeyGen runs. A party that sends an invalid share or proof aborts it
+func KeyGen(ctx context.Context, index, threshold, parties int, session string, transport Transport,
+	logger signercommon.Logger) (*KeyShare, error) {
+	if parties < 1 || parties > maxKeyGenParties || threshold < 1 || threshold > parties ||
+		index < 1 || index > parties {
+		return nil, fmt.Errorf("%w: index %d, threshold %d, parties %d", ErrBadKeyGen, index, threshold, parties)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	k := &keyGen{
+		index:     index,
+		threshold: threshold,
+		parties:   parties,
+		session:   newSession(session, parties),
+		transport: transport,
+		logger:    logger,
+	}
+	go k.receive(ctx)
+	return k.run(ctx)
+}
+
+// keyGen is the state of KeyGen for a party
+type keyGen struct {
+	index     int
+	threshold int
+	parties   int
+	session   *session
+	transport Transport
+	logger    signercommon.Logger
+}
+
+func (k *keyGen) run(ctx context.Context) (*KeyShare, error) {
+	var others []int
+	for j := 1; j <= k.parties; j++ {
+		if j != k.index {
+			others = append(others, j)
+		}
+	}
+	coefficients := make([]*big.Int, k.threshold)
+	defer func() {
+		for _, c := range coefficients {
+			signercommon.ZeroInt(c)
+		}
+	}()
+	commitments := make([]point, k.threshold)
+	for i := range coefficients {
+		c, err := shamir.RandomScalar()
+		if err != nil {
+			return nil, err
+		}
+		coefficients[i] = c
+		commitments[i] = scalarBaseMult(c)
+	}
+	salt := make([]byte, keyGenSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("%s can't generate salt. Err: %w", k.logPrefix(), err)
+	}
+
+	// roundKeyGenCommit
+	hash := commitmentsHash(k.session.id, k.index, commitments, salt)
+	if err := k.broadcast(ctx, roundKeyGenCommit, others, keyGenCommitPayload{Hash: hash}); err != nil {
+		return nil, err
+	}
+	hashes, err := k.collectHashes(ctx, roundKeyGenCommit, others)
+	if err != nil {
+		return nil, err
+	}
+
+	// roundKeyGenShare
+	proof, err := proveSchnorr(proofAux(k.session.id, k.index, 0), coefficients[0], commitments[0])
+	if err != nil {
+		return nil, err
+	}
+	encoded := make([]hexutil.Bytes, len(commitments))
+	for i, c := range commitments {
+		encoded[i] = c.bytes()
+	}
+	for _, j := range others {
+		payload := keyGenSharePayload{
+			Commitments: encoded,
+			Salt:        salt,
+			Proof:       proof,
+			Share:       (*hexutil.Big)(evaluatePolynomial(coefficients, j)),
+		}
+		if err := k.send(ctx, roundKeyGenShare, j, payload); err != nil {
+			return nil, err
+		}
+	}
+	raws, err := k.session.collect(ctx, roundKeyGenShare, others)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", k.logPrefix(), err)
+	}
+	all := map[int][]point{k.index: commitments}
+	share := evaluatePolynomial(coefficients, k.index)
+	for _, j := range others {
+		received, value, err := k.verifyShare(j, raws[j], hashes[j])
+		if err != nil {
+			signercommon.ZeroInt(share)
+			return nil, err
+		}
+		all[j] = received
+		share.Add(share, value)
+		signercommon.ZeroInt(value)
+	}
+	share.Mod(share, shamir.Order)
+
+	// roundKeyGenConfirm
+	view := viewHash(k.session.id, k.parties, all)
+	if err := k.broadcast(ctx, roundKeyGenConfirm, others, keyGenCommitPayload{Hash: view}); err != nil {
+		return nil, err
+	}
+	views, err := k.collectHashes(ctx, roundKeyGenConfirm, others)
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range others {
+		if !bytes.Equal(views[j], view) {
+			return nil, fmt.Errorf("%s %w: party %d received other commitments", k.logPrefix(), ErrBadMessage, j)
+		}
+	}
+	return k.keyShare(all, share)
+}
+
+// verifyShare checks the share of party j against its commitments (f_j(i)*G = sum(A_jk * i^k)),
+// the commitments against the hash of the first round and the proof of a_j0
+func (k *keyGen) verifyShare(j int, raw json.RawMessage, hash []byte) ([]point, *big.Int, error) {
+	var payload keyGenSharePayload
+	if err := json.Unmarshal(raw, &payload); err != nil || len(payload.Commitments) != k.threshold ||
+		payload.Proof == nil || payload.Share == nil {
+		return nil, nil, fmt.Errorf("%s party %d round %d: %w", k.logPrefix(), j, roundKeyGenShare, ErrBadMessage)
+	}
+	commitments := make([]point, len(payload.Commitments))
+	for i, data := range payload.Commitments {
+		c, err := decodePoint(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s party %d: %w", k.logPrefix(), j, err)
+		}
+		commitments[i] = c
+	}
+	if !bytes.Equal(commitmentsHash(k.session.id, j, commitments, payload.Salt), hash) {
+		return nil, nil, fmt.Errorf("%s %w: commitments of party %d don't match its hash", k.logPrefix(),
+			ErrBadMessage, j)
+	}
+	if err := payload.Proof.verify(proofAux(k.session.id, j, 0), commitments[0]); err != nil {
+		return nil, nil, fmt.Errorf("%s party %d: %w", k.logPrefix(), j, err)
+	}
+	value := payload.Share.ToInt()
+	if value.Sign() <= 0 || value.Cmp(shamir.Order) >= 0 ||
+		!scalarBaseMult(value).equal(evaluateCommitments(commitments, k.index)) {
+		return nil, nil, fmt.Errorf("%s %w: share of party %d doesn't match its commitments", k.logPrefix(),
+			ErrInvalidProof, j)
+	}
+	return commitments, value, nil
+}
+
+// keyShare builds the key share from the commitments of all the parties: the public key is
+// the sum of the constant terms and the public share of party j the sum of their f_i(j)*G
+func (k *keyGen) keyShare(all map[int][]point, share *big.Int) (*KeyShare, error) {
+	sums := make([]point, k.threshold)
+	for i := range sums {
+		sums[i] = all[1][i]
+		for j := 2; j <= k.parties; j++ {
+			sums[i] = sums[i].add(all[j][i])
+		}
+	}
+	if sums[0].isInfinity() {
+		return nil, fmt.Errorf("%s %w: public key is the point at infinity", k.logPrefix(), ErrBadMessage)
+	}
+	res := &KeyShare{
+		Index:        k.index,
+		Threshold:    k.threshold,
+		PublicKey:    sums[0].publicKey(),
+		PublicShares: make([]*ecdsa.PublicKey, k.parties),
+		Share:        share,
+	}
+	for j := 1; j <= k.parties; j++ {
+		public := evaluateCommitments(sums, j)
+		if public.isInfinity() {
+			return nil, fmt.Errorf("%s %w: public share %d is the point at infinity", k.logPrefix(), ErrBadMessage, j)
+		}
+		res.PublicShares[j-1] = public.publicKey()
+	}
+	if err := res.Validate(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (k *keyGen) collectHashes(ctx context.Context, round int, others []int) (map[int][]byte, error) {
+	raws, err := k.session.collect(ctx, round, others)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", k.logPrefix(), err)
+	}
+	res := make(map[int][]byte, len(raws))
+	for j, raw := range raws {
+		var payload keyGenCommitPayload
+		if err := json.Unmarshal(raw, &payload); err != nil || len(payload.Hash) == 0 {
+			return nil, fmt.Errorf("%s party %d round %d: %w", k.logPrefix(), j, round, ErrBadMessage)
+		}
+		res[j] = payload.Hash
+	}
+	return res, nil
+}
+
+// receive delivers the messages of the session to its inbox until ctx is done
+func (k *keyGen) receive(ctx context.Context) {
+	for {
+		msg, err := k.transport.Receive(ctx)
+		if err != nil {
+			return
+		}
+		if msg.Session != k.session.id || msg.To != k.index || msg.From == k.index ||
+			msg.From < 1 || msg.From > k.parties {
+			k.logger.Debugf("%s dropped message of session %s from %d to %d", k.logPrefix(), msg.Session,
+				msg.From, msg.To)
+			continue
+		}
+		select {
+		case k.session.inbox <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (k *keyGen) broadcast(ctx context.Context, round int, to []int, payload any) error {
+	for _, index := range to {
+		if err := k.send(ctx, round, index, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send delivers payload to party to, retrying until ctx is done: the parties don't start at the
+// same time, so some of them can be not listening yet
+func (k *keyGen) send(ctx context.Context, round, to int, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%s can't encode message. Err: %w", k.logPrefix(), err)
+	}
+	msg := Message{Session: k.session.id, Round: round, From: k.index, To: to, Payload: data}
+	for {
+		err := k.transport.Send(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrUnknownParty) || errors.Is(err, ErrTransportClosed) {
+			return fmt.Errorf("%s can't send round %d to party %d. Err: %w", k.logPrefix(), round, to, err)
+		}
+		k.logger.Debugf("%s can't send round %d to party %d, retrying. Err: %v", k.logPrefix(), round, to, err)
+		select {
+		case <-time.After(keyGenRetryInterval):
+		case <-ctx.Done():
+			return fmt.Errorf("%s can't send round %d to party %d. Err: %w", k.logPrefix(), round, to,
+				errors.Join(err, ctx.Err()))
+		}
+	}
+}
+
+func (k *keyGen) logPrefix() string {
+	return fmt.Sprintf("tss[%d] keygen:", k.index)
+}
+
+// commitmentsHash is the hash of the commitments of party index sent on roundKeyGenCommit
+func commitmentsHash(session string, index int, commitments []point, salt []byte) []byte {
+	values := []*big.Int{new(big.Int).SetBytes(salt)}
+	for _, c := range commitments {
+		values = append(values, pointInt(c))
+	}
+	return transcriptHash("tss/keygen-commit", proofAux(session, index, 0), values...)
+}
+
+// viewHash is the hash of the commitments of all the parties (1..parties)
+func viewHash(session string, parties int, all map[int][]point) []byte {
+	var values []*big.Int
+	for j := 1; j <= parties; j++ {
+		for _, c := range all[j] {
+			values = append(values, pointInt(c))
+		}
+	}
+	return transcriptHash("tss/keygen-view", []byte(session), values...)
+}
+
+// evaluatePolynomial returns sum(coefficients[k] * x^k) mod q
+func evaluatePolynomial(coefficients []*big.Int, x int) *big.Int {
+	res := new(big.Int)
+	bigX := big.NewInt(int64(x))
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		res.Mul(res, bigX)
+		res.Add(res, coefficients[i])
+		res.Mod(res, shamir.Order)
+	}
+	return res
+}
+
+// evaluateCommitments returns sum(commitments[k] * x^k), the commitment of f(x)
+func evaluateCommitments(commitments []point, x int) point {
+	bigX := big.NewInt(int64(x))
+	res := commitments[len(commitments)-1]
+	for i := len(commitments) - 2; i >= 0; i-- {
+		res = res.mul(bigX).add(commitments[i])
+	}
+	return res
+}
+
+// proveSchnorr proves the knowledge of x, the discrete log of public
+func proveSchnorr(aux []byte, x *big.Int, public point) (*schnorrProof, error) {
+	r, err := shamir.RandomScalar()
+	if err != nil {
+		return nil, err
+	}
+	defer signercommon.ZeroInt(r)
+	bigR := scalarBaseMult(r)
+	e := challenge("tss/schnorr", aux, pointInt(public), pointInt(bigR))
+	z := e.Mul(e, x)
+	z.Add(z, r)
+	z.Mod(z, shamir.Order)
+	return &schnorrProof{R: bigR.bytes(), Z: (*hexutil.Big)(z)}, nil
+}
+
+// verify checks z*G = R + e*public
+func (p *schnorrProof) verify(aux []byte, public point) error {
+	bigR, err := decodePoint(p.R)
+	if err != nil || p.Z == nil || p.Z.ToInt().Sign() < 0 || p.Z.ToInt().Cmp(shamir.Order) >= 0 {
+		return fmt.Errorf("%w: bad schnorr proof", ErrInvalidProof)
+	}
+	e := challenge("tss/schnorr", aux, pointInt(public), pointInt(bigR))
+	if !scalarBaseMult(p.Z.ToInt()).equal(bigR.add(public.mul(e))) {
+		return fmt.Errorf("%w: schnorr proof doesn't verify", ErrInvalidProof)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/agglayer/go_signer/log"
+	"github.com/agglayer/go_signer/signer/shamir"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// tamperTransport changes the messages it sends with tamper
+type tamperTransport struct {
+	Transport
+	tamper func(msg *Message)
+}
+
+func (t tamperTransport) Send(ctx context.Context, msg Message) error {
+	t.tamper(&msg)
+	return t.Transport.Send(ctx, msg)
+}
+
+// runKeyGen runs KeyGen for each of transports (party i+1) and returns their results
+func runKeyGen(ctx context.Context, threshold int, transports []Transport) ([]*KeyShare, []error) {
+	shares := make([]*KeyShare, len(transports))
+	errs := make([]error, len(transports))
+	done := make(chan int)
+	for i, transport := range transports {
+		go func() {
+			shares[i], errs[i] = KeyGen(ctx, i+1, threshold, len(transports), "keygen", transport,
+				log.WithFields("party", i+1))
+			done <- i
+		}()
+	}
+	for range transports {
+		<-done
+	}
+	return shares, errs
+}
+
+func memoryTransports(parties int) []Transport {
+	indexes := make([]int, parties)
+	for i := range indexes {
+		indexes[i] = i + 1
+	}
+	network := NewMemoryNetwork(indexes...)
+	res := make([]Transport, parties)
+	for i := range res {
+		res[i] = network.Transport(i + 1)
+	}
+	return res
+}
+
+func TestKeyGenMemoryNetwork(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct{ threshold, parties int }{{1, 1}, {1, 2}, {2, 3}, {3, 5}} {
+		shares, errs := runKeyGen(ctx, tc.threshold, memoryTransports(tc.parties))
+		for i, err := range errs {
+			require.NoError(t, err, "party %d, %d of %d", i+1, tc.threshold, tc.parties)
+		}
+		for _, share := range shares {
+			require.NoError(t, share.Validate())
+			require.Equal(t, shares[0].PublicKey, share.PublicKey)
+			require.Equal(t, shares[0].PublicShares, share.PublicShares)
+		}
+		// threshold shares rebuild the key, it's only done here to check them
+		values := make([]shamir.Share, tc.threshold)
+		for i := range values {
+			values[i] = shamir.Share{Index: shares[i].Index, Value: shares[i].Share}
+		}
+		key, err := shamir.Combine(values)
+		require.NoError(t, err)
+		require.True(t, scalarBaseMult(key).equal(point{X: shares[0].PublicKey.X, Y: shares[0].PublicKey.Y}))
+	}
+}
+
+func TestKeyGenSign(t *testing.T) {
+	shares, errs := runKeyGen(context.Background(), 2, memoryTransports(3))
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	parties := newTestParties(t, shares, nil)
+	hash := crypto.Keccak256Hash([]byte("keygen"))
+	signature, err := parties[2].Sign(context.Background(), hash)
+	require.NoError(t, err)
+	requireSignatureOf(t, shares[0].Address(), hash, signature)
+}
+
+func TestKeyGenTCP(t *testing.T) {
+	certs := writeTestCerts(t, 3, nil)
+	tcp := make([]*TCPTransport, 3)
+	transports := make([]Transport, len(tcp))
+	for i := range tcp {
+		transport, err := ListenTCP(i+1, "127.0.0.1:0", testTLSConfig(t, certs, i+1))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, transport.Close()) })
+		tcp[i] = transport
+		transports[i] = transport
+	}
+	for i, transport := range tcp {
+		for j, peer := range tcp {
+			if i != j {
+				transport.AddPeer(j+1, peer.Addr())
+			}
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	shares, errs := runKeyGen(ctx, 2, transports)
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, shares[0].Address(), shares[2].Address())
+}
+
+func TestKeyGenInvalidShare(t *testing.T) {
+	transports := memoryTransports(3)
+	// Party 1 sends a wrong share to party 2
+	transports[0] = tamperTransport{Transport: transports[0], tamper: func(msg *Message) {
+		if msg.Round != roundKeyGenShare || msg.To != 2 {
+			return
+		}
+		var payload keyGenSharePayload
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return
+		}
+		payload.Share = (*hexutil.Big)(new(big.Int).Add(payload.Share.ToInt(), one))
+		msg.Payload, _ = json.Marshal(payload)
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, errs := runKeyGen(ctx, 2, transports)
+	require.ErrorIs(t, errs[1], ErrInvalidProof)
+	// The others don't get the confirmation of party 2
+	require.Error(t, errs[0])
+	require.Error(t, errs[2])
+}
+
+func TestKeyGenInconsistentCommitments(t *testing.T) {
+	transports := memoryTransports(3)
+	// Party 1 sends the hash of other commitments to party 3, so its view differs
+	transports[0] = tamperTransport{Transport: transports[0], tamper: func(msg *Message) {
+		if msg.Round != roundKeyGenConfirm || msg.To != 3 {
+			return
+		}
+		msg.Payload, _ = json.Marshal(keyGenCommitPayload{Hash: []byte("other")})
+	}}
+	_, errs := runKeyGen(context.Background(), 2, transports)
+	require.NoError(t, errs[1])
+	require.ErrorIs(t, errs[2], ErrBadMessage)
+}
+
+func TestKeyGenBadParams(t *testing.T) {
+	transport := memoryTransports(1)[0]
+	for _, tc := range []struct{ index, threshold, parties int }{{0, 1, 1}, {2, 1, 1}, {1, 0, 2}, {1, 3, 2}} {
+		_, err := KeyGen(context.Background(), tc.index, tc.threshold, tc.parties, "keygen", transport,
+			log.WithFields("test", "test"))
+		require.ErrorIs(t, err, ErrBadKeyGen)
+	}
+}
@@ -0,0 +1,201 @@
+package tss
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/agglayer/go_signer/signer/shamir"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const filePermissions = 0o600
+
+var (
+	// ErrBadKeyShare is returned for a key share that is not consistent with its public key
+	ErrBadKeyShare = errors.New("tss: bad key share")
+)
+
+// KeyShare is the share of the key of a party. Any Threshold parties sign together for the
+// address of PublicKey, none of them knows the private key
+type KeyShare struct {
+	// Index of the party, 1..len(PublicShares)
+	Index int
+	// Threshold is the number of parties needed to sign
+	Threshold int
+	// PublicKey is the public key of the whole key
+	PublicKey *ecdsa.PublicKey
+	// PublicShares are the public keys of the shares of each party (index i+1)
+	PublicShares []*ecdsa.PublicKey
+	// Share is the secret share of the party
+	Share *big.Int
+}
+
+// Deal splits key in parties shares, threshold of them are needed to sign. It's only to import an
+// existing key: the whole key exists in the memory of the dealer, so the dealer machine is a single
+// point of compromise. Use it on an offline machine and distribute the shares, a new key is
+// created with KeyGen
+func Deal(key *ecdsa.PrivateKey, threshold, parties int) ([]*KeyShare, error) {
+	shares, err := shamir.Split(key.D, threshold, parties)
+	if err != nil {
+		return nil, fmt.Errorf("tss: can't split key. Err: %w", err)
+	}
+	publicShares := make([]*ecdsa.PublicKey, parties)
+	for i, share := range shares {
+		publicShares[i] = scalarBaseMult(share.Value).publicKey()
+	}
+	res := make([]*KeyShare, parties)
+	for i, share := range shares {
+		res[i] = &KeyShare{
+			Index:        share.Index,
+			Threshold:    threshold,
+			PublicKey:    &key.PublicKey,
+			PublicShares: publicShares,
+			Share:        share.Value,
+		}
+	}
+	return res, nil
+}
+
+// Address returns the address of the whole key
+func (s *KeyShare) Address() common.Address {
+	return crypto.PubkeyToAddress(*s.PublicKey)
+}
+
+// Parties returns the number of parties of the key
+func (s *KeyShare) Parties() int {
+	return len(s.PublicShares)
+}
+
+// Validate checks that Share matches its public share and that the public shares interpolate PublicKey
+func (s *KeyShare) Validate() error {
+	if s.Threshold < 1 || s.Threshold > s.Parties() || s.Index < 1 || s.Index > s.Parties() {
+		return fmt.Errorf("%w: index %d, threshold %d, parties %d", ErrBadKeyShare, s.Index, s.Threshold, s.Parties())
+	}
+	if s.Share == nil || s.Share.Sign() <= 0 || s.Share.Cmp(shamir.Order) >= 0 || s.PublicKey == nil {
+		return fmt.Errorf("%w: missing share or public key", ErrBadKeyShare)
+	}
+	own := s.PublicShares[s.Index-1]
+	if own == nil || !scalarBaseMult(s.Share).equal(point{X: own.X, Y: own.Y}) {
+		return fmt.Errorf("%w: share doesn't match the public share %d", ErrBadKeyShare, s.Index)
+	}
+	indexes := make([]int, s.Threshold)
+	for i := range indexes {
+		indexes[i] = i + 1
+	}
+	var sum *point
+	for _, index := range indexes {
+		pub := s.PublicShares[index-1]
+		if pub == nil {
+			return fmt.Errorf("%w: missing public share %d", ErrBadKeyShare, index)
+		}
+		lambda, err := shamir.LagrangeCoefficient(index, indexes)
+		if err != nil {
+			return err
+		}
+		term := point{X: pub.X, Y: pub.Y}.mul(lambda)
+		if sum == nil {
+			sum = &term
+		} else {
+			added := sum.add(term)
+			sum = &added
+		}
+	}
+	if !sum.equal(point{X: s.PublicKey.X, Y: s.PublicKey.Y}) {
+		return fmt.Errorf("%w: public shares don't match the public key", ErrBadKeyShare)
+	}
+	return nil
+}
+
+// keyShareJSON is the format of a key share file, only the secret share is encrypted
+type keyShareJSON struct {
+	Address      common.Address      `json:"address"`
+	Index        int                 `json:"index"`
+	Threshold    int                 `json:"threshold"`
+	PublicKey    hexutil.Bytes       `json:"publicKey"`
+	PublicShares []hexutil.Bytes     `json:"publicShares"`
+	Crypto       keystore.CryptoJSON `json:"crypto"`
+}
+
+// EncryptKeyShare encodes share as JSON with the secret share encrypted with password
+// using the keystore v3 scheme (scrypt + AES-128-CTR)
+func EncryptKeyShare(share *KeyShare, password string) ([]byte, error) {
+	if err := share.Validate(); err != nil {
+		return nil, err
+	}
+	cryptoJSON, err := keystore.EncryptDataV3(scalarBytes(share.Share), []byte(password),
+		keystore.StandardScryptN, keystore.StandardScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("tss: can't encrypt key share. Err: %w", err)
+	}
+	res := keyShareJSON{
+		Address:      share.Address(),
+		Index:        share.Index,
+		Threshold:    share.Threshold,
+		PublicKey:    crypto.CompressPubkey(share.PublicKey),
+		PublicShares: make([]hexutil.Bytes, len(share.PublicShares)),
+		Crypto:       cryptoJSON,
+	}
+	for i, pub := range share.PublicShares {
+		res.PublicShares[i] = crypto.CompressPubkey(pub)
+	}
+	return json.MarshalIndent(res, "", "  ")
+}
+
+// DecryptKeyShare decodes the output of EncryptKeyShare and validates the share
+func DecryptKeyShare(data []byte, password string) (*KeyShare, error) {
+	var encoded keyShareJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("tss: key share has a bad format. Err: %w", err)
+	}
+	secret, err := keystore.DecryptDataV3(encoded.Crypto, password)
+	if err != nil {
+		return nil, fmt.Errorf("tss: can't decrypt key share. Err: %w", err)
+	}
+	res := &KeyShare{
+		Index:        encoded.Index,
+		Threshold:    encoded.Threshold,
+		PublicShares: make([]*ecdsa.PublicKey, len(encoded.PublicShares)),
+		Share:        new(big.Int).SetBytes(secret),
+	}
+	if res.PublicKey, err = crypto.DecompressPubkey(encoded.PublicKey); err != nil {
+		return nil, fmt.Errorf("%w: public key. Err: %w", ErrBadKeyShare, err)
+	}
+	for i, pub := range encoded.PublicShares {
+		if res.PublicShares[i], err = crypto.DecompressPubkey(pub); err != nil {
+			return nil, fmt.Errorf("%w: public share %d. Err: %w", ErrBadKeyShare, i+1, err)
+		}
+	}
+	if err := res.Validate(); err != nil {
+		return nil, err
+	}
+	if res.Address() != encoded.Address {
+		return nil, fmt.Errorf("%w: address %s doesn't match the public key", ErrBadKeyShare, encoded.Address.Hex())
+	}
+	return res, nil
+}
+
+// WriteKeyShareFile stores share encrypted with password on path
+func WriteKeyShareFile(path string, share *KeyShare, password string) error {
+	data, err := EncryptKeyShare(share, password)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(path), data, filePermissions)
+}
+
+// ReadKeyShareFile reads a key share file created by WriteKeyShareFile
+func ReadKeyShareFile(path, password string) (*KeyShare, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("tss: can't read key share file %s. Err: %w", path, err)
+	}
+	return DecryptKeyShare(data, password)
+}
@@ -0,0 +1,68 @@
+package tss
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/agglayer/go_signer/signer/shamir"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyShareFile(t *testing.T) {
+	shares := newTestShares(t, 2, 3)
+	path := filepath.Join(t.TempDir(), "share2.json")
+	require.NoError(t, WriteKeyShareFile(path, shares[1], "secret"))
+
+	read, err := ReadKeyShareFile(path, "secret")
+	require.NoError(t, err)
+	require.Equal(t, shares[1].Index, read.Index)
+	require.Equal(t, shares[1].Threshold, read.Threshold)
+	require.Equal(t, shares[1].Share, read.Share)
+	require.Equal(t, shares[1].Address(), read.Address())
+	require.Equal(t, 3, read.Parties())
+
+	_, err = ReadKeyShareFile(path, "wrong")
+	require.ErrorContains(t, err, "can't decrypt")
+}
+
+func TestDealCombine(t *testing.T) {
+	shares := newTestShares(t, 2, 3)
+	secret, err := shamir.Combine([]shamir.Share{
+		{Index: shares[0].Index, Value: shares[0].Share},
+		{Index: shares[2].Index, Value: shares[2].Share},
+	})
+	require.NoError(t, err)
+	require.Equal(t, scalarBaseMult(secret).X, shares[0].PublicKey.X)
+}
+
+func TestKeyShareValidate(t *testing.T) {
+	shares := newTestShares(t, 2, 3)
+	// The public shares don't interpolate the public key
+	other := newTestShares(t, 2, 3)
+	shares[0].PublicKey = other[0].PublicKey
+	require.ErrorIs(t, shares[0].Validate(), ErrBadKeyShare)
+
+	shares[1].Index = 4
+	require.ErrorIs(t, shares[1].Validate(), ErrBadKeyShare)
+
+	_, err := EncryptKeyShare(&KeyShare{Index: 1, Threshold: 1, Share: big.NewInt(1)}, "")
+	require.ErrorIs(t, err, ErrBadKeyShare)
+}
+
+func TestPaillier(t *testing.T) {
+	key := testPartyKeys(t, 1).paillier
+	require.Equal(t, DefaultPaillierBits, key.N.BitLen())
+	require.Equal(t, uint64(3), new(big.Int).Mod(key.p, big.NewInt(4)).Uint64())
+	a, _, err := key.encrypt(big.NewInt(20))
+	require.NoError(t, err)
+	b, _, err := key.encrypt(big.NewInt(-22))
+	require.NoError(t, err)
+	sum, err := key.decryptSigned(key.add(key.mul(a, big.NewInt(2)), b))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(18), sum)
+
+	_, err = key.decrypt(key.NSquared)
+	require.ErrorIs(t, err, ErrBadPaillierKey)
+	require.ErrorIs(t, newPaillierPublicKey(big.NewInt(15)).check(), ErrBadPaillierKey)
+}
@@ -0,0 +1,263 @@
+package tss
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// DefaultPaillierBits is the size of the Paillier modulus of each party
+	DefaultPaillierBits = 2048
+	// MinPaillierBits is the min size of the Paillier modulus: the no small factor proof only
+	// bounds the factors of N if N >= 2^(8*rangeBits) and the MtA masks (< 2^maskBits) plus
+	// the slack of the range proofs must be < N
+	MinPaillierBits = 2048
+)
+
+var (
+	// ErrBadPaillierKey is returned for a modulus too small or a ciphertext out of range
+	ErrBadPaillierKey = errors.New("tss: bad paillier key or ciphertext")
+)
+
+var (
+	one = big.NewInt(1)
+	two = big.NewInt(2)
+	// smallPrimes are the odd primes < 2000, to sieve the candidates of safe primes
+	smallPrimes = oddPrimes(2000)
+)
+
+// paillierPublicKey is the public key of the Paillier cryptosystem with g = N + 1
+type paillierPublicKey struct {
+	N        *big.Int
+	NSquared *big.Int
+}
+
+// paillierPrivateKey is the private key of a party, used to receive the MtA results. N is a
+// product of two safe primes (so it's a Paillier-Blum modulus), the factors are kept for the
+// proofs about N
+type paillierPrivateKey struct {
+	paillierPublicKey
+	p, q *big.Int
+	// phi is (p-1)(q-1)
+	phi *big.Int
+	// mu is phi^-1 mod N
+	mu *big.Int
+}
+
+// generatePaillierKey generates a key with a modulus of bits that is the product of two safe
+// primes, it takes a few seconds
+func generatePaillierKey(bits int) (*paillierPrivateKey, error) {
+	if bits < MinPaillierBits {
+		return nil, fmt.Errorf("%w: modulus of %d bits, min %d", ErrBadPaillierKey, bits, MinPaillierBits)
+	}
+	type result struct {
+		prime *big.Int
+		err   error
+	}
+	for {
+		// Both primes are generated at the same time, each one takes ~1s
+		results := make(chan result, 2)
+		for range 2 {
+			go func() {
+				prime, err := safePrime(bits / 2)
+				results <- result{prime: prime, err: err}
+			}()
+		}
+		first, second := <-results, <-results
+		if err := errors.Join(first.err, second.err); err != nil {
+			return nil, err
+		}
+		p, q := first.prime, second.prime
+		if p.Cmp(q) == 0 {
+			continue
+		}
+		if new(big.Int).Mul(p, q).BitLen() != bits {
+			continue
+		}
+		return newPaillierPrivateKey(p, q), nil
+	}
+}
+
+// newPaillierPrivateKey returns the key of the primes p and q (different safe primes)
+func newPaillierPrivateKey(p, q *big.Int) *paillierPrivateKey {
+	n := new(big.Int).Mul(p, q)
+	phi := new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+	return &paillierPrivateKey{
+		paillierPublicKey: newPaillierPublicKey(n),
+		p:                 p,
+		q:                 q,
+		phi:               phi,
+		// gcd(N, phi) = 1 for primes of the same size
+		mu: new(big.Int).ModInverse(phi, n),
+	}
+}
+
+// safePrime returns a prime p = 2p' + 1 of bits with p' prime and the 2 top bits set, so the
+// product of two of them has 2*bits. p = 3 mod 4, so the product is a Blum integer
+func safePrime(bits int) (*big.Int, error) {
+	limit := new(big.Int).Lsh(one, uint(bits-1))
+	residue := new(big.Int)
+	for {
+		q, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return nil, fmt.Errorf("tss: can't generate prime. Err: %w", err)
+		}
+		q.SetBit(q, bits-2, 1)
+		q.SetBit(q, bits-3, 1)
+		q.SetBit(q, 0, 1)
+		if !sieve(q, residue) {
+			continue
+		}
+		p := new(big.Int).Lsh(q, 1)
+		p.Add(p, one)
+		// A Fermat test of both numbers discards most candidates before Miller-Rabin
+		if new(big.Int).Exp(two, new(big.Int).Sub(q, one), q).Cmp(one) != 0 ||
+			new(big.Int).Exp(two, new(big.Int).Sub(p, one), p).Cmp(one) != 0 {
+			continue
+		}
+		if q.ProbablyPrime(20) && p.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+}
+
+// sieve returns false if q or 2q+1 has a small prime factor
+func sieve(q, residue *big.Int) bool {
+	for _, prime := range smallPrimes {
+		r := residue.Mod(q, new(big.Int).SetUint64(prime)).Uint64()
+		if r == 0 || (2*r+1)%prime == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func oddPrimes(limit uint64) []uint64 {
+	var res []uint64
+	for candidate := uint64(3); candidate < limit; candidate += 2 {
+		isPrime := true
+		for _, prime := range res {
+			if candidate%prime == 0 {
+				isPrime = false
+				break
+			}
+		}
+		if isPrime {
+			res = append(res, candidate)
+		}
+	}
+	return res
+}
+
+func newPaillierPublicKey(n *big.Int) paillierPublicKey {
+	return paillierPublicKey{N: n, NSquared: new(big.Int).Mul(n, n)}
+}
+
+// check returns an error if the modulus is too small to run MtA. The proofs of the modulus
+// (modProof and facProof) are checked by the protocol
+func (k paillierPublicKey) check() error {
+	if k.N == nil || k.N.BitLen() < MinPaillierBits || k.N.Bit(0) == 0 {
+		return fmt.Errorf("%w: modulus too small", ErrBadPaillierKey)
+	}
+	return nil
+}
+
+// checkCiphertext returns an error if c is not in Z*_{N^2}
+func (k paillierPublicKey) checkCiphertext(c *big.Int) error {
+	if c == nil || c.Sign() <= 0 || c.Cmp(k.NSquared) >= 0 || new(big.Int).GCD(nil, nil, c, k.N).Cmp(one) != 0 {
+		return fmt.Errorf("%w: ciphertext out of range", ErrBadPaillierKey)
+	}
+	return nil
+}
+
+// encrypt returns the encryption of m and its nonce, m can be negative (it's taken mod N)
+func (k paillierPublicKey) encrypt(m *big.Int) (*big.Int, *big.Int, error) {
+	r, err := randomUnit(k.N)
+	if err != nil {
+		return nil, nil, err
+	}
+	return k.encryptWithNonce(m, r), r, nil
+}
+
+// encryptWithNonce returns (1 + m*N) * r^N mod N^2
+func (k paillierPublicKey) encryptWithNonce(m, r *big.Int) *big.Int {
+	c := k.plaintext(m)
+	return c.Mul(c, new(big.Int).Exp(r, k.N, k.NSquared)).Mod(c, k.NSquared)
+}
+
+// plaintext returns (1 + N)^m = 1 + m*N mod N^2, m can be negative
+func (k paillierPublicKey) plaintext(m *big.Int) *big.Int {
+	c := new(big.Int).Mod(m, k.N)
+	c.Mul(c, k.N)
+	c.Add(c, one)
+	return c.Mod(c, k.NSquared)
+}
+
+// add returns the encryption of m1 + m2
+func (k paillierPublicKey) add(c1, c2 *big.Int) *big.Int {
+	res := new(big.Int).Mul(c1, c2)
+	return res.Mod(res, k.NSquared)
+}
+
+// mul returns the encryption of m * scalar
+func (k paillierPublicKey) mul(c, scalar *big.Int) *big.Int {
+	return new(big.Int).Exp(c, scalar, k.NSquared)
+}
+
+// decrypt returns L(c^phi mod N^2) * mu mod N, with L(x) = (x - 1) / N
+func (k *paillierPrivateKey) decrypt(c *big.Int) (*big.Int, error) {
+	if err := k.checkCiphertext(c); err != nil {
+		return nil, err
+	}
+	m := new(big.Int).Exp(c, k.phi, k.NSquared)
+	m.Sub(m, one)
+	m.Div(m, k.N)
+	m.Mul(m, k.mu)
+	return m.Mod(m, k.N), nil
+}
+
+// exp returns base^exp mod N using the factors of N (CRT), base must be a unit and exp >= 0
+func (k *paillierPrivateKey) exp(base, exp *big.Int) *big.Int {
+	expMod := func(prime *big.Int) *big.Int {
+		primeExp := new(big.Int).Mod(exp, new(big.Int).Sub(prime, one))
+		return new(big.Int).Exp(new(big.Int).Mod(base, prime), primeExp, prime)
+	}
+	return k.crt(expMod(k.p), expMod(k.q))
+}
+
+// crt returns the x mod N with x = a mod p and x = b mod q
+func (k *paillierPrivateKey) crt(a, b *big.Int) *big.Int {
+	// a + p * ((b - a) * p^-1 mod q)
+	res := new(big.Int).Sub(b, a)
+	res.Mul(res, new(big.Int).ModInverse(k.p, k.q))
+	res.Mod(res, k.q)
+	res.Mul(res, k.p)
+	return res.Add(res, a)
+}
+
+// decryptSigned returns the plaintext of c in (-N/2, N/2]
+func (k *paillierPrivateKey) decryptSigned(c *big.Int) (*big.Int, error) {
+	m, err := k.decrypt(c)
+	if err != nil {
+		return nil, err
+	}
+	if m.Cmp(new(big.Int).Rsh(k.N, 1)) > 0 {
+		m.Sub(m, k.N)
+	}
+	return m, nil
+}
+
+// randomUnit returns a random element of Z*_n
+func randomUnit(n *big.Int) (*big.Int, error) {
+	for {
+		r, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return nil, fmt.Errorf("tss: can't generate random number. Err: %w", err)
+		}
+		if r.Sign() > 0 && new(big.Int).GCD(nil, nil, r, n).Cmp(one) == 0 {
+			return r, nil
+		}
+	}
+}
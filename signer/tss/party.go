@@ -0,0 +1,412 @@
+// Package tss implements threshold ECDSA signing over secp256k1: the key is split in n shares
+// (KeyShare) and any threshold of the parties sign together without rebuilding the key.
+//
+// The protocol is the presigning and signing of CGGMP21 (Canetti, Gennaro, Goldfeder,
+// Makriyannis, Peled) with Paillier based MtA: each party proves that its Paillier modulus is a
+// Paillier-Blum modulus without small factors (Π-mod, Π-fac) and that its ring-Pedersen
+// parameters are well formed (Π-prm), the ciphertexts are range proved (Π-enc, Π-log*) and each
+// MtA answer proves it's affine on the committed values (Π-aff-g), so a malicious party can't
+// extract the shares of the others with crafted messages. A party that sends an invalid proof
+// aborts the session (ErrInvalidProof), the identification of the culprit of CGGMP21 is not
+// implemented. The key shares are created by the distributed key generation (KeyGen), so no
+// machine ever has the whole key. Deal splits an existing key, it's only to import it
+package tss
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultTimeout is the max duration of a signing session
+	DefaultTimeout = 30 * time.Second
+	// sessionIDLength is the number of random bytes of a session id
+	sessionIDLength = 16
+)
+
+var (
+	// ErrNotEnoughParties is returned if less than threshold parties accept to sign
+	ErrNotEnoughParties = errors.New("tss: not enough parties to sign")
+	// ErrBadMessage is returned for a message that doesn't follow the protocol
+	ErrBadMessage = errors.New("tss: bad message")
+	// ErrInvalidSignature is returned if the combined signature doesn't match the public key,
+	// some party has not followed the protocol
+	ErrInvalidSignature = errors.New("tss: combined signature doesn't match the public key")
+	// ErrNoApproval is the answer to the proposals of other parties without WithApproval
+	ErrNoApproval = errors.New("tss: party has no approval function, it rejects the requests of other parties")
+)
+
+// SignRequest is a request to sign received by a party from the coordinator of the session
+type SignRequest struct {
+	Session     string
+	Coordinator int
+	Hash        common.Hash
+}
+
+// ApproveFunc decides if a party joins a signing session, a non nil error rejects it
+type ApproveFunc func(ctx context.Context, request SignRequest) error
+
+// ApproveAll is an ApproveFunc that accepts all the requests: any party can sign any hash
+// with threshold-1 of the other parties, so it's only safe if the requests are checked
+// before reaching the coordinators
+func ApproveAll(context.Context, SignRequest) error {
+	return nil
+}
+
+// Option is an optional parameter of NewParty
+type Option func(*Party)
+
+// WithPaillierBits sets the size of the Paillier modulus (default DefaultPaillierBits)
+func WithPaillierBits(bits int) Option {
+	return func(p *Party) { p.paillierBits = bits }
+}
+
+// WithTimeout sets the max duration of a signing session (default DefaultTimeout)
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *Party) { p.timeout = timeout }
+}
+
+// WithApproval sets the function that decides which requests of other parties are signed,
+// by default none of them (ErrNoApproval)
+func WithApproval(approve ApproveFunc) Option {
+	return func(p *Party) { p.approve = approve }
+}
+
+// Party is a participant of the threshold signing. Run must be running to receive the
+// messages. Any party can start a session with Sign (it's the coordinator of the session),
+// the other parties join it if approve accepts the request
+type Party struct {
+	share        *KeyShare
+	transport    Transport
+	logger       signercommon.Logger
+	keys         *partyKeys
+	paillierBits int
+	timeout      time.Duration
+	approve      ApproveFunc
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// NewParty creates the party of share that talks to the others using transport. It
+// generates the Paillier key of the party (from safe primes) and the proofs about it, that
+// takes a few seconds
+func NewParty(share *KeyShare, transport Transport, logger signercommon.Logger, opts ...Option) (*Party, error) {
+	if err := share.Validate(); err != nil {
+		return nil, err
+	}
+	res := &Party{
+		share:        share,
+		transport:    transport,
+		logger:       logger,
+		paillierBits: DefaultPaillierBits,
+		timeout:      DefaultTimeout,
+		sessions:     map[string]*session{},
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	if res.keys == nil {
+		keys, err := generatePartyKeys(res.paillierBits)
+		if err != nil {
+			return nil, err
+		}
+		res.keys = keys
+	}
+	return res, nil
+}
+
+// Index returns the index of the party
+func (p *Party) Index() int {
+	return p.share.Index
+}
+
+// Address returns the address of the shared key
+func (p *Party) Address() common.Address {
+	return p.share.Address()
+}
+
+// Threshold returns the number of parties needed to sign
+func (p *Party) Threshold() int {
+	return p.share.Threshold
+}
+
+// Run receives the messages of the transport until ctx is done or the transport is closed
+func (p *Party) Run(ctx context.Context) error {
+	for {
+		msg, err := p.transport.Receive(ctx)
+		if err != nil {
+			if errors.Is(err, ErrTransportClosed) || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("%s can't receive. Err: %w", p.logPrefix(), err)
+		}
+		p.dispatch(ctx, msg)
+	}
+}
+
+// Sign signs hash with threshold-1 of the other parties and returns [R || S || V] (V 0/1),
+// a standard signature of Address()
+func (p *Party) Sign(ctx context.Context, hash common.Hash) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+	s := p.newSession(id)
+	defer p.endSession(id)
+
+	var peers []int
+	for index := 1; index <= p.share.Parties(); index++ {
+		if index == p.Index() {
+			continue
+		}
+		if err := p.send(ctx, id, roundPropose, index, proposalPayload{Hash: hash}); err != nil {
+			p.logger.Warnf("%s session %s: %v", p.logPrefix(), id, err)
+			continue
+		}
+		peers = append(peers, index)
+	}
+	accepted, err := p.collectAccepts(ctx, s, peers)
+	if err != nil {
+		return nil, err
+	}
+	signers := append([]int{p.Index()}, accepted...)
+	slices.Sort(signers)
+	// The peers that are not on signers (late or rejected) receive it too, so they end the session
+	for _, index := range peers {
+		if err := p.send(ctx, id, roundStart, index, startPayload{Signers: signers}); err != nil &&
+			slices.Contains(signers, index) {
+			return nil, err
+		}
+	}
+	return p.signSession(ctx, s, signers, hash)
+}
+
+// collectAccepts waits the answers of peers until threshold-1 of them accept
+func (p *Party) collectAccepts(ctx context.Context, s *session, peers []int) ([]int, error) {
+	need := p.Threshold() - 1
+	var (
+		accepted []int
+		errs     []error
+	)
+	pending := slices.Clone(peers)
+	for len(accepted) < need && len(pending) >= need-len(accepted) {
+		msg, err := s.next(ctx, roundAccept, pending)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		pending = slices.DeleteFunc(pending, func(index int) bool { return index == msg.From })
+		var payload acceptPayload
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			errs = append(errs, fmt.Errorf("party %d: %w", msg.From, ErrBadMessage))
+			continue
+		}
+		if payload.Error != "" {
+			errs = append(errs, fmt.Errorf("party %d: %s", msg.From, payload.Error))
+			continue
+		}
+		accepted = append(accepted, msg.From)
+	}
+	if len(accepted) < need {
+		return nil, fmt.Errorf("%s %w: %d of %d. Err: %w", p.logPrefix(), ErrNotEnoughParties,
+			len(accepted)+1, p.Threshold(), errors.Join(errs...))
+	}
+	return accepted, nil
+}
+
+// cosign joins the session proposed by msg.From
+func (p *Party) cosign(ctx context.Context, s *session, proposal Message) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	defer p.endSession(s.id)
+	var payload proposalPayload
+	if err := json.Unmarshal(proposal.Payload, &payload); err != nil {
+		p.logger.Warnf("%s session %s: bad proposal of party %d", p.logPrefix(), s.id, proposal.From)
+		return
+	}
+	request := SignRequest{Session: s.id, Coordinator: proposal.From, Hash: payload.Hash}
+	var answer acceptPayload
+	if p.approve == nil {
+		answer.Error = ErrNoApproval.Error()
+	} else if err := p.approve(ctx, request); err != nil {
+		answer.Error = err.Error()
+	}
+	if err := p.send(ctx, s.id, roundAccept, proposal.From, answer); err != nil || answer.Error != "" {
+		p.logger.Infof("%s session %s: rejected hash %s of party %d: %s %v", p.logPrefix(), s.id,
+			payload.Hash.Hex(), proposal.From, answer.Error, err)
+		return
+	}
+	msg, err := s.next(ctx, roundStart, []int{proposal.From})
+	if err != nil {
+		p.logger.Warnf("%s session %s: not started. Err: %v", p.logPrefix(), s.id, err)
+		return
+	}
+	var start startPayload
+	if err := json.Unmarshal(msg.Payload, &start); err != nil {
+		p.logger.Warnf("%s session %s: bad start of party %d", p.logPrefix(), s.id, proposal.From)
+		return
+	}
+	if !slices.Contains(start.Signers, p.Index()) {
+		return
+	}
+	if !slices.Contains(start.Signers, proposal.From) {
+		p.logger.Warnf("%s session %s: coordinator is not a signer", p.logPrefix(), s.id)
+		return
+	}
+	if _, err := p.signSession(ctx, s, start.Signers, payload.Hash); err != nil {
+		p.logger.Warnf("%s session %s: %v", p.logPrefix(), s.id, err)
+		return
+	}
+	p.logger.Infof("%s session %s: signed hash %s with parties %v", p.logPrefix(), s.id,
+		payload.Hash.Hex(), start.Signers)
+}
+
+// dispatch delivers msg to its session, a proposal of a new session starts cosign
+func (p *Party) dispatch(ctx context.Context, msg Message) {
+	if msg.To != p.Index() || msg.From == p.Index() || msg.From < 1 || msg.From > p.share.Parties() {
+		p.logger.Debugf("%s dropped message from %d to %d", p.logPrefix(), msg.From, msg.To)
+		return
+	}
+	p.mu.Lock()
+	p.pruneSessions()
+	s, ok := p.sessions[msg.Session]
+	if !ok {
+		// The messages of other parties can arrive before the proposal, they are kept
+		s = newSession(msg.Session, p.share.Parties())
+		p.sessions[msg.Session] = s
+	}
+	if msg.Round == roundPropose {
+		joined := s.joined
+		s.joined = true
+		p.mu.Unlock()
+		if !joined {
+			go p.cosign(ctx, s, msg)
+		}
+		return
+	}
+	p.mu.Unlock()
+	select {
+	case s.inbox <- msg:
+	default:
+		p.logger.Warnf("%s session %s: dropped message of party %d, too many messages", p.logPrefix(),
+			msg.Session, msg.From)
+	}
+}
+
+func (p *Party) newSession(id string) *session {
+	s := newSession(id, p.share.Parties())
+	s.joined = true
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sessions[id] = s
+	return s
+}
+
+func (p *Party) endSession(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.sessions, id)
+}
+
+// pruneSessions removes the sessions not joined (no proposal) after the timeout. p.mu must be locked
+func (p *Party) pruneSessions() {
+	for id, s := range p.sessions {
+		if !s.joined && time.Since(s.created) > p.timeout {
+			delete(p.sessions, id)
+		}
+	}
+}
+
+func (p *Party) send(ctx context.Context, session string, round, to int, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%s can't encode message. Err: %w", p.logPrefix(), err)
+	}
+	msg := Message{Session: session, Round: round, From: p.Index(), To: to, Payload: data}
+	if err := p.transport.Send(ctx, msg); err != nil {
+		return fmt.Errorf("%s can't send round %d to party %d. Err: %w", p.logPrefix(), round, to, err)
+	}
+	return nil
+}
+
+func (p *Party) logPrefix() string {
+	return fmt.Sprintf("tss[%d]:", p.Index())
+}
+
+// session is a signing session, it buffers the messages until the protocol reads them
+type session struct {
+	id      string
+	created time.Time
+	inbox   chan Message
+	// joined is true once the party takes part (coordinator or proposal received)
+	joined bool
+	// pending are the messages received for a later round
+	pending []Message
+}
+
+func newSession(id string, parties int) *session {
+	return &session{
+		id:      id,
+		created: time.Now(),
+		inbox:   make(chan Message, parties*rounds),
+	}
+}
+
+// next returns the next message of round from any of from
+func (s *session) next(ctx context.Context, round int, from []int) (Message, error) {
+	for i, msg := range s.pending {
+		if msg.Round == round && slices.Contains(from, msg.From) {
+			s.pending = slices.Delete(s.pending, i, i+1)
+			return msg, nil
+		}
+	}
+	for {
+		select {
+		case msg := <-s.inbox:
+			if msg.Round == round && slices.Contains(from, msg.From) {
+				return msg, nil
+			}
+			if msg.Round > round {
+				s.pending = append(s.pending, msg)
+			}
+		case <-ctx.Done():
+			return Message{}, fmt.Errorf("session %s: waiting round %d of %v. Err: %w", s.id, round, from, ctx.Err())
+		}
+	}
+}
+
+// collect returns the payload of round of each party of from
+func (s *session) collect(ctx context.Context, round int, from []int) (map[int]json.RawMessage, error) {
+	res := make(map[int]json.RawMessage, len(from))
+	pending := slices.Clone(from)
+	for len(pending) > 0 {
+		msg, err := s.next(ctx, round, pending)
+		if err != nil {
+			return nil, err
+		}
+		pending = slices.DeleteFunc(pending, func(index int) bool { return index == msg.From })
+		res[msg.From] = msg.Payload
+	}
+	return res, nil
+}
+
+func newSessionID() (string, error) {
+	id := make([]byte, sessionIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("tss: can't generate session id. Err: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
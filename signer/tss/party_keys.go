@@ -0,0 +1,99 @@
+package tss
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// verifiedKeys are the digests of the keys whose proofs are valid. The proofs only depend on the
+// keys, so they are verified once per process for all the parties and sessions
+var verifiedKeys sync.Map
+
+// keysPayload are the keys of a party with the proofs that they are well formed
+type keysPayload struct {
+	PaillierN hexutil.Bytes `json:"paillierN"`
+	PedersenS hexutil.Bytes `json:"pedersenS"`
+	PedersenT hexutil.Bytes `json:"pedersenT"`
+	ModProof  *modProof     `json:"modProof"`
+	PrmProof  *prmProof     `json:"prmProof"`
+}
+
+// partyKeys are the Paillier key and the ring-Pedersen parameters (over the same modulus) of a
+// party. They are generated once by NewParty and sent to the other parties on each session
+type partyKeys struct {
+	paillier *paillierPrivateKey
+	pedersen pedersenParams
+	payload  keysPayload
+}
+
+// peerKeys are the keys of another party, once their proofs are verified
+type peerKeys struct {
+	paillier paillierPublicKey
+	pedersen pedersenParams
+}
+
+// generatePartyKeys generates the keys of a party with a modulus of bits and their proofs
+func generatePartyKeys(bits int) (*partyKeys, error) {
+	paillier, err := generatePaillierKey(bits)
+	if err != nil {
+		return nil, err
+	}
+	return newPartyKeys(paillier)
+}
+
+// newPartyKeys generates the ring-Pedersen parameters over the modulus of paillier and the proofs
+func newPartyKeys(paillier *paillierPrivateKey) (*partyKeys, error) {
+	pedersen, lambda, err := generatePedersenParams(paillier)
+	if err != nil {
+		return nil, err
+	}
+	mod, err := proveMod(paillier)
+	if err != nil {
+		return nil, err
+	}
+	prm, err := provePrm(paillier, pedersen, lambda)
+	if err != nil {
+		return nil, err
+	}
+	return &partyKeys{
+		paillier: paillier,
+		pedersen: pedersen,
+		payload: keysPayload{
+			PaillierN: paillier.N.Bytes(),
+			PedersenS: pedersen.S.Bytes(),
+			PedersenT: pedersen.T.Bytes(),
+			ModProof:  mod,
+			PrmProof:  prm,
+		},
+	}, nil
+}
+
+// verifyKeys checks the keys of a party with their proofs
+func verifyKeys(payload keysPayload) (peerKeys, error) {
+	n := new(big.Int).SetBytes(payload.PaillierN)
+	res := peerKeys{
+		paillier: newPaillierPublicKey(n),
+		pedersen: pedersenParams{
+			N: n,
+			S: new(big.Int).SetBytes(payload.PedersenS),
+			T: new(big.Int).SetBytes(payload.PedersenT),
+		},
+	}
+	if err := res.pedersen.check(); err != nil {
+		return peerKeys{}, err
+	}
+	digest := string(transcriptHash("tss/keys", nil, res.pedersen.N, res.pedersen.S, res.pedersen.T))
+	if _, ok := verifiedKeys.Load(digest); ok {
+		return res, nil
+	}
+	if err := payload.ModProof.verify(n); err != nil {
+		return peerKeys{}, err
+	}
+	if err := payload.PrmProof.verify(res.pedersen); err != nil {
+		return peerKeys{}, err
+	}
+	verifiedKeys.Store(digest, struct{}{})
+	return res, nil
+}
@@ -0,0 +1,197 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agglayer/go_signer/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// testParties is the max number of parties of the tests, they share the keys of testPartyKeys
+const testParties = 5
+
+var (
+	errTestRejected = errors.New("rejected by policy")
+
+	testKeysOnce sync.Once
+	testKeys     []*partyKeys
+	testKeysErr  error
+)
+
+// testPartyKeys returns the keys of party index. The Paillier keys are built from the safe primes
+// of testdata, generating them takes a few seconds each
+func testPartyKeys(t *testing.T, index int) *partyKeys {
+	t.Helper()
+	testKeysOnce.Do(func() {
+		data, err := os.ReadFile(filepath.Join("testdata", "safe_primes.json"))
+		if err != nil {
+			testKeysErr = err
+			return
+		}
+		var primes []hexutil.Bytes
+		if testKeysErr = json.Unmarshal(data, &primes); testKeysErr != nil {
+			return
+		}
+		testKeys = make([]*partyKeys, testParties)
+		for i := range testKeys {
+			paillier := newPaillierPrivateKey(new(big.Int).SetBytes(primes[2*i]), new(big.Int).SetBytes(primes[2*i+1]))
+			if testKeys[i], testKeysErr = newPartyKeys(paillier); testKeysErr != nil {
+				return
+			}
+		}
+	})
+	require.NoError(t, testKeysErr)
+	return testKeys[index-1]
+}
+
+// withKeys sets the keys of the party, so the tests don't generate them
+func withKeys(keys *partyKeys) Option {
+	return func(p *Party) { p.keys = keys }
+}
+
+// testOptions are the options of party index on the tests, it accepts all the requests
+func testOptions(t *testing.T, index int, timeout time.Duration) []Option {
+	t.Helper()
+	return []Option{withKeys(testPartyKeys(t, index)), WithTimeout(timeout), WithApproval(ApproveAll)}
+}
+
+func newTestShares(t *testing.T, threshold, parties int) []*KeyShare {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	shares, err := Deal(key, threshold, parties)
+	require.NoError(t, err)
+	for _, share := range shares {
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), share.Address())
+	}
+	return shares
+}
+
+// newTestParties runs a party for each share on a MemoryNetwork, opts[i] are the options of party i+1
+func newTestParties(t *testing.T, shares []*KeyShare, opts map[int][]Option) []*Party {
+	t.Helper()
+	indexes := make([]int, len(shares))
+	for i, share := range shares {
+		indexes[i] = share.Index
+	}
+	network := NewMemoryNetwork(indexes...)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	parties := make([]*Party, len(shares))
+	for i, share := range shares {
+		partyOpts := append(testOptions(t, share.Index, 30*time.Second), opts[share.Index]...)
+		party, err := NewParty(share, network.Transport(share.Index), log.WithFields("party", share.Index),
+			partyOpts...)
+		require.NoError(t, err)
+		go func() { _ = party.Run(ctx) }()
+		parties[i] = party
+	}
+	return parties
+}
+
+func requireSignatureOf(t *testing.T, address common.Address, hash common.Hash, signature []byte) {
+	t.Helper()
+	require.Len(t, signature, crypto.SignatureLength)
+	pub, err := crypto.SigToPub(hash.Bytes(), signature)
+	require.NoError(t, err)
+	require.Equal(t, address, crypto.PubkeyToAddress(*pub))
+	require.True(t, crypto.ValidateSignatureValues(signature[crypto.RecoveryIDOffset],
+		common.BytesToHash(signature[:32]).Big(), common.BytesToHash(signature[32:64]).Big(), true))
+}
+
+func TestSignMemoryNetwork(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct{ threshold, parties int }{{1, 2}, {2, 3}, {3, 5}} {
+		shares := newTestShares(t, tc.threshold, tc.parties)
+		parties := newTestParties(t, shares, nil)
+		// Any party can coordinate
+		for _, party := range []*Party{parties[0], parties[len(parties)-1]} {
+			hash := crypto.Keccak256Hash([]byte("message"), []byte{byte(party.Index())})
+			signature, err := party.Sign(ctx, hash)
+			require.NoError(t, err, "%d of %d", tc.threshold, tc.parties)
+			requireSignatureOf(t, shares[0].Address(), hash, signature)
+		}
+	}
+}
+
+func TestSignRejected(t *testing.T) {
+	ctx := context.Background()
+	shares := newTestShares(t, 2, 3)
+	reject := WithApproval(func(context.Context, SignRequest) error { return errTestRejected })
+	var requested SignRequest
+	accept := WithApproval(func(_ context.Context, request SignRequest) error {
+		requested = request
+		return nil
+	})
+
+	// Party 2 rejects, party 3 accepts
+	parties := newTestParties(t, shares, map[int][]Option{2: {reject}, 3: {accept}})
+	hash := common.Hash{1}
+	signature, err := parties[0].Sign(ctx, hash)
+	require.NoError(t, err)
+	requireSignatureOf(t, shares[0].Address(), hash, signature)
+	require.Equal(t, 1, requested.Coordinator)
+	require.Equal(t, hash, requested.Hash)
+
+	// Both reject
+	parties = newTestParties(t, shares, map[int][]Option{2: {reject}, 3: {reject}})
+	_, err = parties[0].Sign(ctx, hash)
+	require.ErrorIs(t, err, ErrNotEnoughParties)
+	require.ErrorContains(t, err, errTestRejected.Error())
+}
+
+func TestSignNoApproval(t *testing.T) {
+	shares := newTestShares(t, 2, 2)
+	parties := newTestParties(t, shares, map[int][]Option{2: {WithApproval(nil)}})
+	_, err := parties[0].Sign(context.Background(), common.Hash{1})
+	require.ErrorIs(t, err, ErrNotEnoughParties)
+	require.ErrorContains(t, err, ErrNoApproval.Error())
+}
+
+func TestSignPartyDown(t *testing.T) {
+	shares := newTestShares(t, 3, 3)
+	network := NewMemoryNetwork(1, 2, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	party, err := NewParty(shares[0], network.Transport(1), log.WithFields("party", 1),
+		testOptions(t, 1, 100*time.Millisecond)...)
+	require.NoError(t, err)
+	go func() { _ = party.Run(ctx) }()
+	// Parties 2 and 3 are not running
+	_, err = party.Sign(ctx, common.Hash{1})
+	require.ErrorIs(t, err, ErrNotEnoughParties)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewPartyErrors(t *testing.T) {
+	shares := newTestShares(t, 2, 3)
+	network := NewMemoryNetwork(1, 2, 3)
+	_, err := NewParty(shares[0], network.Transport(1), log.WithFields("party", 1), WithPaillierBits(1024))
+	require.ErrorIs(t, err, ErrBadPaillierKey)
+
+	shares[0].Share.Add(shares[0].Share, common.Big1)
+	_, err = NewParty(shares[0], network.Transport(1), log.WithFields("party", 1))
+	require.ErrorIs(t, err, ErrBadKeyShare)
+}
+
+func TestSignInvalidProof(t *testing.T) {
+	ctx := context.Background()
+	shares := newTestShares(t, 2, 2)
+	// Party 2 sends ring-Pedersen parameters where s is not generated by t
+	keys := *testPartyKeys(t, 2)
+	keys.payload.PedersenS = new(big.Int).Add(keys.pedersen.S, one).Bytes()
+	parties := newTestParties(t, shares, map[int][]Option{2: {withKeys(&keys)}})
+	_, err := parties[0].Sign(ctx, common.Hash{1})
+	require.ErrorIs(t, err, ErrInvalidProof)
+}
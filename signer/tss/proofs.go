@@ -0,0 +1,188 @@
+package tss
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/agglayer/go_signer/signer/shamir"
+)
+
+// The zero-knowledge proofs are the ones of CGGMP21 (Canetti, Gennaro, Goldfeder, Makriyannis,
+// Peled: "UC Non-Interactive, Proactive, Threshold ECDSA with Identifiable Aborts") made
+// non-interactive with Fiat-Shamir. The big numbers of the proofs are *big.Int: they can be
+// negative and bigger than hexutil.Big allows, encoding/json writes them as JSON numbers
+const (
+	// rangeBits is l: the secrets of the range proofs (k_i, gamma_i, w_i) are < 2^rangeBits
+	rangeBits = 256
+	// maskBits is l': the MtA masks are < 2^maskBits
+	maskBits = 5 * rangeBits
+	// slackBits is epsilon: the masks of the proofs hide e*x with e, x < 2^rangeBits
+	slackBits = 2 * rangeBits
+	// proofIterations is m, the repetitions of modProof and prmProof (soundness 2^-m)
+	proofIterations = 128
+)
+
+// ErrInvalidProof is returned if a zero-knowledge proof of a party doesn't verify
+var ErrInvalidProof = errors.New("tss: invalid proof")
+
+// pedersenParams are the ring-Pedersen parameters of a party: N is its Paillier modulus and
+// s = t^lambda mod N. The other parties use them as setup of the proofs they send to it
+type pedersenParams struct {
+	N, S, T *big.Int
+}
+
+// generatePedersenParams returns the parameters over the modulus of key and lambda
+func generatePedersenParams(key *paillierPrivateKey) (pedersenParams, *big.Int, error) {
+	tau, err := randomUnit(key.N)
+	if err != nil {
+		return pedersenParams{}, nil, err
+	}
+	lambda, err := rand.Int(rand.Reader, key.phi)
+	if err != nil {
+		return pedersenParams{}, nil, fmt.Errorf("tss: can't generate random number. Err: %w", err)
+	}
+	t := new(big.Int).Exp(tau, two, key.N)
+	return pedersenParams{N: key.N, S: new(big.Int).Exp(t, lambda, key.N), T: t}, lambda, nil
+}
+
+// check returns an error if s and t are not units of Z*_N different from 1. prmProof proves
+// that s is generated by t
+func (p pedersenParams) check() error {
+	if err := newPaillierPublicKey(p.N).check(); err != nil {
+		return err
+	}
+	for _, value := range []*big.Int{p.S, p.T} {
+		if !isUnit(value, p.N) || value.Cmp(one) == 0 {
+			return fmt.Errorf("%w: bad ring-Pedersen parameters", ErrInvalidProof)
+		}
+	}
+	return nil
+}
+
+// commit returns s^x * t^r mod N, x and r can be negative
+func (p pedersenParams) commit(x, r *big.Int) *big.Int {
+	res := new(big.Int).Exp(p.S, x, p.N)
+	if res == nil {
+		return nil
+	}
+	return mulMod(res, expMod(p.T, r, p.N), p.N)
+}
+
+// expMod returns base^exp mod m, exp can be negative. It's nil if base has no inverse
+func expMod(base, exp, m *big.Int) *big.Int {
+	return new(big.Int).Exp(base, exp, m)
+}
+
+// mulMod returns a*b mod m, nil if any of them is nil
+func mulMod(a, b, m *big.Int) *big.Int {
+	if a == nil || b == nil {
+		return nil
+	}
+	res := new(big.Int).Mul(a, b)
+	return res.Mod(res, m)
+}
+
+// equal returns true if a and b are not nil and equal
+func equal(a, b *big.Int) bool {
+	return a != nil && b != nil && a.Cmp(b) == 0
+}
+
+// isUnit returns true if x is in Z*_n
+func isUnit(x, n *big.Int) bool {
+	return x != nil && x.Sign() > 0 && x.Cmp(n) < 0 && new(big.Int).GCD(nil, nil, x, n).Cmp(one) == 0
+}
+
+// hasNil returns true if any of values is nil (a field missing on the message)
+func hasNil(values ...*big.Int) bool {
+	for _, value := range values {
+		if value == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// pow2 returns 2^bits
+func pow2(bits int) *big.Int {
+	return new(big.Int).Lsh(one, uint(bits))
+}
+
+// inRange returns true if |x| <= bound
+func inRange(x, bound *big.Int) bool {
+	return new(big.Int).Abs(x).Cmp(bound) <= 0
+}
+
+// randomSymmetric returns a random integer in [-bound, bound]
+func randomSymmetric(bound *big.Int) (*big.Int, error) {
+	width := new(big.Int).Lsh(bound, 1)
+	res, err := rand.Int(rand.Reader, width.Add(width, one))
+	if err != nil {
+		return nil, fmt.Errorf("tss: can't generate random number. Err: %w", err)
+	}
+	return res.Sub(res, bound), nil
+}
+
+// randomSymmetrics returns a random integer in [-bound, bound] for each bound
+func randomSymmetrics(bounds ...*big.Int) ([]*big.Int, error) {
+	res := make([]*big.Int, len(bounds))
+	for i, bound := range bounds {
+		var err error
+		if res[i], err = randomSymmetric(bound); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// transcriptHash hashes label, aux (the context of the proof) and values, each one prefixed
+// by its length so different inputs never have the same encoding
+func transcriptHash(label string, aux []byte, values ...*big.Int) []byte {
+	h := sha256.New()
+	write := func(data []byte) {
+		var size [8]byte
+		binary.BigEndian.PutUint64(size[:], uint64(len(data)))
+		h.Write(size[:])
+		h.Write(data)
+	}
+	write([]byte(label))
+	write(aux)
+	for _, value := range values {
+		sign := byte(0)
+		if value.Sign() < 0 {
+			sign = 1
+		}
+		write(append([]byte{sign}, value.Bytes()...))
+	}
+	return h.Sum(nil)
+}
+
+// expandHash returns size bytes derived from seed
+func expandHash(seed []byte, size int) []byte {
+	res := make([]byte, 0, size+sha256.Size)
+	var counter [4]byte
+	for i := uint32(0); len(res) < size; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		h := sha256.New()
+		h.Write(seed)
+		h.Write(counter[:])
+		res = h.Sum(res)
+	}
+	return res[:size]
+}
+
+// challenge returns the Fiat-Shamir challenge in [0, q) of label, aux and values
+func challenge(label string, aux []byte, values ...*big.Int) *big.Int {
+	// 64 bytes so the bias of the reduction mod q is negligible
+	res := new(big.Int).SetBytes(expandHash(transcriptHash(label, aux, values...), 64))
+	return res.Mod(res, shamir.Order)
+}
+
+// proofAux is the context of a proof of a session: the proofs of a session can't be replayed
+// on other sessions or to other parties
+func proofAux(session string, prover, verifier int) []byte {
+	return fmt.Appendf(nil, "%s:%d:%d", session, prover, verifier)
+}
@@ -0,0 +1,265 @@
+package tss
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// modProof (Π-mod) proves that N is a Paillier-Blum modulus: N = pq with p, q = 3 mod 4 and
+// gcd(N, phi(N)) = 1. For each challenge y_i, X_i is a 4th root of (-1)^A_i * W^B_i * y_i and
+// Z_i is the N-th root of y_i
+type modProof struct {
+	W *big.Int   `json:"w"`
+	X []*big.Int `json:"x"`
+	A []bool     `json:"a"`
+	B []bool     `json:"b"`
+	Z []*big.Int `json:"z"`
+}
+
+// proveMod proves that the modulus of key is a Paillier-Blum modulus
+func proveMod(key *paillierPrivateKey) (*modProof, error) {
+	n := key.N
+	var w *big.Int
+	for w == nil || big.Jacobi(w, n) != -1 {
+		var err error
+		if w, err = randomUnit(n); err != nil {
+			return nil, err
+		}
+	}
+	nInverse := new(big.Int).ModInverse(n, key.phi)
+	if nInverse == nil {
+		return nil, fmt.Errorf("%w: N is not coprime with phi(N)", ErrBadPaillierKey)
+	}
+	res := &modProof{
+		W: w,
+		X: make([]*big.Int, proofIterations),
+		A: make([]bool, proofIterations),
+		B: make([]bool, proofIterations),
+		Z: make([]*big.Int, proofIterations),
+	}
+	for i, y := range modChallenges(n, w) {
+		res.Z[i] = key.exp(y, nInverse)
+		// Exactly one of y, -y, w*y, -w*y is a quadratic residue mod p and mod q
+		found := false
+		for _, a := range []bool{false, true} {
+			for _, b := range []bool{false, true} {
+				candidate := modAdjust(n, w, y, a, b)
+				if !found && big.Jacobi(candidate, key.p) == 1 && big.Jacobi(candidate, key.q) == 1 {
+					res.X[i], res.A[i], res.B[i] = key.fourthRoot(candidate), a, b
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: challenge is not a unit", ErrBadPaillierKey)
+		}
+	}
+	return res, nil
+}
+
+// verify checks that the proof is valid for n
+func (p *modProof) verify(n *big.Int) error {
+	if p == nil || len(p.X) != proofIterations || len(p.A) != proofIterations ||
+		len(p.B) != proofIterations || len(p.Z) != proofIterations || hasNil(p.X...) || hasNil(p.Z...) {
+		return fmt.Errorf("%w: bad mod proof", ErrInvalidProof)
+	}
+	if n.Bit(0) == 0 || n.ProbablyPrime(20) || !isUnit(p.W, n) || big.Jacobi(p.W, n) != -1 {
+		return fmt.Errorf("%w: mod proof, N or W", ErrInvalidProof)
+	}
+	four := big.NewInt(4)
+	for i, y := range modChallenges(n, p.W) {
+		if !isUnit(p.X[i], n) || !isUnit(p.Z[i], n) ||
+			new(big.Int).Exp(p.Z[i], n, n).Cmp(y) != 0 ||
+			new(big.Int).Exp(p.X[i], four, n).Cmp(modAdjust(n, p.W, y, p.A[i], p.B[i])) != 0 {
+			return fmt.Errorf("%w: mod proof, iteration %d", ErrInvalidProof, i)
+		}
+	}
+	return nil
+}
+
+// modChallenges returns the challenges y_i in Z_N of a modProof
+func modChallenges(n, w *big.Int) []*big.Int {
+	res := make([]*big.Int, proofIterations)
+	size := len(n.Bytes()) + 16
+	for i := range res {
+		seed := transcriptHash("tss/mod", nil, n, w, big.NewInt(int64(i)))
+		res[i] = new(big.Int).SetBytes(expandHash(seed, size))
+		res[i].Mod(res[i], n)
+	}
+	return res
+}
+
+// modAdjust returns (-1)^a * w^b * y mod n
+func modAdjust(n, w, y *big.Int, a, b bool) *big.Int {
+	res := new(big.Int).Set(y)
+	if a {
+		res.Neg(res)
+	}
+	if b {
+		res.Mul(res, w)
+	}
+	return res.Mod(res, n)
+}
+
+// fourthRoot returns the 4th root of a quadratic residue y mod N that is a quadratic residue.
+// For a Blum prime p, y^((p+1)/4) is the square root of y that is a quadratic residue
+func (k *paillierPrivateKey) fourthRoot(y *big.Int) *big.Int {
+	root := func(prime *big.Int) *big.Int {
+		pMinus1 := new(big.Int).Sub(prime, one)
+		exp := new(big.Int).Rsh(new(big.Int).Add(prime, one), 2)
+		exp.Mul(exp, exp).Mod(exp, pMinus1)
+		return new(big.Int).Exp(new(big.Int).Mod(y, prime), exp, prime)
+	}
+	return k.crt(root(k.p), root(k.q))
+}
+
+// prmProof (Π-prm) proves that s is generated by t on the ring-Pedersen parameters (s = t^lambda)
+type prmProof struct {
+	A []*big.Int `json:"a"`
+	Z []*big.Int `json:"z"`
+}
+
+// provePrm proves that params.S = params.T^lambda, params are over the modulus of key
+func provePrm(key *paillierPrivateKey, params pedersenParams, lambda *big.Int) (*prmProof, error) {
+	phi := key.phi
+	secrets := make([]*big.Int, proofIterations)
+	res := &prmProof{A: make([]*big.Int, proofIterations), Z: make([]*big.Int, proofIterations)}
+	for i := range secrets {
+		var err error
+		if secrets[i], err = rand.Int(rand.Reader, phi); err != nil {
+			return nil, fmt.Errorf("tss: can't generate random number. Err: %w", err)
+		}
+		res.A[i] = key.exp(params.T, secrets[i])
+	}
+	for i, e := range prmChallenges(params, res.A) {
+		res.Z[i] = secrets[i]
+		if e {
+			res.Z[i].Add(res.Z[i], lambda).Mod(res.Z[i], phi)
+		}
+	}
+	return res, nil
+}
+
+// verify checks that the proof is valid for params
+func (p *prmProof) verify(params pedersenParams) error {
+	if p == nil || len(p.A) != proofIterations || len(p.Z) != proofIterations || hasNil(p.A...) ||
+		hasNil(p.Z...) {
+		return fmt.Errorf("%w: bad prm proof", ErrInvalidProof)
+	}
+	for i, e := range prmChallenges(params, p.A) {
+		expected := p.A[i]
+		if e {
+			expected = mulMod(expected, params.S, params.N)
+		}
+		if p.Z[i].Sign() < 0 || !isUnit(p.A[i], params.N) ||
+			new(big.Int).Exp(params.T, p.Z[i], params.N).Cmp(expected) != 0 {
+			return fmt.Errorf("%w: prm proof, iteration %d", ErrInvalidProof, i)
+		}
+	}
+	return nil
+}
+
+// prmChallenges returns the challenge bits of a prmProof
+func prmChallenges(params pedersenParams, commitments []*big.Int) []bool {
+	values := append([]*big.Int{params.N, params.S, params.T}, commitments...)
+	bits := expandHash(transcriptHash("tss/prm", nil, values...), proofIterations/8)
+	res := make([]bool, proofIterations)
+	for i := range res {
+		res[i] = bits[i/8]>>(i%8)&1 == 1
+	}
+	return res
+}
+
+// facProof (Π-fac) proves that the factors of N0 are > 2^-(rangeBits+slackBits) * sqrt(N0), so
+// N0 has no small factors. It uses the ring-Pedersen parameters of the verifier
+type facProof struct {
+	P     *big.Int `json:"p"`
+	Q     *big.Int `json:"q"`
+	A     *big.Int `json:"a"`
+	B     *big.Int `json:"b"`
+	T     *big.Int `json:"t"`
+	Sigma *big.Int `json:"sigma"`
+	Z1    *big.Int `json:"z1"`
+	Z2    *big.Int `json:"z2"`
+	W1    *big.Int `json:"w1"`
+	W2    *big.Int `json:"w2"`
+	V     *big.Int `json:"v"`
+}
+
+// proveFac proves that the modulus of key has no small factors to the verifier with params
+func proveFac(aux []byte, verifier pedersenParams, key *paillierPrivateKey) (*facProof, error) {
+	n0, nHat := key.N, verifier.N
+	sqrtN0 := new(big.Int).Sqrt(n0)
+	slack := pow2(rangeBits + slackBits)
+	secretBound := new(big.Int).Mul(pow2(rangeBits), nHat)
+	maskBound := new(big.Int).Mul(slack, nHat)
+	randoms, err := randomSymmetrics(
+		new(big.Int).Mul(slack, sqrtN0), new(big.Int).Mul(slack, sqrtN0), // alpha, beta
+		secretBound, secretBound, // mu, nu
+		new(big.Int).Mul(secretBound, n0), // sigma
+		new(big.Int).Mul(maskBound, n0),   // r
+		maskBound, maskBound,              // x, y
+	)
+	if err != nil {
+		return nil, err
+	}
+	alpha, beta, mu, nu, sigma, r, x, y := randoms[0], randoms[1], randoms[2], randoms[3], randoms[4],
+		randoms[5], randoms[6], randoms[7]
+	res := &facProof{
+		P:     verifier.commit(key.p, mu),
+		Q:     verifier.commit(key.q, nu),
+		A:     verifier.commit(alpha, x),
+		B:     verifier.commit(beta, y),
+		Sigma: sigma,
+	}
+	res.T = mulMod(expMod(res.Q, alpha, nHat), expMod(verifier.T, r, nHat), nHat)
+	if hasNil(res.P, res.Q, res.A, res.B, res.T) {
+		return nil, fmt.Errorf("%w: bad ring-Pedersen parameters", ErrInvalidProof)
+	}
+	e := facChallenge(aux, verifier, n0, res)
+	// sigmaHat = sigma - nu*p
+	sigmaHat := new(big.Int).Sub(sigma, new(big.Int).Mul(nu, key.p))
+	res.Z1 = affine(alpha, e, key.p)
+	res.Z2 = affine(beta, e, key.q)
+	res.W1 = affine(x, e, mu)
+	res.W2 = affine(y, e, nu)
+	res.V = affine(r, e, sigmaHat)
+	return res, nil
+}
+
+// verify checks that the proof is valid for the modulus n0
+func (p *facProof) verify(aux []byte, verifier pedersenParams, n0 *big.Int) error {
+	if p == nil || hasNil(p.P, p.Q, p.A, p.B, p.T, p.Sigma, p.Z1, p.Z2, p.W1, p.W2, p.V) {
+		return fmt.Errorf("%w: bad fac proof", ErrInvalidProof)
+	}
+	nHat := verifier.N
+	bound := new(big.Int).Mul(pow2(rangeBits+slackBits), new(big.Int).Sqrt(n0))
+	if !inRange(p.Z1, bound) || !inRange(p.Z2, bound) {
+		return fmt.Errorf("%w: fac proof out of range", ErrInvalidProof)
+	}
+	for _, value := range []*big.Int{p.P, p.Q, p.A, p.B, p.T} {
+		if !isUnit(value, nHat) {
+			return fmt.Errorf("%w: fac proof, commitment out of range", ErrInvalidProof)
+		}
+	}
+	e := facChallenge(aux, verifier, n0, p)
+	bigR := verifier.commit(n0, p.Sigma)
+	if !equal(verifier.commit(p.Z1, p.W1), mulMod(p.A, expMod(p.P, e, nHat), nHat)) ||
+		!equal(verifier.commit(p.Z2, p.W2), mulMod(p.B, expMod(p.Q, e, nHat), nHat)) ||
+		!equal(mulMod(expMod(p.Q, p.Z1, nHat), expMod(verifier.T, p.V, nHat), nHat),
+			mulMod(p.T, expMod(bigR, e, nHat), nHat)) {
+		return fmt.Errorf("%w: fac proof", ErrInvalidProof)
+	}
+	return nil
+}
+
+func facChallenge(aux []byte, verifier pedersenParams, n0 *big.Int, p *facProof) *big.Int {
+	return challenge("tss/fac", aux, n0, verifier.N, verifier.S, verifier.T, p.P, p.Q, p.A, p.B, p.T, p.Sigma)
+}
+
+// affine returns a + e*b
+func affine(a, e, b *big.Int) *big.Int {
+	res := new(big.Int).Mul(e, b)
+	return res.Add(res, a)
+}
@@ -0,0 +1,250 @@
+package tss
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// encProof (Π-enc) proves that the ciphertext C of the prover's key encrypts k in
+// ±2^(rangeBits+slackBits). It uses the ring-Pedersen parameters of the verifier
+type encProof struct {
+	S  *big.Int `json:"s"`
+	A  *big.Int `json:"a"`
+	C  *big.Int `json:"c"`
+	Z1 *big.Int `json:"z1"`
+	Z2 *big.Int `json:"z2"`
+	Z3 *big.Int `json:"z3"`
+}
+
+// proveEnc proves that c = Enc(k; rho) with key
+func proveEnc(aux []byte, verifier pedersenParams, key paillierPublicKey, c, k, rho *big.Int) (*encProof, error) {
+	randoms, err := randomSymmetrics(pow2(rangeBits+slackBits),
+		new(big.Int).Mul(pow2(rangeBits), verifier.N), new(big.Int).Mul(pow2(rangeBits+slackBits), verifier.N))
+	if err != nil {
+		return nil, err
+	}
+	alpha, mu, gamma := randoms[0], randoms[1], randoms[2]
+	r, err := randomUnit(key.N)
+	if err != nil {
+		return nil, err
+	}
+	res := &encProof{
+		S: verifier.commit(k, mu),
+		A: key.encryptWithNonce(alpha, r),
+		C: verifier.commit(alpha, gamma),
+	}
+	if hasNil(res.S, res.C) {
+		return nil, fmt.Errorf("%w: bad ring-Pedersen parameters", ErrInvalidProof)
+	}
+	e := challenge("tss/enc", aux, key.N, c, verifier.N, verifier.S, verifier.T, res.S, res.A, res.C)
+	res.Z1 = affine(alpha, e, k)
+	res.Z2 = mulMod(r, new(big.Int).Exp(rho, e, key.N), key.N)
+	res.Z3 = affine(gamma, e, mu)
+	return res, nil
+}
+
+// verify checks that the proof is valid for the ciphertext c of key
+func (p *encProof) verify(aux []byte, verifier pedersenParams, key paillierPublicKey, c *big.Int) error {
+	if p == nil || hasNil(p.S, p.A, p.C, p.Z1, p.Z2, p.Z3) {
+		return fmt.Errorf("%w: bad enc proof", ErrInvalidProof)
+	}
+	if !inRange(p.Z1, pow2(rangeBits+slackBits)) || !isUnit(p.Z2, key.N) {
+		return fmt.Errorf("%w: enc proof out of range", ErrInvalidProof)
+	}
+	e := challenge("tss/enc", aux, key.N, c, verifier.N, verifier.S, verifier.T, p.S, p.A, p.C)
+	if !equal(key.encryptWithNonce(p.Z1, p.Z2), key.add(p.A, key.mul(c, e))) ||
+		!equal(verifier.commit(p.Z1, p.Z3), mulMod(p.C, expMod(p.S, e, verifier.N), verifier.N)) {
+		return fmt.Errorf("%w: enc proof", ErrInvalidProof)
+	}
+	return nil
+}
+
+// logStarStatement is the statement of a logStarProof: C = Enc(x) with key and X = x*Base
+type logStarStatement struct {
+	key  paillierPublicKey
+	c    *big.Int
+	base point
+	x    point
+}
+
+func (s logStarStatement) challenge(aux []byte, verifier pedersenParams, values ...*big.Int) *big.Int {
+	values = append([]*big.Int{s.key.N, s.c, pointInt(s.base), pointInt(s.x), verifier.N, verifier.S,
+		verifier.T}, values...)
+	return challenge("tss/logstar", aux, values...)
+}
+
+// logStarProof (Π-log*) proves that the ciphertext C of the prover's key encrypts the discrete
+// log x of X in base Base, with x in ±2^(rangeBits+slackBits). It uses the ring-Pedersen
+// parameters of the verifier
+type logStarProof struct {
+	S  *big.Int      `json:"s"`
+	A  *big.Int      `json:"a"`
+	Y  hexutil.Bytes `json:"y"`
+	D  *big.Int      `json:"d"`
+	Z1 *big.Int      `json:"z1"`
+	Z2 *big.Int      `json:"z2"`
+	Z3 *big.Int      `json:"z3"`
+}
+
+// proveLogStar proves statement with c = Enc(x; rho)
+func proveLogStar(aux []byte, verifier pedersenParams, statement logStarStatement, x, rho *big.Int) (*logStarProof,
+	error) {
+	randoms, err := randomSymmetrics(pow2(rangeBits+slackBits),
+		new(big.Int).Mul(pow2(rangeBits), verifier.N), new(big.Int).Mul(pow2(rangeBits+slackBits), verifier.N))
+	if err != nil {
+		return nil, err
+	}
+	alpha, mu, gamma := randoms[0], randoms[1], randoms[2]
+	key := statement.key
+	r, err := randomUnit(key.N)
+	if err != nil {
+		return nil, err
+	}
+	y := statement.base.mul(alpha)
+	res := &logStarProof{
+		S: verifier.commit(x, mu),
+		A: key.encryptWithNonce(alpha, r),
+		Y: y.bytes(),
+		D: verifier.commit(alpha, gamma),
+	}
+	if hasNil(res.S, res.D) {
+		return nil, fmt.Errorf("%w: bad ring-Pedersen parameters", ErrInvalidProof)
+	}
+	e := statement.challenge(aux, verifier, res.S, res.A, pointInt(y), res.D)
+	res.Z1 = affine(alpha, e, x)
+	res.Z2 = mulMod(r, new(big.Int).Exp(rho, e, key.N), key.N)
+	res.Z3 = affine(gamma, e, mu)
+	return res, nil
+}
+
+// verify checks that the proof is valid for statement
+func (p *logStarProof) verify(aux []byte, verifier pedersenParams, statement logStarStatement) error {
+	if p == nil || hasNil(p.S, p.A, p.D, p.Z1, p.Z2, p.Z3) {
+		return fmt.Errorf("%w: bad log* proof", ErrInvalidProof)
+	}
+	key := statement.key
+	if !inRange(p.Z1, pow2(rangeBits+slackBits)) || !isUnit(p.Z2, key.N) {
+		return fmt.Errorf("%w: log* proof out of range", ErrInvalidProof)
+	}
+	y, err := decodePoint(p.Y)
+	if err != nil {
+		return fmt.Errorf("%w: log* proof. Err: %w", ErrInvalidProof, err)
+	}
+	e := statement.challenge(aux, verifier, p.S, p.A, pointInt(y), p.D)
+	if !equal(key.encryptWithNonce(p.Z1, p.Z2), key.add(p.A, key.mul(statement.c, e))) ||
+		!statement.base.mul(p.Z1).equal(y.add(statement.x.mul(e))) ||
+		!equal(verifier.commit(p.Z1, p.Z3), mulMod(p.D, expMod(p.S, e, verifier.N), verifier.N)) {
+		return fmt.Errorf("%w: log* proof", ErrInvalidProof)
+	}
+	return nil
+}
+
+// affgStatement is the statement of an affgProof: D = C^x * Enc0(y) with verifierKey (the key of C),
+// Y = Enc1(y) with proverKey and X = x*G
+type affgStatement struct {
+	verifierKey paillierPublicKey
+	proverKey   paillierPublicKey
+	c, d, y     *big.Int
+	x           point
+}
+
+func (s affgStatement) challenge(aux []byte, verifier pedersenParams, values ...*big.Int) *big.Int {
+	values = append([]*big.Int{s.verifierKey.N, s.proverKey.N, s.c, s.d, s.y, pointInt(s.x), verifier.N,
+		verifier.S, verifier.T}, values...)
+	return challenge("tss/affg", aux, values...)
+}
+
+// affgProof (Π-aff-g) proves that the MtA answer D is C^x * Enc0(y) with x the discrete log of X
+// in ±2^(rangeBits+slackBits) and y the plaintext of Y in ±2^(maskBits+slackBits). It uses the
+// ring-Pedersen parameters of the verifier
+type affgProof struct {
+	A  *big.Int      `json:"a"`
+	Bx hexutil.Bytes `json:"bx"`
+	By *big.Int      `json:"by"`
+	E  *big.Int      `json:"e"`
+	S  *big.Int      `json:"s"`
+	F  *big.Int      `json:"f"`
+	T  *big.Int      `json:"t"`
+	Z1 *big.Int      `json:"z1"`
+	Z2 *big.Int      `json:"z2"`
+	Z3 *big.Int      `json:"z3"`
+	Z4 *big.Int      `json:"z4"`
+	W  *big.Int      `json:"w"`
+	Wy *big.Int      `json:"wy"`
+}
+
+// proveAffg proves statement with D = C^x * Enc0(y; rho) and Y = Enc1(y; rhoY)
+func proveAffg(aux []byte, verifier pedersenParams, statement affgStatement, x, y, rho, rhoY *big.Int) (*affgProof,
+	error) {
+	secretBound := new(big.Int).Mul(pow2(rangeBits), verifier.N)
+	maskBound := new(big.Int).Mul(pow2(rangeBits+slackBits), verifier.N)
+	randoms, err := randomSymmetrics(pow2(rangeBits+slackBits), pow2(maskBits+slackBits),
+		maskBound, secretBound, maskBound, secretBound)
+	if err != nil {
+		return nil, err
+	}
+	alpha, beta, gamma, m, delta, mu := randoms[0], randoms[1], randoms[2], randoms[3], randoms[4], randoms[5]
+	key0, key1 := statement.verifierKey, statement.proverKey
+	r, err := randomUnit(key0.N)
+	if err != nil {
+		return nil, err
+	}
+	rY, err := randomUnit(key1.N)
+	if err != nil {
+		return nil, err
+	}
+	bx := scalarBaseMult(alpha)
+	res := &affgProof{
+		A:  mulMod(key0.mul(statement.c, alpha), key0.encryptWithNonce(beta, r), key0.NSquared),
+		Bx: bx.bytes(),
+		By: key1.encryptWithNonce(beta, rY),
+		E:  verifier.commit(alpha, gamma),
+		S:  verifier.commit(x, m),
+		F:  verifier.commit(beta, delta),
+		T:  verifier.commit(y, mu),
+	}
+	if hasNil(res.A, res.E, res.S, res.F, res.T) {
+		return nil, fmt.Errorf("%w: bad ciphertext or ring-Pedersen parameters", ErrInvalidProof)
+	}
+	e := statement.challenge(aux, verifier, res.A, pointInt(bx), res.By, res.E, res.S, res.F, res.T)
+	res.Z1 = affine(alpha, e, x)
+	res.Z2 = affine(beta, e, y)
+	res.Z3 = affine(gamma, e, m)
+	res.Z4 = affine(delta, e, mu)
+	res.W = mulMod(r, new(big.Int).Exp(rho, e, key0.N), key0.N)
+	res.Wy = mulMod(rY, new(big.Int).Exp(rhoY, e, key1.N), key1.N)
+	return res, nil
+}
+
+// verify checks that the proof is valid for statement
+func (p *affgProof) verify(aux []byte, verifier pedersenParams, statement affgStatement) error {
+	if p == nil || hasNil(p.A, p.By, p.E, p.S, p.F, p.T, p.Z1, p.Z2, p.Z3, p.Z4, p.W, p.Wy) {
+		return fmt.Errorf("%w: bad aff-g proof", ErrInvalidProof)
+	}
+	key0, key1 := statement.verifierKey, statement.proverKey
+	if !inRange(p.Z1, pow2(rangeBits+slackBits)) || !inRange(p.Z2, pow2(maskBits+slackBits)) ||
+		!isUnit(p.W, key0.N) || !isUnit(p.Wy, key1.N) {
+		return fmt.Errorf("%w: aff-g proof out of range", ErrInvalidProof)
+	}
+	bx, err := decodePoint(p.Bx)
+	if err != nil {
+		return fmt.Errorf("%w: aff-g proof. Err: %w", ErrInvalidProof, err)
+	}
+	e := statement.challenge(aux, verifier, p.A, pointInt(bx), p.By, p.E, p.S, p.F, p.T)
+	lhs0 := mulMod(key0.mul(statement.c, p.Z1), key0.encryptWithNonce(p.Z2, p.W), key0.NSquared)
+	if !equal(lhs0, key0.add(p.A, key0.mul(statement.d, e))) ||
+		!scalarBaseMult(p.Z1).equal(bx.add(statement.x.mul(e))) ||
+		!equal(key1.encryptWithNonce(p.Z2, p.Wy), key1.add(p.By, key1.mul(statement.y, e))) ||
+		!equal(verifier.commit(p.Z1, p.Z3), mulMod(p.E, expMod(p.S, e, verifier.N), verifier.N)) ||
+		!equal(verifier.commit(p.Z2, p.Z4), mulMod(p.F, expMod(p.T, e, verifier.N), verifier.N)) {
+		return fmt.Errorf("%w: aff-g proof", ErrInvalidProof)
+	}
+	return nil
+}
+
+// pointInt returns the compressed encoding of p as a number, to hash it
+func pointInt(p point) *big.Int {
+	return new(big.Int).SetBytes(p.bytes())
+}
@@ -0,0 +1,141 @@
+package tss
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/agglayer/go_signer/signer/shamir"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModPrmProofs(t *testing.T) {
+	keys, other := testPartyKeys(t, 1), testPartyKeys(t, 2)
+	require.NoError(t, keys.payload.ModProof.verify(keys.paillier.N))
+	require.NoError(t, keys.payload.PrmProof.verify(keys.pedersen))
+
+	require.ErrorIs(t, keys.payload.ModProof.verify(other.paillier.N), ErrInvalidProof)
+	require.ErrorIs(t, keys.payload.PrmProof.verify(other.pedersen), ErrInvalidProof)
+	// A prime modulus is not a Paillier-Blum modulus
+	require.ErrorIs(t, keys.payload.ModProof.verify(keys.paillier.p), ErrInvalidProof)
+	require.ErrorIs(t, (*modProof)(nil).verify(keys.paillier.N), ErrInvalidProof)
+
+	// s is not generated by t
+	params := keys.pedersen
+	params.S = new(big.Int).Add(params.S, one)
+	require.ErrorIs(t, keys.payload.PrmProof.verify(params), ErrInvalidProof)
+}
+
+func TestFacProof(t *testing.T) {
+	prover, verifier := testPartyKeys(t, 1), testPartyKeys(t, 2)
+	aux := proofAux("session", 1, 2)
+	proof, err := proveFac(aux, verifier.pedersen, prover.paillier)
+	require.NoError(t, err)
+	require.NoError(t, proof.verify(aux, verifier.pedersen, prover.paillier.N))
+
+	require.ErrorIs(t, proof.verify(proofAux("other", 1, 2), verifier.pedersen, prover.paillier.N), ErrInvalidProof)
+	require.ErrorIs(t, proof.verify(aux, verifier.pedersen, testPartyKeys(t, 3).paillier.N), ErrInvalidProof)
+
+	// A modulus with a small factor can't be proved
+	small := *prover.paillier
+	small.p = big.NewInt(3)
+	small.q = new(big.Int).Div(small.N, small.p)
+	small.N = new(big.Int).Mul(small.p, small.q)
+	proof, err = proveFac(aux, verifier.pedersen, &small)
+	require.NoError(t, err)
+	require.ErrorIs(t, proof.verify(aux, verifier.pedersen, small.N), ErrInvalidProof)
+}
+
+func TestEncProof(t *testing.T) {
+	prover, verifier := testPartyKeys(t, 1), testPartyKeys(t, 2)
+	key := prover.paillier.paillierPublicKey
+	aux := proofAux("session", 1, 2)
+	k, err := shamir.RandomScalar()
+	require.NoError(t, err)
+	c, rho, err := key.encrypt(k)
+	require.NoError(t, err)
+	proof, err := proveEnc(aux, verifier.pedersen, key, c, k, rho)
+	require.NoError(t, err)
+	require.NoError(t, proof.verify(aux, verifier.pedersen, key, c))
+
+	other, _, err := key.encrypt(k)
+	require.NoError(t, err)
+	require.ErrorIs(t, proof.verify(aux, verifier.pedersen, key, other), ErrInvalidProof)
+	require.ErrorIs(t, proof.verify(proofAux("session", 1, 3), verifier.pedersen, key, c), ErrInvalidProof)
+
+	// k out of range
+	k = pow2(rangeBits + 2*slackBits)
+	c, rho, err = key.encrypt(k)
+	require.NoError(t, err)
+	proof, err = proveEnc(aux, verifier.pedersen, key, c, k, rho)
+	require.NoError(t, err)
+	require.ErrorIs(t, proof.verify(aux, verifier.pedersen, key, c), ErrInvalidProof)
+}
+
+func TestLogStarProof(t *testing.T) {
+	prover, verifier := testPartyKeys(t, 1), testPartyKeys(t, 2)
+	key := prover.paillier.paillierPublicKey
+	aux := proofAux("session", 1, 2)
+	x, err := shamir.RandomScalar()
+	require.NoError(t, err)
+	c, rho, err := key.encrypt(x)
+	require.NoError(t, err)
+	base := scalarBaseMult(big.NewInt(7))
+	statement := logStarStatement{key: key, c: c, base: base, x: base.mul(x)}
+	proof, err := proveLogStar(aux, verifier.pedersen, statement, x, rho)
+	require.NoError(t, err)
+	require.NoError(t, proof.verify(aux, verifier.pedersen, statement))
+
+	// X is not x*Base
+	statement.x = generator().mul(x)
+	require.ErrorIs(t, proof.verify(aux, verifier.pedersen, statement), ErrInvalidProof)
+	proof, err = proveLogStar(aux, verifier.pedersen, statement, x, rho)
+	require.NoError(t, err)
+	require.ErrorIs(t, proof.verify(aux, verifier.pedersen, statement), ErrInvalidProof)
+}
+
+func TestAffgProof(t *testing.T) {
+	prover, verifier := testPartyKeys(t, 1), testPartyKeys(t, 2)
+	key0, key1 := verifier.paillier.paillierPublicKey, prover.paillier.paillierPublicKey
+	aux := proofAux("session", 1, 2)
+	k, err := shamir.RandomScalar()
+	require.NoError(t, err)
+	encK, _, err := key0.encrypt(k)
+	require.NoError(t, err)
+	x, err := shamir.RandomScalar()
+	require.NoError(t, err)
+
+	newStatement := func(x, y *big.Int) (affgStatement, *big.Int, *big.Int) {
+		encY, rho, err := key0.encrypt(y)
+		require.NoError(t, err)
+		f, rhoY, err := key1.encrypt(y)
+		require.NoError(t, err)
+		return affgStatement{
+			verifierKey: key0,
+			proverKey:   key1,
+			c:           encK,
+			d:           key0.add(key0.mul(encK, x), encY),
+			y:           f,
+			x:           scalarBaseMult(x),
+		}, rho, rhoY
+	}
+	y := pow2(maskBits - 1)
+	statement, rho, rhoY := newStatement(x, y)
+	proof, err := proveAffg(aux, verifier.pedersen, statement, x, y, rho, rhoY)
+	require.NoError(t, err)
+	require.NoError(t, proof.verify(aux, verifier.pedersen, statement))
+	plaintext, err := verifier.paillier.decryptSigned(statement.d)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Add(new(big.Int).Mul(k, x), y), plaintext)
+
+	// X is not x*G
+	wrong := statement
+	wrong.x = scalarBaseMult(k)
+	require.ErrorIs(t, proof.verify(aux, verifier.pedersen, wrong), ErrInvalidProof)
+
+	// The mask is too big: it could reveal k with a wrap around N
+	y = pow2(maskBits + 2*slackBits)
+	statement, rho, rhoY = newStatement(x, y)
+	proof, err = proveAffg(aux, verifier.pedersen, statement, x, y, rho, rhoY)
+	require.NoError(t, err)
+	require.ErrorIs(t, proof.verify(aux, verifier.pedersen, statement), ErrInvalidProof)
+}
@@ -0,0 +1,540 @@
+package tss
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/agglayer/go_signer/signer/shamir"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Rounds of a session. The coordinator proposes the hash to all the parties, picks the
+// first threshold-1 that accept and starts the signing rounds with them. The proofs sent to a
+// party use its ring-Pedersen parameters and are bound to the session, the prover and the verifier
+const (
+	roundPropose = iota + 1
+	roundAccept
+	roundStart
+	// roundKeys: each party sends its Paillier key and ring-Pedersen parameters with their proofs
+	roundKeys
+	// roundCommit: each party sends K_i = Enc(k_i) and G_i = Enc(gamma_i) with the range proof of
+	// K_i and the proof that its Paillier key has no small factors
+	roundCommit
+	// roundMtA: each party sends Gamma_i = gamma_i*G with the proof that G_i encrypts its discrete
+	// log, and answers the MtA of k_j*gamma_i and k_j*w_i to each party j with their proofs
+	roundMtA
+	// roundDelta: each party sends delta_i, its share of k*gamma, and Delta_i = k_i*Gamma with the
+	// proof that K_i encrypts its discrete log
+	roundDelta
+	// roundSignature: each party sends s_i, its share of s
+	roundSignature
+	rounds = roundSignature
+)
+
+type proposalPayload struct {
+	Hash common.Hash `json:"hash"`
+}
+
+type acceptPayload struct {
+	// Error is the reason of a rejection, empty if it's accepted
+	Error string `json:"error,omitempty"`
+}
+
+type startPayload struct {
+	Signers []int `json:"signers"`
+}
+
+// commitPayload has the Paillier numbers as bytes, they are bigger than hexutil.Big allows
+type commitPayload struct {
+	EncK     hexutil.Bytes `json:"encK"`
+	EncGamma hexutil.Bytes `json:"encGamma"`
+	EncProof *encProof     `json:"encProof"`
+	FacProof *facProof     `json:"facProof"`
+}
+
+// mtaAnswer is the answer to the MtA of k_j*x_i: D = K_j^x_i * Enc_j(beta) and F = Enc_i(beta)
+type mtaAnswer struct {
+	D     hexutil.Bytes `json:"d"`
+	F     hexutil.Bytes `json:"f"`
+	Proof *affgProof    `json:"proof"`
+}
+
+type mtaPayload struct {
+	Gamma      hexutil.Bytes `json:"gamma"`
+	GammaProof *logStarProof `json:"gammaProof"`
+	// AnswerGamma is the MtA of k_j*gamma_i and AnswerW the one of k_j*w_i
+	AnswerGamma mtaAnswer `json:"answerGamma"`
+	AnswerW     mtaAnswer `json:"answerW"`
+}
+
+type deltaPayload struct {
+	Delta      *hexutil.Big  `json:"delta"`
+	BigDelta   hexutil.Bytes `json:"bigDelta"`
+	DeltaProof *logStarProof `json:"deltaProof"`
+}
+
+type scalarPayload struct {
+	Value *hexutil.Big `json:"value"`
+}
+
+// commit is the decoded commitPayload of a party
+type commit struct {
+	encK     *big.Int
+	encGamma *big.Int
+}
+
+// nonce is the secret nonce of a signing session: k_i and gamma_i with their encryptions
+type nonce struct {
+	k, gamma       *big.Int
+	encK, encGamma *big.Int
+	rhoK, rhoGamma *big.Int
+	gammaPoint     point
+}
+
+// signSession runs the signing rounds with signers (all of them must take part). The nonce is
+// k^-1 with k = sum(k_i), so R = (k*gamma)^-1 * Gamma and s = k*(m + r*x) = sum(m*k_i + r*sigma_i)
+// where sigma_i are the additive shares of k*x computed by MtA. It's the presigning of CGGMP21:
+// each message has the proofs that make the MtA safe against malicious parties
+func (p *Party) signSession(ctx context.Context, s *session, signers []int, hash common.Hash) ([]byte, error) {
+	q := shamir.Order
+	others, err := p.checkSigners(signers)
+	if err != nil {
+		return nil, err
+	}
+	// w is the additive share of x for this set of signers
+	lambda, err := shamir.LagrangeCoefficient(p.Index(), signers)
+	if err != nil {
+		return nil, err
+	}
+	w := new(big.Int).Mul(lambda, p.share.Share)
+	w.Mod(w, q)
+
+	// roundKeys
+	if err := p.broadcast(ctx, s.id, roundKeys, others, p.keys.payload); err != nil {
+		return nil, err
+	}
+	peers, err := p.collectKeys(ctx, s, others)
+	if err != nil {
+		return nil, err
+	}
+
+	// roundCommit
+	own, err := p.newNonce()
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range others {
+		payload, err := p.commitPayload(s.id, j, peers[j], own)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.send(ctx, s.id, roundCommit, j, payload); err != nil {
+			return nil, err
+		}
+	}
+	commits, err := p.collectCommits(ctx, s, others, peers)
+	if err != nil {
+		return nil, err
+	}
+
+	// roundMtA: delta_i and sigma_i start with the local products, the answers add the shares
+	delta := new(big.Int).Mul(own.k, own.gamma)
+	sigma := new(big.Int).Mul(own.k, w)
+	for _, j := range others {
+		payload, betaGamma, betaW, err := p.mtaPayload(s.id, j, peers[j], commits[j], own, w)
+		if err != nil {
+			return nil, err
+		}
+		delta.Add(delta, betaGamma)
+		sigma.Add(sigma, betaW)
+		if err := p.send(ctx, s.id, roundMtA, j, payload); err != nil {
+			return nil, err
+		}
+	}
+	gammas, alphas, mus, err := p.collectMtA(ctx, s, signers, peers, commits, own)
+	if err != nil {
+		return nil, err
+	}
+	totalGamma := own.gammaPoint
+	for _, j := range others {
+		delta.Add(delta, alphas[j])
+		sigma.Add(sigma, mus[j])
+		totalGamma = totalGamma.add(gammas[j])
+	}
+	delta.Mod(delta, q)
+	sigma.Mod(sigma, q)
+
+	// roundDelta: R = (sum(delta_i))^-1 * Gamma = k^-1 * G, checking that delta*G = sum(Delta_i)
+	deltas, err := p.exchangeDelta(ctx, s, others, peers, commits, own, totalGamma, delta)
+	if err != nil {
+		return nil, err
+	}
+	bigR := totalGamma.mul(new(big.Int).ModInverse(deltas, q))
+	if bigR.isInfinity() {
+		return nil, fmt.Errorf("%s %w: R is the point at infinity", p.logPrefix(), ErrBadMessage)
+	}
+	r := new(big.Int).Mod(bigR.X, q)
+
+	// roundSignature: s_i = m*k_i + r*sigma_i
+	m := new(big.Int).SetBytes(hash.Bytes())
+	sI := new(big.Int).Mul(m, own.k)
+	sI.Add(sI, new(big.Int).Mul(r, sigma))
+	sI.Mod(sI, q)
+	sTotal, err := p.exchangeScalar(ctx, s, roundSignature, others, sI)
+	if err != nil {
+		return nil, err
+	}
+	return p.signature(hash, bigR, r, sTotal)
+}
+
+// newNonce generates k_i and gamma_i and encrypts them with the Paillier key of the party
+func (p *Party) newNonce() (*nonce, error) {
+	k, err := shamir.RandomScalar()
+	if err != nil {
+		return nil, err
+	}
+	gamma, err := shamir.RandomScalar()
+	if err != nil {
+		return nil, err
+	}
+	res := &nonce{k: k, gamma: gamma, gammaPoint: scalarBaseMult(gamma)}
+	if res.encK, res.rhoK, err = p.keys.paillier.encrypt(k); err != nil {
+		return nil, err
+	}
+	if res.encGamma, res.rhoGamma, err = p.keys.paillier.encrypt(gamma); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// signature encodes (r, s) as [R || S || V] with low S, and checks that it recovers the address
+func (p *Party) signature(hash common.Hash, bigR point, r, s *big.Int) ([]byte, error) {
+	q := shamir.Order
+	if r.Sign() == 0 || s.Sign() == 0 || bigR.X.Cmp(q) >= 0 {
+		// R.X >= q has no V on Ethereum (probability ~2^-128)
+		return nil, fmt.Errorf("%s %w: r or s out of range", p.logPrefix(), ErrInvalidSignature)
+	}
+	v := byte(bigR.Y.Bit(0))
+	if s.Cmp(new(big.Int).Rsh(q, 1)) > 0 {
+		s = new(big.Int).Sub(q, s)
+		v ^= 1
+	}
+	res := make([]byte, crypto.SignatureLength)
+	r.FillBytes(res[:32])
+	s.FillBytes(res[32:64])
+	res[crypto.RecoveryIDOffset] = v
+	pub, err := crypto.SigToPub(hash.Bytes(), res)
+	if err != nil || crypto.PubkeyToAddress(*pub) != p.Address() {
+		return nil, fmt.Errorf("%s %w", p.logPrefix(), ErrInvalidSignature)
+	}
+	return res, nil
+}
+
+// checkSigners checks that signers are threshold different parties, including this one,
+// and returns the other ones
+func (p *Party) checkSigners(signers []int) ([]int, error) {
+	if len(signers) != p.Threshold() {
+		return nil, fmt.Errorf("%s %w: %d signers, threshold %d", p.logPrefix(), ErrBadMessage,
+			len(signers), p.Threshold())
+	}
+	seen := make(map[int]bool, len(signers))
+	others := make([]int, 0, len(signers)-1)
+	for _, index := range signers {
+		if index < 1 || index > p.share.Parties() || seen[index] {
+			return nil, fmt.Errorf("%s %w: signer %d", p.logPrefix(), ErrBadMessage, index)
+		}
+		seen[index] = true
+		if index != p.Index() {
+			others = append(others, index)
+		}
+	}
+	if !seen[p.Index()] {
+		return nil, fmt.Errorf("%s %w: party is not a signer", p.logPrefix(), ErrBadMessage)
+	}
+	return others, nil
+}
+
+// collectKeys receives and verifies the keys of the other signers
+func (p *Party) collectKeys(ctx context.Context, s *session, others []int) (map[int]peerKeys, error) {
+	raws, err := s.collect(ctx, roundKeys, others)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int]peerKeys, len(raws))
+	for j, raw := range raws {
+		var payload keysPayload
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return nil, fmt.Errorf("%s party %d round %d: %w", p.logPrefix(), j, roundKeys, ErrBadMessage)
+		}
+		keys, err := verifyKeys(payload)
+		if err != nil {
+			return nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		res[j] = keys
+	}
+	return res, nil
+}
+
+// commitPayload returns the commit for party j, with the proofs for its ring-Pedersen parameters
+func (p *Party) commitPayload(session string, j int, peer peerKeys, own *nonce) (commitPayload, error) {
+	aux := proofAux(session, p.Index(), j)
+	enc, err := proveEnc(aux, peer.pedersen, p.keys.paillier.paillierPublicKey, own.encK, own.k, own.rhoK)
+	if err != nil {
+		return commitPayload{}, err
+	}
+	fac, err := proveFac(aux, peer.pedersen, p.keys.paillier)
+	if err != nil {
+		return commitPayload{}, err
+	}
+	return commitPayload{EncK: own.encK.Bytes(), EncGamma: own.encGamma.Bytes(), EncProof: enc, FacProof: fac}, nil
+}
+
+// collectCommits receives the commits of the other signers and verifies their proofs
+func (p *Party) collectCommits(ctx context.Context, s *session, others []int,
+	peers map[int]peerKeys) (map[int]commit, error) {
+	raws, err := s.collect(ctx, roundCommit, others)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int]commit, len(raws))
+	for j, raw := range raws {
+		var payload commitPayload
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return nil, fmt.Errorf("%s party %d round %d: %w", p.logPrefix(), j, roundCommit, ErrBadMessage)
+		}
+		c := commit{encK: new(big.Int).SetBytes(payload.EncK), encGamma: new(big.Int).SetBytes(payload.EncGamma)}
+		key := peers[j].paillier
+		aux := proofAux(s.id, j, p.Index())
+		if err := errors.Join(key.checkCiphertext(c.encK), key.checkCiphertext(c.encGamma)); err != nil {
+			return nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		if err := payload.EncProof.verify(aux, p.keys.pedersen, key, c.encK); err != nil {
+			return nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		if err := payload.FacProof.verify(aux, p.keys.pedersen, key.N); err != nil {
+			return nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		res[j] = c
+	}
+	return res, nil
+}
+
+// mtaPayload returns Gamma_i and the answers to the MtA of party j with their proofs. The shares of
+// this party are -beta (mod q) of each answer
+func (p *Party) mtaPayload(session string, j int, peer peerKeys, c commit, own *nonce,
+	w *big.Int) (mtaPayload, *big.Int, *big.Int, error) {
+	aux := proofAux(session, p.Index(), j)
+	gammaProof, err := proveLogStar(aux, peer.pedersen, logStarStatement{
+		key:  p.keys.paillier.paillierPublicKey,
+		c:    own.encGamma,
+		base: generator(),
+		x:    own.gammaPoint,
+	}, own.gamma, own.rhoGamma)
+	if err != nil {
+		return mtaPayload{}, nil, nil, err
+	}
+	answerGamma, betaGamma, err := p.mta(aux, peer, c.encK, own.gamma)
+	if err != nil {
+		return mtaPayload{}, nil, nil, err
+	}
+	answerW, betaW, err := p.mta(aux, peer, c.encK, w)
+	if err != nil {
+		return mtaPayload{}, nil, nil, err
+	}
+	return mtaPayload{
+		Gamma:       own.gammaPoint.bytes(),
+		GammaProof:  gammaProof,
+		AnswerGamma: answerGamma,
+		AnswerW:     answerW,
+	}, betaGamma, betaW, nil
+}
+
+// mta answers the MtA of k_j*x with encK = Enc_j(k_j): D = Enc_j(k_j*x + beta), F = Enc_i(beta) and
+// the proof that they are well formed. It returns the answer and -beta mod q
+func (p *Party) mta(aux []byte, peer peerKeys, encK, x *big.Int) (mtaAnswer, *big.Int, error) {
+	beta, err := rand.Int(rand.Reader, pow2(maskBits))
+	if err != nil {
+		return mtaAnswer{}, nil, fmt.Errorf("tss: can't generate random number. Err: %w", err)
+	}
+	encBeta, rho, err := peer.paillier.encrypt(beta)
+	if err != nil {
+		return mtaAnswer{}, nil, err
+	}
+	own := p.keys.paillier.paillierPublicKey
+	f, rhoY, err := own.encrypt(beta)
+	if err != nil {
+		return mtaAnswer{}, nil, err
+	}
+	statement := affgStatement{
+		verifierKey: peer.paillier,
+		proverKey:   own,
+		c:           encK,
+		d:           peer.paillier.add(peer.paillier.mul(encK, x), encBeta),
+		y:           f,
+		x:           scalarBaseMult(x),
+	}
+	proof, err := proveAffg(aux, peer.pedersen, statement, x, beta, rho, rhoY)
+	if err != nil {
+		return mtaAnswer{}, nil, err
+	}
+	share := new(big.Int).Neg(beta)
+	return mtaAnswer{D: statement.d.Bytes(), F: f.Bytes(), Proof: proof}, share.Mod(share, shamir.Order), nil
+}
+
+// collectMtA receives the MtA answers of the other signers and verifies them. It returns Gamma_j
+// and the shares of k_i*gamma_j and k_i*w_j of each one
+func (p *Party) collectMtA(ctx context.Context, s *session, signers []int, peers map[int]peerKeys,
+	commits map[int]commit, own *nonce) (map[int]point, map[int]*big.Int, map[int]*big.Int, error) {
+	others := slices.DeleteFunc(slices.Clone(signers), func(index int) bool { return index == p.Index() })
+	raws, err := s.collect(ctx, roundMtA, others)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	gammas := make(map[int]point, len(raws))
+	alphas := make(map[int]*big.Int, len(raws))
+	mus := make(map[int]*big.Int, len(raws))
+	for j, raw := range raws {
+		var payload mtaPayload
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return nil, nil, nil, fmt.Errorf("%s party %d round %d: %w", p.logPrefix(), j, roundMtA, ErrBadMessage)
+		}
+		gamma, err := decodePoint(payload.Gamma)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		aux := proofAux(s.id, j, p.Index())
+		err = payload.GammaProof.verify(aux, p.keys.pedersen, logStarStatement{
+			key:  peers[j].paillier,
+			c:    commits[j].encGamma,
+			base: generator(),
+			x:    gamma,
+		})
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		// W_j = lambda_j * X_j, the public key of the additive share of party j
+		lambda, err := shamir.LagrangeCoefficient(j, signers)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		publicShare := p.share.PublicShares[j-1]
+		bigW := point{X: publicShare.X, Y: publicShare.Y}.mul(lambda)
+		if alphas[j], err = p.receiveMtA(aux, peers[j], own, gamma, payload.AnswerGamma); err != nil {
+			return nil, nil, nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		if mus[j], err = p.receiveMtA(aux, peers[j], own, bigW, payload.AnswerW); err != nil {
+			return nil, nil, nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		gammas[j] = gamma
+	}
+	return gammas, alphas, mus, nil
+}
+
+// receiveMtA verifies the answer of a party to the MtA of k_i*x_j, with X = x_j*G, and returns
+// its plaintext k_i*x_j + beta
+func (p *Party) receiveMtA(aux []byte, peer peerKeys, own *nonce, x point, answer mtaAnswer) (*big.Int, error) {
+	ownKey := p.keys.paillier.paillierPublicKey
+	statement := affgStatement{
+		verifierKey: ownKey,
+		proverKey:   peer.paillier,
+		c:           own.encK,
+		d:           new(big.Int).SetBytes(answer.D),
+		y:           new(big.Int).SetBytes(answer.F),
+		x:           x,
+	}
+	if err := errors.Join(ownKey.checkCiphertext(statement.d), peer.paillier.checkCiphertext(statement.y)); err != nil {
+		return nil, err
+	}
+	if err := answer.Proof.verify(aux, p.keys.pedersen, statement); err != nil {
+		return nil, err
+	}
+	return p.keys.paillier.decryptSigned(statement.d)
+}
+
+// exchangeDelta sends delta_i and Delta_i = k_i*Gamma to the other signers and returns the sum of
+// the deltas. It fails if a proof is not valid or delta*G != sum(Delta_i) (some party didn't use
+// the same k_i and gamma_i on all the MtA)
+func (p *Party) exchangeDelta(ctx context.Context, s *session, others []int, peers map[int]peerKeys,
+	commits map[int]commit, own *nonce, totalGamma point, delta *big.Int) (*big.Int, error) {
+	ownDelta := totalGamma.mul(own.k)
+	ownStatement := logStarStatement{key: p.keys.paillier.paillierPublicKey, c: own.encK, base: totalGamma, x: ownDelta}
+	for _, j := range others {
+		proof, err := proveLogStar(proofAux(s.id, p.Index(), j), peers[j].pedersen, ownStatement, own.k, own.rhoK)
+		if err != nil {
+			return nil, err
+		}
+		payload := deltaPayload{Delta: (*hexutil.Big)(delta), BigDelta: ownDelta.bytes(), DeltaProof: proof}
+		if err := p.send(ctx, s.id, roundDelta, j, payload); err != nil {
+			return nil, err
+		}
+	}
+	raws, err := s.collect(ctx, roundDelta, others)
+	if err != nil {
+		return nil, err
+	}
+	sum := new(big.Int).Set(delta)
+	sumDelta := ownDelta
+	for j, raw := range raws {
+		var payload deltaPayload
+		if err := json.Unmarshal(raw, &payload); err != nil || payload.Delta == nil ||
+			payload.Delta.ToInt().Sign() < 0 || payload.Delta.ToInt().Cmp(shamir.Order) >= 0 {
+			return nil, fmt.Errorf("%s party %d round %d: %w", p.logPrefix(), j, roundDelta, ErrBadMessage)
+		}
+		bigDelta, err := decodePoint(payload.BigDelta)
+		if err != nil {
+			return nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		statement := logStarStatement{key: peers[j].paillier, c: commits[j].encK, base: totalGamma, x: bigDelta}
+		if err := payload.DeltaProof.verify(proofAux(s.id, j, p.Index()), p.keys.pedersen, statement); err != nil {
+			return nil, fmt.Errorf("%s party %d: %w", p.logPrefix(), j, err)
+		}
+		sum.Add(sum, payload.Delta.ToInt())
+		sumDelta = sumDelta.add(bigDelta)
+	}
+	sum.Mod(sum, shamir.Order)
+	if sum.Sign() == 0 {
+		return nil, fmt.Errorf("%s %w: delta is zero", p.logPrefix(), ErrBadMessage)
+	}
+	if !scalarBaseMult(sum).equal(sumDelta) {
+		return nil, fmt.Errorf("%s %w: delta doesn't match the Delta of the parties", p.logPrefix(), ErrBadMessage)
+	}
+	return sum, nil
+}
+
+// exchangeScalar broadcasts value and returns the sum (mod q) of the values of all the signers
+func (p *Party) exchangeScalar(ctx context.Context, s *session, round int, others []int,
+	value *big.Int) (*big.Int, error) {
+	if err := p.broadcast(ctx, s.id, round, others, scalarPayload{Value: (*hexutil.Big)(value)}); err != nil {
+		return nil, err
+	}
+	raws, err := s.collect(ctx, round, others)
+	if err != nil {
+		return nil, err
+	}
+	sum := new(big.Int).Set(value)
+	for j, raw := range raws {
+		var payload scalarPayload
+		if err := json.Unmarshal(raw, &payload); err != nil || payload.Value == nil ||
+			payload.Value.ToInt().Sign() < 0 || payload.Value.ToInt().Cmp(shamir.Order) >= 0 {
+			return nil, fmt.Errorf("%s party %d round %d: %w", p.logPrefix(), j, round, ErrBadMessage)
+		}
+		sum.Add(sum, payload.Value.ToInt())
+	}
+	return sum.Mod(sum, shamir.Order), nil
+}
+
+func (p *Party) broadcast(ctx context.Context, session string, round int, to []int, payload any) error {
+	for _, index := range to {
+		if err := p.send(ctx, session, round, index, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package tss
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// maxFrameSize is the max size of an encoded message
+	maxFrameSize = 1 << 20
+	// frameHeaderSize is the size of the length prefix of each message
+	frameHeaderSize = 4
+	// dialTimeout is the max time connecting to a party, so a host down doesn't block a session
+	dialTimeout = 5 * time.Second
+)
+
+// ErrFrameTooLarge is returned when a message is bigger than maxFrameSize
+var ErrFrameTooLarge = errors.New("tss: message too large")
+
+// TCPTransport is a Transport over mutual TLS: it listens for the messages of the other parties
+// and dials them to send. Each message is a JSON frame prefixed by its length. Each party is
+// identified by its certificate (see PartyName): the messages of a connection are dropped if
+// From is not the party of the certificate of the peer
+type TCPTransport struct {
+	index     int
+	listener  net.Listener
+	tlsConfig *tls.Config
+	inbox     chan Message
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	mu    sync.Mutex
+	peers map[int]string
+	conns map[int]net.Conn
+
+	// acceptedMu is not mu: Send holds mu while it dials, and two parties dialing each other at
+	// the same time would wait for each other to accept
+	acceptedMu sync.Mutex
+	// accepted are the incoming connections, closed by Close
+	accepted map[net.Conn]struct{}
+}
+
+var _ Transport = (*TCPTransport)(nil)
+
+// ListenTCP creates the transport of party index listening on addr (e.g. 127.0.0.1:0) with the
+// mutual TLS config tlsConfig (see NewTLSConfig)
+func ListenTCP(index int, addr string, tlsConfig *tls.Config) (*TCPTransport, error) {
+	if tlsConfig == nil || len(tlsConfig.Certificates) == 0 ||
+		tlsConfig.RootCAs == nil || tlsConfig.ClientCAs == nil {
+		return nil, ErrNoTLSConfig
+	}
+	if err := checkOwnCertificate(index, tlsConfig.Certificates[0]); err != nil {
+		return nil, err
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	listener, err := tls.Listen("tcp", addr, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("tss: can't listen on %s. Err: %w", addr, err)
+	}
+	res := &TCPTransport{
+		index:     index,
+		listener:  listener,
+		tlsConfig: tlsConfig,
+		inbox:     make(chan Message, memoryQueueSize),
+		closed:    make(chan struct{}),
+		peers:     map[int]string{},
+		conns:     map[int]net.Conn{},
+		accepted:  map[net.Conn]struct{}{},
+	}
+	go res.acceptLoop()
+	return res, nil
+}
+
+// Addr returns the address where the transport listens
+func (t *TCPTransport) Addr() string {
+	return t.listener.Addr().String()
+}
+
+// AddPeer sets the address of party index
+func (t *TCPTransport) AddPeer(index int, addr string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.peers[index] = addr
+	if conn, ok := t.conns[index]; ok {
+		_ = conn.Close()
+		delete(t.conns, index)
+	}
+}
+
+func (t *TCPTransport) Send(ctx context.Context, msg Message) error {
+	msg.From = t.index
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("tss: can't encode message. Err: %w", err)
+	}
+	if len(data) > maxFrameSize {
+		return ErrFrameTooLarge
+	}
+	frame := make([]byte, frameHeaderSize+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	copy(frame[frameHeaderSize:], data)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// A cached connection can be broken (peer restarted), so it's retried once with a new one
+	for attempt := 0; ; attempt++ {
+		conn, err := t.conn(ctx, msg.To)
+		if err != nil {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetWriteDeadline(deadline)
+		}
+		_, err = conn.Write(frame)
+		if err == nil {
+			return nil
+		}
+		_ = conn.Close()
+		delete(t.conns, msg.To)
+		if attempt > 0 {
+			return fmt.Errorf("tss: can't send to party %d. Err: %w", msg.To, err)
+		}
+	}
+}
+
+// conn returns the connection to party index, dialing it if needed. t.mu must be locked
+func (t *TCPTransport) conn(ctx context.Context, index int) (net.Conn, error) {
+	if conn, ok := t.conns[index]; ok {
+		return conn, nil
+	}
+	addr, ok := t.peers[index]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownParty, index)
+	}
+	// The certificate of the peer must be the one of party index
+	config := t.tlsConfig.Clone()
+	config.ServerName = PartyName(index)
+	config.VerifyConnection = expectParty(index)
+	dialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: dialTimeout}, Config: config}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("tss: can't connect to party %d (%s). Err: %w", index, addr, err)
+	}
+	t.conns[index] = conn
+	return conn, nil
+}
+
+func (t *TCPTransport) Receive(ctx context.Context) (Message, error) {
+	select {
+	case msg := <-t.inbox:
+		return msg, nil
+	case <-t.closed:
+		return Message{}, ErrTransportClosed
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
+
+// Close stops listening and closes all the connections
+func (t *TCPTransport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.closed)
+		err = t.listener.Close()
+		t.mu.Lock()
+		for index, conn := range t.conns {
+			_ = conn.Close()
+			delete(t.conns, index)
+		}
+		t.mu.Unlock()
+		t.acceptedMu.Lock()
+		defer t.acceptedMu.Unlock()
+		for conn := range t.accepted {
+			_ = conn.Close()
+		}
+	})
+	return err
+}
+
+func (t *TCPTransport) acceptLoop() {
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+		t.acceptedMu.Lock()
+		select {
+		case <-t.closed:
+			t.acceptedMu.Unlock()
+			_ = conn.Close()
+			return
+		default:
+		}
+		t.accepted[conn] = struct{}{}
+		t.acceptedMu.Unlock()
+		go t.readLoop(conn)
+	}
+}
+
+// readLoop reads the frames of conn until it's closed or a frame is not valid. The sender of the
+// messages is the party of the certificate of the peer
+func (t *TCPTransport) readLoop(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+		t.acceptedMu.Lock()
+		delete(t.accepted, conn)
+		t.acceptedMu.Unlock()
+	}()
+	from, err := t.handshake(conn)
+	if err != nil {
+		return
+	}
+	reader := bufio.NewReader(conn)
+	header := make([]byte, frameHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return
+		}
+		size := binary.BigEndian.Uint32(header)
+		if size > maxFrameSize {
+			return
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return
+		}
+		var msg Message
+		if err := json.Unmarshal(data, &msg); err != nil || msg.To != t.index || msg.From != from {
+			return
+		}
+		select {
+		case t.inbox <- msg:
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+// handshake runs the TLS handshake of an incoming connection and returns the party of the peer
+func (t *TCPTransport) handshake(conn net.Conn) (int, error) {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return 0, ErrNoTLSConfig
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return 0, err
+	}
+	state := tlsConn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return 0, fmt.Errorf("%w: no certificate", ErrPartyIdentity)
+	}
+	return certificateParty(state.PeerCertificates[0])
+}
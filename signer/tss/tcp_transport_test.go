@@ -0,0 +1,72 @@
+package tss
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agglayer/go_signer/log"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignTCP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	shares := newTestShares(t, 2, 3)
+	certs := writeTestCerts(t, 3, nil)
+	transports := make([]*TCPTransport, len(shares))
+	for i, share := range shares {
+		transport, err := ListenTCP(share.Index, "127.0.0.1:0", testTLSConfig(t, certs, share.Index))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, transport.Close()) })
+		transports[i] = transport
+	}
+	for i, transport := range transports {
+		for j, peer := range transports {
+			if i != j {
+				transport.AddPeer(shares[j].Index, peer.Addr())
+			}
+		}
+	}
+	parties := make([]*Party, len(shares))
+	for i, share := range shares {
+		party, err := NewParty(share, transports[i], log.WithFields("party", share.Index),
+			testOptions(t, share.Index, 30*time.Second)...)
+		require.NoError(t, err)
+		go func() { _ = party.Run(ctx) }()
+		parties[i] = party
+	}
+
+	hash := crypto.Keccak256Hash([]byte("tcp"))
+	signature, err := parties[1].Sign(ctx, hash)
+	require.NoError(t, err)
+	requireSignatureOf(t, shares[0].Address(), hash, signature)
+
+	// Party 3 restarts on another port, the cached connections to it are broken
+	require.NoError(t, transports[2].Close())
+	restarted, err := ListenTCP(3, "127.0.0.1:0", testTLSConfig(t, certs, 3))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, restarted.Close()) })
+	restarted.AddPeer(1, transports[0].Addr())
+	restarted.AddPeer(2, transports[1].Addr())
+	transports[0].AddPeer(3, restarted.Addr())
+	transports[1].AddPeer(3, restarted.Addr())
+	party3, err := NewParty(shares[2], restarted, log.WithFields("party", 3), testOptions(t, 3, 30*time.Second)...)
+	require.NoError(t, err)
+	go func() { _ = party3.Run(ctx) }()
+	signature, err = party3.Sign(ctx, hash)
+	require.NoError(t, err)
+	requireSignatureOf(t, shares[0].Address(), hash, signature)
+}
+
+func TestTCPTransportErrors(t *testing.T) {
+	certs := writeTestCerts(t, 1, nil)
+	transport, err := ListenTCP(1, "127.0.0.1:0", testTLSConfig(t, certs, 1))
+	require.NoError(t, err)
+	err = transport.Send(context.Background(), Message{To: 2})
+	require.ErrorIs(t, err, ErrUnknownParty)
+	require.NoError(t, transport.Close())
+	_, err = transport.Receive(context.Background())
+	require.ErrorIs(t, err, ErrTransportClosed)
+}
@@ -0,0 +1,117 @@
+package tss
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// partyNamePrefix is the prefix of the DNS name that identifies a party on its certificate
+const partyNamePrefix = "party-"
+
+var (
+	// ErrNoTLSConfig is returned by ListenTCP without a mutual TLS config (see NewTLSConfig)
+	ErrNoTLSConfig = errors.New("tss: the TCP transport requires a mutual TLS config")
+	// ErrPartyIdentity is returned if a certificate doesn't identify exactly one party or it's
+	// not the expected one
+	ErrPartyIdentity = errors.New("tss: certificate doesn't identify the party")
+)
+
+// PartyName is the DNS name that identifies party index on its certificate (party-<index>). The
+// certificate of each party must have it as its only party name, with the usages server and
+// client auth, and be signed by the CA shared by all the parties
+func PartyName(index int) string {
+	return partyNamePrefix + strconv.Itoa(index)
+}
+
+// NewTLSConfig returns the mutual TLS config of party index for ListenTCP: certFile and keyFile
+// are the certificate of the party (PEM files) and caFile the CA that signs the certificates of
+// all the parties
+func NewTLSConfig(index int, caFile, certFile, keyFile string) (*tls.Config, error) {
+	pem, err := os.ReadFile(filepath.Clean(caFile))
+	if err != nil {
+		return nil, fmt.Errorf("tss: can't read CA %s. Err: %w", caFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("tss: CA %s has no certificates", caFile)
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tss: can't load certificate %s. Err: %w", certFile, err)
+	}
+	if err := checkOwnCertificate(index, cert); err != nil {
+		return nil, fmt.Errorf("certificate %s: %w", certFile, err)
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+// checkOwnCertificate checks that cert is the certificate of party index
+func checkOwnCertificate(index int, cert tls.Certificate) error {
+	leaf := cert.Leaf
+	if leaf == nil && len(cert.Certificate) > 0 {
+		var err error
+		if leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return fmt.Errorf("%w: %w", ErrPartyIdentity, err)
+		}
+	}
+	own, err := certificateParty(leaf)
+	if err != nil {
+		return err
+	}
+	if own != index {
+		return fmt.Errorf("%w: certificate of party %d, not %d", ErrPartyIdentity, own, index)
+	}
+	return nil
+}
+
+// certificateParty returns the index of the party of cert, from its DNS name party-<index>
+func certificateParty(cert *x509.Certificate) (int, error) {
+	if cert == nil {
+		return 0, fmt.Errorf("%w: no certificate", ErrPartyIdentity)
+	}
+	res := 0
+	for _, name := range cert.DNSNames {
+		suffix, ok := strings.CutPrefix(name, partyNamePrefix)
+		if !ok {
+			continue
+		}
+		index, err := strconv.Atoi(suffix)
+		if err != nil || index < 1 || (res != 0 && res != index) {
+			return 0, fmt.Errorf("%w: DNS names %v", ErrPartyIdentity, cert.DNSNames)
+		}
+		res = index
+	}
+	if res == 0 {
+		return 0, fmt.Errorf("%w: no %s<index> DNS name", ErrPartyIdentity, partyNamePrefix)
+	}
+	return res, nil
+}
+
+// expectParty returns a VerifyConnection that checks that the peer is party index
+func expectParty(index int) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return fmt.Errorf("%w: no certificate", ErrPartyIdentity)
+		}
+		peer, err := certificateParty(state.PeerCertificates[0])
+		if err != nil {
+			return err
+		}
+		if peer != index {
+			return fmt.Errorf("%w: party %d instead of %d", ErrPartyIdentity, peer, index)
+		}
+		return nil
+	}
+}
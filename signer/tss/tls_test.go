@@ -0,0 +1,163 @@
+package tss
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCerts writes a CA (ca.pem) and the certificates of the parties 1..parties
+// (party-N.pem, party-N.key) to a temp dir. The certificate of party N has the DNS names names[N]
+// instead of PartyName(N) if set
+func writeTestCerts(t *testing.T, parties int, names map[int][]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "tss test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	writeTestPEM(t, filepath.Join(dir, "ca.pem"), "CERTIFICATE", caDER)
+	for index := 1; index <= parties; index++ {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		dnsNames, ok := names[index]
+		if !ok {
+			dnsNames = []string{PartyName(index)}
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(index + 1)),
+			Subject:      pkix.Name{CommonName: PartyName(index)},
+			DNSNames:     dnsNames,
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		writeTestPEM(t, filepath.Join(dir, PartyName(index)+".pem"), "CERTIFICATE", der)
+		writeTestPEM(t, filepath.Join(dir, PartyName(index)+".key"), "EC PRIVATE KEY", keyDER)
+	}
+	return dir
+}
+
+func writeTestPEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+// testTLSConfig is the config of party index with the certificates of dir (see writeTestCerts)
+func testTLSConfig(t *testing.T, dir string, index int) *tls.Config {
+	t.Helper()
+	res, err := NewTLSConfig(index, filepath.Join(dir, "ca.pem"), filepath.Join(dir, PartyName(index)+".pem"),
+		filepath.Join(dir, PartyName(index)+".key"))
+	require.NoError(t, err)
+	return res
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir := writeTestCerts(t, 3, map[int][]string{2: {"party-2", "party-3"}, 3: {"localhost"}})
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := func(index int) (string, string) {
+		return filepath.Join(dir, PartyName(index)+".pem"), filepath.Join(dir, PartyName(index)+".key")
+	}
+	cert, key := certFile(1)
+	config, err := NewTLSConfig(1, caFile, cert, key)
+	require.NoError(t, err)
+	require.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+
+	// Certificate of another party
+	_, err = NewTLSConfig(2, caFile, cert, key)
+	require.ErrorIs(t, err, ErrPartyIdentity)
+	// Two parties
+	cert, key = certFile(2)
+	_, err = NewTLSConfig(2, caFile, cert, key)
+	require.ErrorIs(t, err, ErrPartyIdentity)
+	// No party
+	cert, key = certFile(3)
+	_, err = NewTLSConfig(3, caFile, cert, key)
+	require.ErrorIs(t, err, ErrPartyIdentity)
+
+	_, err = NewTLSConfig(1, filepath.Join(dir, "missing.pem"), cert, key)
+	require.Error(t, err)
+	_, err = NewTLSConfig(1, key, cert, key)
+	require.ErrorContains(t, err, "has no certificates")
+}
+
+func TestListenTCPRequiresTLS(t *testing.T) {
+	_, err := ListenTCP(1, "127.0.0.1:0", nil)
+	require.ErrorIs(t, err, ErrNoTLSConfig)
+	_, err = ListenTCP(1, "127.0.0.1:0", &tls.Config{MinVersion: tls.VersionTLS13})
+	require.ErrorIs(t, err, ErrNoTLSConfig)
+
+	dir := writeTestCerts(t, 2, nil)
+	_, err = ListenTCP(2, "127.0.0.1:0", testTLSConfig(t, dir, 1))
+	require.ErrorIs(t, err, ErrPartyIdentity)
+}
+
+// TestTCPTransportSender checks that the messages are dropped if the sender is not the party of
+// the certificate of the connection
+func TestTCPTransportSender(t *testing.T) {
+	dir := writeTestCerts(t, 2, nil)
+	transport, err := ListenTCP(2, "127.0.0.1:0", testTLSConfig(t, dir, 2))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, transport.Close()) })
+
+	send := func(msg Message) {
+		config := testTLSConfig(t, dir, 1)
+		config.ServerName = PartyName(2)
+		conn, err := tls.Dial("tcp", transport.Addr(), config)
+		require.NoError(t, err)
+		defer conn.Close()
+		data, err := json.Marshal(msg)
+		require.NoError(t, err)
+		frame := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+		_, err = conn.Write(append(frame, data...))
+		require.NoError(t, err)
+	}
+	// Party 1 claims to be party 3
+	send(Message{Session: "forged", From: 3, To: 2})
+	send(Message{Session: "valid", From: 1, To: 2})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	msg, err := transport.Receive(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "valid", msg.Session)
+	require.Equal(t, 1, msg.From)
+
+	// Party 1 answers with the certificate of party 2
+	impostor, err := ListenTCP(2, "127.0.0.1:0", testTLSConfig(t, dir, 2))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, impostor.Close()) })
+	transport.AddPeer(1, impostor.Addr())
+	err = transport.Send(ctx, Message{To: 1})
+	require.ErrorContains(t, err, PartyName(1))
+}
@@ -0,0 +1,112 @@
+package tss
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// memoryQueueSize is the number of messages buffered for each party of a MemoryNetwork
+const memoryQueueSize = 1024
+
+var (
+	// ErrUnknownParty is returned when sending to a party that is not on the transport
+	ErrUnknownParty = errors.New("tss: unknown party")
+	// ErrTransportClosed is returned by a closed transport
+	ErrTransportClosed = errors.New("tss: transport closed")
+)
+
+// Message is a message of the signing protocol between two parties
+type Message struct {
+	// Session is the id of the signing session
+	Session string `json:"session"`
+	// Round is the round of the protocol
+	Round int `json:"round"`
+	// From is the index of the sender
+	From int `json:"from"`
+	// To is the index of the receiver
+	To int `json:"to"`
+	// Payload is the content of the message, depends on Round
+	Payload json.RawMessage `json:"payload"`
+}
+
+// Transport delivers the messages between the parties. It's pluggable: MemoryNetwork runs all
+// the parties in the same process and TCPTransport runs them on different processes or hosts.
+// The protocol has no authentication of its own, the transport must guarantee that From is
+// the real sender and keep the messages private (e.g. mTLS, a VPN or a private network)
+type Transport interface {
+	// Send delivers msg to the party msg.To
+	Send(ctx context.Context, msg Message) error
+	// Receive returns the next message for this party
+	Receive(ctx context.Context) (Message, error)
+	// Close releases the resources of the transport, Receive returns ErrTransportClosed
+	Close() error
+}
+
+// MemoryNetwork is an in-process network of parties, to run the protocol on tests or
+// on a single process
+type MemoryNetwork struct {
+	mu     sync.RWMutex
+	queues map[int]chan Message
+}
+
+// NewMemoryNetwork creates a network for the parties with indexes
+func NewMemoryNetwork(indexes ...int) *MemoryNetwork {
+	res := &MemoryNetwork{queues: make(map[int]chan Message, len(indexes))}
+	for _, index := range indexes {
+		res.queues[index] = make(chan Message, memoryQueueSize)
+	}
+	return res
+}
+
+// Transport returns the transport of party index
+func (n *MemoryNetwork) Transport(index int) Transport {
+	return &memoryTransport{network: n, index: index, closed: make(chan struct{})}
+}
+
+type memoryTransport struct {
+	network   *MemoryNetwork
+	index     int
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (t *memoryTransport) Send(ctx context.Context, msg Message) error {
+	t.network.mu.RLock()
+	queue, ok := t.network.queues[msg.To]
+	t.network.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownParty, msg.To)
+	}
+	msg.From = t.index
+	select {
+	case queue <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *memoryTransport) Receive(ctx context.Context) (Message, error) {
+	t.network.mu.RLock()
+	queue, ok := t.network.queues[t.index]
+	t.network.mu.RUnlock()
+	if !ok {
+		return Message{}, fmt.Errorf("%w: %d", ErrUnknownParty, t.index)
+	}
+	select {
+	case msg := <-queue:
+		return msg, nil
+	case <-t.closed:
+		return Message{}, ErrTransportClosed
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
+
+func (t *memoryTransport) Close() error {
+	t.closeOnce.Do(func() { close(t.closed) })
+	return nil
+}
@@ -0,0 +1,317 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/batch"
+	"github.com/agglayer/go_signer/signer/tss"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// FieldShareFile is the path of the key share file of the party (tss.WriteKeyShareFile)
+	FieldShareFile = "sharefile"
+	// FieldListen is the address where the party listens for the other parties (host:port)
+	FieldListen = "listen"
+	// FieldPeers are the addresses of the other parties (index -> host:port)
+	FieldPeers = "peers"
+	// FieldPaillierBits is the size of the Paillier modulus of the party
+	FieldPaillierBits = "paillierbits"
+	// FieldApproveAll makes the party join all the sessions of the other parties
+	FieldApproveAll = "approveall"
+)
+
+// TSSConfig is the specific config of the tss method
+type TSSConfig struct {
+	// ShareFile is the key share file of this party
+	ShareFile string
+	// Password of ShareFile
+	Password string
+	// Listen is the address where this party listens (host:port)
+	Listen string
+	// Peers are the addresses of the other parties by index
+	Peers map[int]string
+	// Timeout is the max duration of a signing session
+	Timeout time.Duration
+	// PaillierBits is the size of the Paillier modulus of this party
+	PaillierBits int
+	// TLSCACert is the CA that signs the certificates of all the parties (PEM file)
+	TLSCACert string
+	// TLSCert is the certificate of this party, with the DNS name tss.PartyName(index) (PEM file)
+	TLSCert string
+	// TLSKey is the private key of TLSCert (PEM file)
+	TLSKey string
+	// ApproveAll joins all the sessions of the other parties. Otherwise they are rejected
+	// unless SetApproval sets an approval function
+	ApproveAll bool
+}
+
+// tssConfigFields is the format of TSSConfig on SignerConfig (the keys of Peers are strings)
+type tssConfigFields struct {
+	ShareFile    string            `mapstructure:"sharefile"`
+	Password     string            `mapstructure:"password"`
+	Listen       string            `mapstructure:"listen"`
+	Peers        map[string]string `mapstructure:"peers"`
+	Timeout      time.Duration     `mapstructure:"timeout"`
+	PaillierBits int               `mapstructure:"paillierbits"`
+	TLSCACert    string            `mapstructure:"tlscacert"`
+	TLSCert      string            `mapstructure:"tlscert"`
+	TLSKey       string            `mapstructure:"tlskey"`
+	ApproveAll   bool              `mapstructure:"approveall"`
+}
+
+func (c TSSConfig) String() string {
+	return fmt.Sprintf("TSSConfig{ShareFile: %s, Password: ***, Listen: %s, Peers: %v, TLSCACert: %s, "+
+		"TLSCert: %s, TLSKey: %s, ApproveAll: %t}", c.ShareFile, c.Listen, c.Peers, c.TLSCACert, c.TLSCert,
+		c.TLSKey, c.ApproveAll)
+}
+
+// NewTSSConfig creates a TSSConfig (specific config) from a SignerConfig
+func NewTSSConfig(cfg signertypes.SignerConfig) (TSSConfig, error) {
+	fields := tssConfigFields{Timeout: tss.DefaultTimeout, PaillierBits: tss.DefaultPaillierBits}
+	if err := cfg.Decode(&fields); err != nil {
+		return TSSConfig{}, err
+	}
+	required := map[string]string{
+		FieldShareFile: fields.ShareFile,
+		FieldListen:    fields.Listen,
+		FieldTLSCACert: fields.TLSCACert,
+		FieldTLSCert:   fields.TLSCert,
+		FieldTLSKey:    fields.TLSKey,
+	}
+	for field, value := range required {
+		if value == "" {
+			return TSSConfig{}, fmt.Errorf("config %s: field %s is required. Err: %w",
+				cfg.Method, field, signertypes.ErrMissingConfigParam)
+		}
+	}
+	peers, err := parseTSSPeers(fields.Peers)
+	if err != nil {
+		return TSSConfig{}, fmt.Errorf("config %s: field %s. Err: %w: %w", cfg.Method, FieldPeers,
+			signertypes.ErrBadConfigParams, err)
+	}
+	return TSSConfig{
+		ShareFile:    fields.ShareFile,
+		Password:     fields.Password,
+		Listen:       fields.Listen,
+		Peers:        peers,
+		Timeout:      fields.Timeout,
+		PaillierBits: fields.PaillierBits,
+		TLSCACert:    fields.TLSCACert,
+		TLSCert:      fields.TLSCert,
+		TLSKey:       fields.TLSKey,
+		ApproveAll:   fields.ApproveAll,
+	}, nil
+}
+
+// parseTSSPeers converts the keys of peers to party indexes
+func parseTSSPeers(peers map[string]string) (map[int]string, error) {
+	res := make(map[int]string, len(peers))
+	for key, addr := range peers {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 1 {
+			return nil, fmt.Errorf("%q is not a party index", key)
+		}
+		res[index] = addr
+	}
+	return res, nil
+}
+
+// TSSSign is a signer for a key split between several parties (threshold ECDSA, see package tss).
+// This process is one of the parties: it coordinates the sessions of its own requests and joins the
+// sessions of the other parties. No party has the private key
+type TSSSign struct {
+	name    string
+	logger  signercommon.Logger
+	cfg     TSSConfig
+	chainID uint64
+	batcher *batch.Batcher
+	approve tss.ApproveFunc
+
+	mu        sync.Mutex
+	party     *tss.Party
+	transport tss.Transport
+	cancel    context.CancelFunc
+}
+
+var _ signertypes.Signer = (*TSSSign)(nil)
+
+// NewTSSSign creates a TSSSign that is initialized reading the share file of cfg and
+// listening on cfg.Listen
+func NewTSSSign(name string, logger signercommon.Logger, cfg TSSConfig, chainID uint64) *TSSSign {
+	return &TSSSign{
+		name:    name,
+		logger:  logger,
+		cfg:     cfg,
+		chainID: chainID,
+		batcher: batch.NewBatcher(signertypes.DefaultBatchConfig()),
+	}
+}
+
+// NewTSSSignFromParty creates a TSSSign for party, e.g. on a tss.MemoryNetwork. The caller runs party.Run
+func NewTSSSignFromParty(name string, logger signercommon.Logger, party *tss.Party, chainID uint64) *TSSSign {
+	res := NewTSSSign(name, logger, TSSConfig{}, chainID)
+	res.party = party
+	return res
+}
+
+// SetApproval sets the function that decides which sessions of the other parties are joined,
+// it must be called before Initialize. Without it (or cfg.ApproveAll) all of them are rejected
+func (e *TSSSign) SetApproval(approve tss.ApproveFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.approve = approve
+}
+
+// Initialize reads the key share, listens for the other parties and starts receiving their messages
+func (e *TSSSign) Initialize(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.party != nil {
+		return nil
+	}
+	share, err := tss.ReadKeyShareFile(e.cfg.ShareFile, e.cfg.Password)
+	if err != nil {
+		return fmt.Errorf("%s Initialize. Err: %w", e.logPrefix(), err)
+	}
+	tlsConfig, err := tss.NewTLSConfig(share.Index, e.cfg.TLSCACert, e.cfg.TLSCert, e.cfg.TLSKey)
+	if err != nil {
+		return fmt.Errorf("%s Initialize. Err: %w", e.logPrefix(), err)
+	}
+	transport, err := tss.ListenTCP(share.Index, e.cfg.Listen, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("%s Initialize. Err: %w", e.logPrefix(), err)
+	}
+	for index, addr := range e.cfg.Peers {
+		transport.AddPeer(index, addr)
+	}
+	approve := e.approve
+	if e.cfg.ApproveAll {
+		approve = tss.ApproveAll
+	}
+	party, err := tss.NewParty(share, transport, e.logger, tss.WithTimeout(e.cfg.Timeout),
+		tss.WithPaillierBits(e.cfg.PaillierBits), tss.WithApproval(approve))
+	if err != nil {
+		_ = transport.Close()
+		return fmt.Errorf("%s Initialize. Err: %w", e.logPrefix(), err)
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		if err := party.Run(runCtx); err != nil {
+			e.logger.Errorf("%s %v", e.logPrefix(), err)
+		}
+	}()
+	e.party = party
+	e.transport = transport
+	e.cancel = cancel
+	e.logger.Infof("%s party %d (%d of %d) listening on %s, address %s", e.logPrefix(), share.Index,
+		share.Threshold, share.Parties(), transport.Addr(), party.Address().Hex())
+	return nil
+}
+
+// Close stops receiving messages and closes the transport created by Initialize
+func (e *TSSSign) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cancel == nil {
+		return nil
+	}
+	e.cancel()
+	e.cancel = nil
+	err := e.transport.Close()
+	e.party = nil
+	e.transport = nil
+	return err
+}
+
+func (e *TSSSign) getParty() (*tss.Party, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.party == nil {
+		return nil, fmt.Errorf("%s not initialized", e.logPrefix())
+	}
+	return e.party, nil
+}
+
+func (e *TSSSign) PublicAddress() common.Address {
+	party, err := e.getParty()
+	if err != nil {
+		return common.Address{}
+	}
+	return party.Address()
+}
+
+func (e *TSSSign) String() string {
+	return fmt.Sprintf("%s pubAddr: %s", e.logPrefix(), e.PublicAddress().String())
+}
+
+// SignHash signs hash with threshold-1 of the other parties
+func (e *TSSSign) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	party, err := e.getParty()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := party.Sign(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("%s SignHash. Err: %w", e.logPrefix(), err)
+	}
+	return signature, nil
+}
+
+func (e *TSSSign) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return e.SignTxForChain(ctx, 0, tx)
+}
+
+// SignTxForChain signs tx for chainID (0 means the chainID of the signer or, if it's not set, the one of the tx)
+func (e *TSSSign) SignTxForChain(ctx context.Context, chainID uint64,
+	tx *types.Transaction) (*types.Transaction, error) {
+	if chainID == 0 {
+		chainID = e.chainID
+	}
+	chainID, err := signertypes.ResolveTxChainID(tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("%s can't signTx. Err: %w", e.logPrefix(), err)
+	}
+	txSigner := types.LatestSignerForChainID(new(big.Int).SetUint64(chainID))
+	signature, err := e.SignHash(ctx, txSigner.Hash(tx))
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(txSigner, signature)
+}
+
+func (e *TSSSign) SignAuthorization(ctx context.Context,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	signed, err := signertypes.SignAuthorizationWithHash(ctx, e, e.chainID, auth)
+	if err != nil {
+		return types.SetCodeAuthorization{}, fmt.Errorf("%s can't signAuthorization. Err: %w", e.logPrefix(), err)
+	}
+	return signed, nil
+}
+
+// SetBatchConfig sets the parameters used by SignHashes and SignTxs
+func (e *TSSSign) SetBatchConfig(cfg signertypes.BatchConfig) {
+	e.batcher = batch.NewBatcher(cfg)
+}
+
+// SignHashes signs a batch of hashes, each one is a signing session
+func (e *TSSSign) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	return e.batcher.SignHashes(ctx, e, hashes)
+}
+
+// SignTxs signs a batch of txs, each one is a signing session
+func (e *TSSSign) SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error) {
+	return e.batcher.SignTxs(ctx, e, txs)
+}
+
+func (e *TSSSign) logPrefix() string {
+	return fmt.Sprintf("signer: %s[%s]: ", signertypes.MethodTSS, e.name)
+}
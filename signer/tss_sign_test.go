@@ -0,0 +1,177 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agglayer/go_signer/log"
+	"github.com/agglayer/go_signer/signer/tss"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTSSConfig(t *testing.T) {
+	cfg := signertypes.SignerConfig{
+		Method: signertypes.MethodTSS,
+		Config: map[string]any{
+			"ShareFile": "/share1.json",
+			"Listen":    "127.0.0.1:9001",
+			"Peers":     map[string]any{"2": "127.0.0.1:9002", "3": "127.0.0.1:9003"},
+			"Timeout":   "10s",
+			"TLSCACert": "/ca.pem",
+			"TLSCert":   "/party-1.pem",
+			"TLSKey":    "/party-1.key",
+		},
+	}
+	res, err := NewTSSConfig(cfg)
+	require.NoError(t, err)
+	require.Equal(t, map[int]string{2: "127.0.0.1:9002", 3: "127.0.0.1:9003"}, res.Peers)
+	require.Equal(t, 10*time.Second, res.Timeout)
+	require.Equal(t, tss.DefaultPaillierBits, res.PaillierBits)
+	require.False(t, res.ApproveAll)
+	require.NoError(t, ValidateConfig(cfg))
+
+	delete(cfg.Config, "TLSKey")
+	_, err = NewTSSConfig(cfg)
+	require.ErrorIs(t, err, signertypes.ErrMissingConfigParam)
+	require.ErrorContains(t, err, FieldTLSKey)
+	cfg.Config["TLSKey"] = "/party-1.key"
+
+	cfg.Config["Peers"] = map[string]any{"two": "127.0.0.1:9002"}
+	_, err = NewTSSConfig(cfg)
+	require.ErrorIs(t, err, signertypes.ErrBadConfigParams)
+	require.ErrorIs(t, ValidateConfig(cfg), signertypes.ErrBadConfigParams)
+
+	delete(cfg.Config, "Listen")
+	_, err = NewTSSConfig(cfg)
+	require.ErrorIs(t, err, signertypes.ErrMissingConfigParam)
+}
+
+func TestTSSSignMemoryNetwork(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	shares, err := tss.Deal(key, 2, 2)
+	require.NoError(t, err)
+	network := tss.NewMemoryNetwork(1, 2)
+	signers := make([]*TSSSign, len(shares))
+	for i, share := range shares {
+		party, err := tss.NewParty(share, network.Transport(share.Index), log.WithFields("party", share.Index),
+			tss.WithPaillierBits(tss.MinPaillierBits), tss.WithApproval(tss.ApproveAll))
+		require.NoError(t, err)
+		go func() { _ = party.Run(ctx) }()
+		signers[i] = NewTSSSignFromParty("tss", log.WithFields("test", "test"), party, 1)
+		require.NoError(t, signers[i].Initialize(ctx))
+	}
+	sut := signers[0]
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	require.Equal(t, address, sut.PublicAddress())
+
+	signatures, err := sut.SignHashes(ctx, []common.Hash{{1}, {2}})
+	require.NoError(t, err)
+	for i, signature := range signatures {
+		pub, err := crypto.SigToPub(common.Hash{byte(i + 1)}.Bytes(), signature)
+		require.NoError(t, err)
+		require.Equal(t, address, crypto.PubkeyToAddress(*pub))
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 1})
+	signed, err := signers[1].SignTx(ctx, tx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), signed)
+	require.NoError(t, err)
+	require.Equal(t, address, sender)
+	_, err = sut.SignTx(ctx, types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(2)}))
+	require.ErrorIs(t, err, signertypes.ErrChainIDMismatch)
+
+	auth, err := sut.SignAuthorization(ctx, types.SetCodeAuthorization{ChainID: *uint256.NewInt(1), Nonce: 1})
+	require.NoError(t, err)
+	authority, err := auth.Authority()
+	require.NoError(t, err)
+	require.Equal(t, address, authority)
+}
+
+func TestTSSSignFromConfig(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	// 1 of 2: the party signs alone, party 2 is not running
+	shares, err := tss.Deal(key, 1, 2)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "share1.json")
+	require.NoError(t, tss.WriteKeyShareFile(path, shares[0], "secret"))
+	certFile, keyFile := writeTSSCert(t, dir, 1)
+
+	sut, err := NewSigner(ctx, 1, signertypes.SignerConfig{
+		Method: signertypes.MethodTSS,
+		Config: map[string]any{
+			"ShareFile":    path,
+			"Password":     "secret",
+			"Listen":       "127.0.0.1:0",
+			"Peers":        map[string]any{"2": "127.0.0.1:1"},
+			"PaillierBits": tss.MinPaillierBits,
+			"TLSCACert":    certFile,
+			"TLSCert":      certFile,
+			"TLSKey":       keyFile,
+		},
+	}, "tss", log.WithFields("test", "test"))
+	require.NoError(t, err)
+	_, err = sut.SignHash(ctx, common.Hash{1})
+	require.ErrorContains(t, err, "not initialized")
+	require.NoError(t, sut.Initialize(ctx))
+	require.NoError(t, sut.Initialize(ctx))
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), sut.PublicAddress())
+	signature, err := sut.SignHash(ctx, common.Hash{1})
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(common.Hash{1}.Bytes(), signature)
+	require.NoError(t, err)
+	require.Equal(t, sut.PublicAddress(), crypto.PubkeyToAddress(*pub))
+
+	closer, ok := sut.(interface{ Close() error })
+	require.True(t, ok)
+	require.NoError(t, closer.Close())
+	require.Equal(t, common.Address{}, sut.PublicAddress())
+}
+
+// writeTSSCert writes a self-signed certificate of party index (it's also the CA)
+func writeTSSCert(t *testing.T, dir string, index int) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: tss.PartyName(index)},
+		DNSNames:              []string{tss.PartyName(index)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certFile := filepath.Join(dir, tss.PartyName(index)+".pem")
+	keyFile := filepath.Join(dir, tss.PartyName(index)+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
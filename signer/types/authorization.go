@@ -0,0 +1,74 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// authorizationMagic is the prefix of the EIP-7702 authorization digest
+const authorizationMagic = 0x05
+
+// ErrAuthorizationNotSupported is returned by the backends that can't sign EIP-7702 authorizations
+var ErrAuthorizationNotSupported = errors.New("signing EIP-7702 authorizations is not supported")
+
+type AuthorizationSigner interface {
+	// SignAuthorization signs an EIP-7702 SetCode authorization. The signature fields of auth are ignored
+	SignAuthorization(ctx context.Context, auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error)
+}
+
+// AuthorizationHash returns the digest of an EIP-7702 authorization:
+// keccak256(0x05 || rlp([chain_id, address, nonce]))
+func AuthorizationHash(auth types.SetCodeAuthorization) (common.Hash, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(authorizationMagic)
+	if err := rlp.Encode(&buf, []any{&auth.ChainID, auth.Address, auth.Nonce}); err != nil {
+		return common.Hash{}, fmt.Errorf("can't encode authorization. Err: %w", err)
+	}
+	return crypto.Keccak256Hash(buf.Bytes()), nil
+}
+
+// AuthorizationWithSignature returns auth with the signature [R || S || V] (V is 0/1)
+func AuthorizationWithSignature(auth types.SetCodeAuthorization,
+	signature []byte) (types.SetCodeAuthorization, error) {
+	if len(signature) != crypto.SignatureLength {
+		return types.SetCodeAuthorization{}, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	auth.R.SetBytes(signature[:32])
+	auth.S.SetBytes(signature[32:64])
+	auth.V = signature[crypto.RecoveryIDOffset]
+	return auth, nil
+}
+
+// CheckAuthorizationChainID returns a *ChainIDMismatchError if auth is for a chain different from
+// chainID. An authorization with chain ID 0 is valid on any chain, so it's not checked
+func CheckAuthorizationChainID(auth types.SetCodeAuthorization, chainID uint64) error {
+	if chainID == 0 || auth.ChainID.IsZero() {
+		return nil
+	}
+	return CheckChainID(chainID, auth.ChainID.ToBig())
+}
+
+// SignAuthorizationWithHash signs auth with a signer of raw hashes, it's the implementation
+// of SignAuthorization for the backends that can sign the digest directly
+func SignAuthorizationWithHash(ctx context.Context, signer HashSigner, chainID uint64,
+	auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	if err := CheckAuthorizationChainID(auth, chainID); err != nil {
+		return types.SetCodeAuthorization{}, err
+	}
+	hash, err := AuthorizationHash(auth)
+	if err != nil {
+		return types.SetCodeAuthorization{}, err
+	}
+	signature, err := signer.SignHash(ctx, hash)
+	if err != nil {
+		return types.SetCodeAuthorization{}, err
+	}
+	return AuthorizationWithSignature(auth, signature)
+}
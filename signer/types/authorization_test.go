@@ -0,0 +1,42 @@
+package types
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+// keySigner signs raw hashes with a private key
+type keySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (k keySigner) SignHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash.Bytes(), k.key)
+}
+
+func TestSignAuthorizationWithHash(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth := types.SetCodeAuthorization{ChainID: *uint256.NewInt(1), Address: common.HexToAddress("0x1234"), Nonce: 7}
+	expected, err := types.SignSetCode(key, auth)
+	require.NoError(t, err)
+
+	signed, err := SignAuthorizationWithHash(context.Background(), keySigner{key: key}, 1, auth)
+	require.NoError(t, err)
+	require.Equal(t, expected, signed)
+	authority, err := signed.Authority()
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), authority)
+
+	_, err = SignAuthorizationWithHash(context.Background(), keySigner{key: key}, 2, auth)
+	require.ErrorIs(t, err, ErrChainIDMismatch)
+	// An authorization for any chain (chain ID 0) is valid for every signer
+	require.NoError(t, CheckAuthorizationChainID(types.SetCodeAuthorization{}, 2))
+}
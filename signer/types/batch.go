@@ -0,0 +1,107 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// FieldBatchConcurrency is the max number of signatures in flight during a batch
+	FieldBatchConcurrency = "BatchConcurrency"
+	// FieldBatchRateLimit is the max number of signatures per second during a batch (0 = unlimited)
+	FieldBatchRateLimit = "BatchRateLimit"
+
+	// DefaultBatchConcurrency is the concurrency used if it's not set in config
+	DefaultBatchConcurrency = 8
+)
+
+// BatchConfig controls how a batch of signatures is executed by backends
+// that don't support native batching
+type BatchConfig struct {
+	// Concurrency is the max number of signatures in flight
+	Concurrency int
+	// RateLimit is the max number of signatures per second, 0 means unlimited
+	RateLimit float64
+}
+
+// DefaultBatchConfig returns the BatchConfig used if nothing is set in config
+func DefaultBatchConfig() BatchConfig {
+	return BatchConfig{
+		Concurrency: DefaultBatchConcurrency,
+	}
+}
+
+// NewBatchConfig extracts the batch parameters from a SignerConfig. This fields
+// are generic, so they are valid for any method
+func NewBatchConfig(cfg SignerConfig) (BatchConfig, error) {
+	res := DefaultBatchConfig()
+	concurrency, err := cfg.GetInt(FieldBatchConcurrency)
+	if err != nil && !errors.Is(err, ErrMissingConfigParam) {
+		return res, fmt.Errorf("config %s: error in field %s. Err: %w", cfg.Method, FieldBatchConcurrency, err)
+	}
+	if err == nil {
+		if concurrency <= 0 {
+			return res, fmt.Errorf("config %s: field %s must be > 0. Err: %w",
+				cfg.Method, FieldBatchConcurrency, ErrBadConfigParams)
+		}
+		res.Concurrency = concurrency
+	}
+	rateLimit, err := cfg.GetFloat(FieldBatchRateLimit)
+	if err != nil && !errors.Is(err, ErrMissingConfigParam) {
+		return res, fmt.Errorf("config %s: error in field %s. Err: %w", cfg.Method, FieldBatchRateLimit, err)
+	}
+	if err == nil {
+		if rateLimit < 0 {
+			return res, fmt.Errorf("config %s: field %s must be >= 0. Err: %w",
+				cfg.Method, FieldBatchRateLimit, ErrBadConfigParams)
+		}
+		res.RateLimit = rateLimit
+	}
+	return res, nil
+}
+
+func (c BatchConfig) String() string {
+	return fmt.Sprintf("BatchConfig{concurrency: %d, rateLimit: %.2f/s}", c.Concurrency, c.RateLimit)
+}
+
+// BatchError is returned by SignHashes / SignTxs when some items of the batch fail.
+// Errors has the same length as the input, with nil for the items that succeeded
+type BatchError struct {
+	Errors []error
+}
+
+// Failed returns the number of items that failed
+func (e *BatchError) Failed() int {
+	failed := 0
+	for _, err := range e.Errors {
+		if err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+func (e *BatchError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("batch sign: %d of %d items failed", e.Failed(), len(e.Errors)))
+	for i, err := range e.Errors {
+		if err != nil {
+			sb.WriteString(fmt.Sprintf("; [%d]: %s", i, err.Error()))
+			// Just the first one to avoid a huge message
+			break
+		}
+	}
+	return sb.String()
+}
+
+// Unwrap allows errors.Is / errors.As to inspect the errors of each item
+func (e *BatchError) Unwrap() []error {
+	res := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		if err != nil {
+			res = append(res, err)
+		}
+	}
+	return res
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrChainIDMismatch is the sentinel of ChainIDMismatchError (use errors.Is)
+	ErrChainIDMismatch = errors.New("chain ID mismatch")
+	// ErrChainIDRequired is returned for a legacy tx if neither the call nor the signer set a chain ID
+	ErrChainIDRequired = errors.New("chain ID required to sign a legacy tx")
+)
+
+// ChainIDMismatchError is returned if a tx (or a remote signer) has a chain ID different from
+// the one configured on the signer
+type ChainIDMismatchError struct {
+	// Expected is the chain ID of the signer
+	Expected *big.Int
+	// Got is the chain ID of the tx or the remote signer
+	Got *big.Int
+}
+
+func (e *ChainIDMismatchError) Error() string {
+	return fmt.Sprintf("%s: expected %s, got %s", ErrChainIDMismatch, e.Expected, e.Got)
+}
+
+// Is allows errors.Is(err, ErrChainIDMismatch)
+func (e *ChainIDMismatchError) Is(target error) bool {
+	return target == ErrChainIDMismatch
+}
+
+// CheckTxChainID returns a *ChainIDMismatchError if tx is a typed tx with a chain ID different
+// from chainID. Legacy txs don't carry the chain ID until they are signed, so they are not
+// checked, as a chainID of 0 (not configured)
+func CheckTxChainID(tx *types.Transaction, chainID uint64) error {
+	if chainID == 0 || tx.Type() == types.LegacyTxType {
+		return nil
+	}
+	return CheckChainID(chainID, tx.ChainId())
+}
+
+// CheckChainID returns a *ChainIDMismatchError if got is not chainID
+func CheckChainID(chainID uint64, got *big.Int) error {
+	expected := new(big.Int).SetUint64(chainID)
+	if got == nil || got.Cmp(expected) != 0 {
+		return &ChainIDMismatchError{Expected: expected, Got: got}
+	}
+	return nil
+}
+
+// ResolveTxChainID returns the chain ID to sign tx with: chainID if it's not 0 (a typed tx must
+// have the same one), or the chain ID of a typed tx. A legacy tx requires chainID
+func ResolveTxChainID(tx *types.Transaction, chainID uint64) (uint64, error) {
+	if chainID != 0 {
+		return chainID, CheckTxChainID(tx, chainID)
+	}
+	if tx.Type() == types.LegacyTxType {
+		return 0, ErrChainIDRequired
+	}
+	if !tx.ChainId().IsUint64() || tx.ChainId().Sign() == 0 {
+		return 0, fmt.Errorf("%w: tx has chain ID %s", ErrChainIDRequired, tx.ChainId())
+	}
+	return tx.ChainId().Uint64(), nil
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTxChainID(t *testing.T) {
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(2)})
+	require.NoError(t, CheckTxChainID(tx, 2))
+	require.NoError(t, CheckTxChainID(tx, 0))
+	err := CheckTxChainID(tx, 1)
+	require.ErrorIs(t, err, ErrChainIDMismatch)
+	require.Equal(t, "chain ID mismatch: expected 1, got 2", err.Error())
+	// A legacy tx gets the chain ID when it's signed
+	require.NoError(t, CheckTxChainID(types.NewTx(&types.LegacyTx{}), 1))
+
+	require.ErrorIs(t, CheckChainID(1, nil), ErrChainIDMismatch)
+	require.NoError(t, CheckChainID(1, big.NewInt(1)))
+}
+
+func TestResolveTxChainID(t *testing.T) {
+	typedTx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(10)})
+	legacyTx := types.NewTx(&types.LegacyTx{})
+
+	chainID, err := ResolveTxChainID(typedTx, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), chainID)
+	chainID, err = ResolveTxChainID(legacyTx, 5)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), chainID)
+
+	_, err = ResolveTxChainID(typedTx, 5)
+	require.ErrorIs(t, err, ErrChainIDMismatch)
+	_, err = ResolveTxChainID(legacyTx, 0)
+	require.ErrorIs(t, err, ErrChainIDRequired)
+	_, err = ResolveTxChainID(types.NewTx(&types.DynamicFeeTx{}), 0)
+	require.ErrorIs(t, err, ErrChainIDRequired)
+}
@@ -2,8 +2,11 @@ package types
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type SignMethod string
@@ -14,6 +17,14 @@ var (
 	MethodRemoteSigner SignMethod = "remote"
 	MethodGCPKMS       SignMethod = "GCP"
 	MethodAWSKMS       SignMethod = "AWS"
+	// MethodHD derives the key from a BIP-39 mnemonic and a BIP-32 derivation path
+	MethodHD SignMethod = "hd"
+	// MethodClef uses Clef (go-ethereum external signer) and its account_* API
+	MethodClef SignMethod = "clef"
+	// MethodShamir recovers the key in memory from k of n Shamir shares (encrypted share files)
+	MethodShamir SignMethod = "shamir"
+	// MethodTSS signs with a key split between several parties (threshold ECDSA)
+	MethodTSS SignMethod = "tss"
 	// Methods for debug / unittest
 	MethodMock SignMethod = "mock" //
 )
@@ -30,19 +41,29 @@ func (m SignMethod) String() string {
 // { Method="remote", URL="http://localhost:9000", Address="0x1234567890abcdef" }
 type SignerConfig struct {
 	// Method is the method to use to sign
-	Method SignMethod `jsonschema:"enum=local, enum=remote_eth" mapstructure:"Method"`
+	Method SignMethod `jsonschema:"enum=none,enum=local,enum=hd,enum=shamir,enum=remote,enum=clef,enum=tss,enum=GCP,enum=AWS,enum=mock" mapstructure:"Method"`
 	// Config is the configuration for the signer (depend on Method field)
 	Config map[string]any `jsonschema:"omitempty" mapstructure:",remain"`
 }
 
-func (c SignerConfig) Get(key string) (string, error) {
-	v, ok := c.Config[key]
-	if !ok {
-		v, ok = c.Config[strings.ToLower(key)]
-		if !ok {
-			return "", fmt.Errorf("key %s not found. Err: %w", key, ErrMissingConfigParam)
+func (c SignerConfig) lookup(key string) (any, error) {
+	if v, ok := c.Config[key]; ok {
+		return v, nil
+	}
+	for k, v := range c.Config {
+		if strings.EqualFold(k, key) {
+			return v, nil
 		}
 	}
+	return nil, fmt.Errorf("key %s not found. Err: %w", key, ErrMissingConfigParam)
+}
+
+// Get returns the value of a string key (case-insensitive)
+func (c SignerConfig) Get(key string) (string, error) {
+	v, err := c.lookup(key)
+	if err != nil {
+		return "", err
+	}
 	s, ok := v.(string)
 	if !ok {
 		return "", fmt.Errorf("key %s is not a string. Err: %w", key, ErrBadConfigParams)
@@ -50,6 +71,84 @@ func (c SignerConfig) Get(key string) (string, error) {
 	return s, nil
 }
 
+// GetInt returns the value of an integer key (case-insensitive). It accepts
+// any numeric type or a string containing a decimal number
+func (c SignerConfig) GetInt(key string) (int, error) {
+	v, err := c.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case uint64:
+		return int(n), nil
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, fmt.Errorf("key %s is not an integer (%v). Err: %w", key, n, ErrBadConfigParams)
+		}
+		return int(n), nil
+	case string:
+		i, err := strconv.Atoi(n)
+		if err != nil {
+			return 0, fmt.Errorf("key %s is not an integer (%s). Err: %w", key, n, ErrBadConfigParams)
+		}
+		return i, nil
+	default:
+		return 0, fmt.Errorf("key %s is not an integer. Err: %w", key, ErrBadConfigParams)
+	}
+}
+
+// GetFloat returns the value of a numeric key (case-insensitive). It accepts
+// any numeric type or a string containing a number
+func (c SignerConfig) GetFloat(key string) (float64, error) {
+	v, err := c.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, fmt.Errorf("key %s is not a number (%s). Err: %w", key, n, ErrBadConfigParams)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("key %s is not a number. Err: %w", key, ErrBadConfigParams)
+	}
+}
+
+// GetDuration returns the value of a duration key (case-insensitive). It accepts
+// a string as "1s", "500ms" or a number that is interpreted as seconds
+func (c SignerConfig) GetDuration(key string) (time.Duration, error) {
+	v, err := c.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	if str, ok := v.(string); ok {
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return 0, fmt.Errorf("key %s is not a duration (%s). Err: %w", key, str, ErrBadConfigParams)
+		}
+		return d, nil
+	}
+	seconds, err := c.GetFloat(key)
+	if err != nil {
+		return 0, fmt.Errorf("key %s is not a duration. Err: %w", key, ErrBadConfigParams)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
 func (c SignerConfig) String() string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("SignerConfig:Method: %s\n", c.Method))
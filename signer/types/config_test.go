@@ -137,3 +137,31 @@ func TestSignerConfigString(t *testing.T) {
 		Config: nil,
 	}.String())
 }
+
+func TestNewBatchConfig(t *testing.T) {
+	cfg, err := NewBatchConfig(SignerConfig{Method: MethodLocal})
+	require.NoError(t, err)
+	require.Equal(t, DefaultBatchConfig(), cfg)
+
+	cfg, err = NewBatchConfig(SignerConfig{
+		Method: MethodGCPKMS,
+		Config: map[string]any{
+			"batchconcurrency": int64(4),
+			"batchratelimit":   "2.5",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, BatchConfig{Concurrency: 4, RateLimit: 2.5}, cfg)
+
+	_, err = NewBatchConfig(SignerConfig{
+		Method: MethodGCPKMS,
+		Config: map[string]any{FieldBatchConcurrency: 0},
+	})
+	require.ErrorIs(t, err, ErrBadConfigParams)
+
+	_, err = NewBatchConfig(SignerConfig{
+		Method: MethodGCPKMS,
+		Config: map[string]any{FieldBatchRateLimit: "fast"},
+	})
+	require.ErrorIs(t, err, ErrBadConfigParams)
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/mapstructure"
+)
+
+var (
+	addressType  = reflect.TypeOf(common.Address{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Decode decodes Config into out (a pointer to a struct with mapstructure tags).
+// The keys are case-insensitive and the numeric, bool and duration fields also accept
+// strings (e.g. from env vars). The keys of Config that are not on out are ignored
+// (use Validate to detect them)
+func (c SignerConfig) Decode(out any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:    out,
+		MatchName: strings.EqualFold,
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			stringToAddressHook,
+			stringToScalarHook,
+			mapstructure.StringToTimeDurationHookFunc(),
+			numberToDurationHook,
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("config %s: can't create decoder. Err: %w", c.Method, err)
+	}
+	if err := decoder.Decode(c.Config); err != nil {
+		return fmt.Errorf("config %s: %s. Err: %w", c.Method, err.Error(), ErrBadConfigParams)
+	}
+	return nil
+}
+
+// stringToAddressHook decodes an address with or without 0x prefix
+func stringToAddressHook(from, to reflect.Type, data any) (any, error) {
+	if to != addressType || from.Kind() != reflect.String {
+		return data, nil
+	}
+	s, _ := data.(string)
+	if !common.IsHexAddress(s) {
+		return nil, fmt.Errorf("%q is not an address", s)
+	}
+	return common.HexToAddress(s), nil
+}
+
+// stringToScalarHook converts strings to numbers and bools (but not numbers to strings)
+func stringToScalarHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to == durationType {
+		return data, nil
+	}
+	s, _ := data.(string)
+	switch to.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(s, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(s, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(s, 64)
+	}
+	return data, nil
+}
+
+// numberToDurationHook interprets a number as seconds (as GetDuration). The hooks are
+// chained so a duration already parsed from a string arrives here as from durationType
+func numberToDurationHook(from, to reflect.Type, data any) (any, error) {
+	if to != durationType || from == durationType {
+		return data, nil
+	}
+	switch from.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Duration(reflect.ValueOf(data).Int()) * time.Second, nil
+	case reflect.Float32, reflect.Float64:
+		return time.Duration(reflect.ValueOf(data).Float() * float64(time.Second)), nil
+	}
+	return data, nil
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type decodeTestConfig struct {
+	Path    string         `mapstructure:"Path"`
+	Count   int            `mapstructure:"Count"`
+	Rate    float64        `mapstructure:"Rate"`
+	Enabled bool           `mapstructure:"Enabled"`
+	Timeout time.Duration  `mapstructure:"Timeout"`
+	Address common.Address `mapstructure:"Address"`
+}
+
+func TestDecode(t *testing.T) {
+	addr := common.HexToAddress("0x71C7656EC7ab88b098defB751B7401B5f6d8976F")
+	t.Run("keys are case-insensitive", func(t *testing.T) {
+		var res decodeTestConfig
+		cfg := SignerConfig{Method: MethodLocal, Config: map[string]any{"path": "/a", "PATH2": "x", "COUNT": 3}}
+		require.NoError(t, cfg.Decode(&res))
+		require.Equal(t, decodeTestConfig{Path: "/a", Count: 3}, res)
+	})
+	t.Run("weak typing from strings", func(t *testing.T) {
+		var res decodeTestConfig
+		cfg := SignerConfig{Method: MethodLocal, Config: map[string]any{
+			"Count":   "7",
+			"Rate":    "1.5",
+			"Enabled": "true",
+			"Timeout": "2s",
+			"Address": addr.Hex()[2:],
+		}}
+		require.NoError(t, cfg.Decode(&res))
+		require.Equal(t, decodeTestConfig{Count: 7, Rate: 1.5, Enabled: true, Timeout: 2 * time.Second, Address: addr}, res)
+	})
+	t.Run("duration as seconds", func(t *testing.T) {
+		var res decodeTestConfig
+		cfg := SignerConfig{Method: MethodLocal, Config: map[string]any{"Timeout": 3}}
+		require.NoError(t, cfg.Decode(&res))
+		require.Equal(t, 3*time.Second, res.Timeout)
+	})
+	t.Run("errors", func(t *testing.T) {
+		for _, config := range []map[string]any{
+			{"Path": 1234},
+			{"Count": "seven"},
+			{"Enabled": "maybe"},
+			{"Timeout": "2 parsecs"},
+			{"Address": "NOTHEXA"},
+		} {
+			var res decodeTestConfig
+			err := SignerConfig{Method: MethodLocal, Config: config}.Decode(&res)
+			require.ErrorIs(t, err, ErrBadConfigParams, "config %v", config)
+		}
+	})
+}
+
+func TestGetCaseInsensitive(t *testing.T) {
+	cfg := SignerConfig{Config: map[string]any{"KeyName": "key"}}
+	v, err := cfg.Get("keyname")
+	require.NoError(t, err)
+	require.Equal(t, "key", v)
+}
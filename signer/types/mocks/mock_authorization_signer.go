@@ -0,0 +1,95 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/ethereum/go-ethereum/core/types"
+)
+
+// AuthorizationSigner is an autogenerated mock type for the AuthorizationSigner type
+type AuthorizationSigner struct {
+	mock.Mock
+}
+
+type AuthorizationSigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthorizationSigner) EXPECT() *AuthorizationSigner_Expecter {
+	return &AuthorizationSigner_Expecter{mock: &_m.Mock}
+}
+
+// SignAuthorization provides a mock function with given fields: ctx, auth
+func (_m *AuthorizationSigner) SignAuthorization(ctx context.Context, auth types.SetCodeAuthorization) (types.SetCodeAuthorization, error) {
+	ret := _m.Called(ctx, auth)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignAuthorization")
+	}
+
+	var r0 types.SetCodeAuthorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.SetCodeAuthorization) (types.SetCodeAuthorization, error)); ok {
+		return rf(ctx, auth)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.SetCodeAuthorization) types.SetCodeAuthorization); ok {
+		r0 = rf(ctx, auth)
+	} else {
+		r0 = ret.Get(0).(types.SetCodeAuthorization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.SetCodeAuthorization) error); ok {
+		r1 = rf(ctx, auth)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthorizationSigner_SignAuthorization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignAuthorization'
+type AuthorizationSigner_SignAuthorization_Call struct {
+	*mock.Call
+}
+
+// SignAuthorization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - auth types.SetCodeAuthorization
+func (_e *AuthorizationSigner_Expecter) SignAuthorization(ctx interface{}, auth interface{}) *AuthorizationSigner_SignAuthorization_Call {
+	return &AuthorizationSigner_SignAuthorization_Call{Call: _e.mock.On("SignAuthorization", ctx, auth)}
+}
+
+func (_c *AuthorizationSigner_SignAuthorization_Call) Run(run func(ctx context.Context, auth types.SetCodeAuthorization)) *AuthorizationSigner_SignAuthorization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.SetCodeAuthorization))
+	})
+	return _c
+}
+
+func (_c *AuthorizationSigner_SignAuthorization_Call) Return(_a0 types.SetCodeAuthorization, _a1 error) *AuthorizationSigner_SignAuthorization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthorizationSigner_SignAuthorization_Call) RunAndReturn(run func(context.Context, types.SetCodeAuthorization) (types.SetCodeAuthorization, error)) *AuthorizationSigner_SignAuthorization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthorizationSigner creates a new instance of AuthorizationSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthorizationSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthorizationSigner {
+	mock := &AuthorizationSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,158 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BatchSigner is an autogenerated mock type for the BatchSigner type
+type BatchSigner struct {
+	mock.Mock
+}
+
+type BatchSigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BatchSigner) EXPECT() *BatchSigner_Expecter {
+	return &BatchSigner_Expecter{mock: &_m.Mock}
+}
+
+// SignHashes provides a mock function with given fields: ctx, hashes
+func (_m *BatchSigner) SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	ret := _m.Called(ctx, hashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignHashes")
+	}
+
+	var r0 [][]byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) ([][]byte, error)); ok {
+		return rf(ctx, hashes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) [][]byte); ok {
+		r0 = rf(ctx, hashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash) error); ok {
+		r1 = rf(ctx, hashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchSigner_SignHashes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignHashes'
+type BatchSigner_SignHashes_Call struct {
+	*mock.Call
+}
+
+// SignHashes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hashes []common.Hash
+func (_e *BatchSigner_Expecter) SignHashes(ctx interface{}, hashes interface{}) *BatchSigner_SignHashes_Call {
+	return &BatchSigner_SignHashes_Call{Call: _e.mock.On("SignHashes", ctx, hashes)}
+}
+
+func (_c *BatchSigner_SignHashes_Call) Run(run func(ctx context.Context, hashes []common.Hash)) *BatchSigner_SignHashes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]common.Hash))
+	})
+	return _c
+}
+
+func (_c *BatchSigner_SignHashes_Call) Return(_a0 [][]byte, _a1 error) *BatchSigner_SignHashes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BatchSigner_SignHashes_Call) RunAndReturn(run func(context.Context, []common.Hash) ([][]byte, error)) *BatchSigner_SignHashes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignTxs provides a mock function with given fields: ctx, txs
+func (_m *BatchSigner) SignTxs(ctx context.Context, txs []*coretypes.Transaction) ([]*coretypes.Transaction, error) {
+	ret := _m.Called(ctx, txs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignTxs")
+	}
+
+	var r0 []*coretypes.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*coretypes.Transaction) ([]*coretypes.Transaction, error)); ok {
+		return rf(ctx, txs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*coretypes.Transaction) []*coretypes.Transaction); ok {
+		r0 = rf(ctx, txs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*coretypes.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*coretypes.Transaction) error); ok {
+		r1 = rf(ctx, txs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchSigner_SignTxs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignTxs'
+type BatchSigner_SignTxs_Call struct {
+	*mock.Call
+}
+
+// SignTxs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txs []*coretypes.Transaction
+func (_e *BatchSigner_Expecter) SignTxs(ctx interface{}, txs interface{}) *BatchSigner_SignTxs_Call {
+	return &BatchSigner_SignTxs_Call{Call: _e.mock.On("SignTxs", ctx, txs)}
+}
+
+func (_c *BatchSigner_SignTxs_Call) Run(run func(ctx context.Context, txs []*coretypes.Transaction)) *BatchSigner_SignTxs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*coretypes.Transaction))
+	})
+	return _c
+}
+
+func (_c *BatchSigner_SignTxs_Call) Return(_a0 []*coretypes.Transaction, _a1 error) *BatchSigner_SignTxs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BatchSigner_SignTxs_Call) RunAndReturn(run func(context.Context, []*coretypes.Transaction) ([]*coretypes.Transaction, error)) *BatchSigner_SignTxs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBatchSigner creates a new instance of BatchSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBatchSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BatchSigner {
+	mock := &BatchSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
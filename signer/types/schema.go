@@ -0,0 +1,339 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrUnknownConfigParam = fmt.Errorf("unknown config parameter")
+	ErrUnknownMethod      = fmt.Errorf("unknown signer method")
+)
+
+// FieldType is the type of the value of a config field
+type FieldType string
+
+const (
+	FieldTypeString   FieldType = "string"
+	FieldTypeInt      FieldType = "integer"
+	FieldTypeFloat    FieldType = "number"
+	FieldTypeDuration FieldType = "duration"
+	FieldTypeAddress  FieldType = "address"
+	FieldTypeBool     FieldType = "boolean"
+	// FieldTypeStringMap is a map of string to string (e.g. address -> password)
+	FieldTypeStringMap FieldType = "map"
+	// FieldTypeStringList is a list of strings
+	FieldTypeStringList FieldType = "list"
+)
+
+// FieldSchema describes a config field of a method
+type FieldSchema struct {
+	Name        string
+	Type        FieldType
+	Required    bool
+	Description string
+	// Check is an optional extra validation of the value (already checked against Type)
+	Check func(value any) error
+}
+
+// MethodSchema describes the config fields of a SignMethod
+type MethodSchema struct {
+	Method      SignMethod
+	Description string
+	Fields      []FieldSchema
+	// Check is an optional validation of the whole config (e.g. fields that exclude each other)
+	Check func(cfg SignerConfig) []error
+}
+
+var (
+	schemasMu sync.RWMutex
+	schemas   = map[SignMethod]MethodSchema{}
+)
+
+// commonFields are the fields accepted by all methods
+var commonFields = []FieldSchema{
+	{Name: FieldBatchConcurrency, Type: FieldTypeInt, Description: "max parallel calls on SignHashes / SignTxs"},
+	{Name: FieldBatchRateLimit, Type: FieldTypeFloat, Description: "max calls per second on SignHashes / SignTxs"},
+}
+
+// RegisterSchema sets the schema of a method, used by SignerConfig.Validate. The schemas of the
+// methods of go_signer are registered by package signer
+func RegisterSchema(schema MethodSchema) {
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+	schemas[schema.Method] = schema
+}
+
+// Schema returns the schema of method
+func Schema(method SignMethod) (MethodSchema, bool) {
+	schemasMu.RLock()
+	defer schemasMu.RUnlock()
+	schema, ok := schemas[method]
+	return schema, ok
+}
+
+// Schemas returns the schemas of all the methods sorted by method
+func Schemas() []MethodSchema {
+	schemasMu.RLock()
+	defer schemasMu.RUnlock()
+	res := make([]MethodSchema, 0, len(schemas))
+	for _, schema := range schemas {
+		res = append(res, schema)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Method < res[j].Method })
+	return res
+}
+
+// Validate checks the config against the registered schema of its method (see MethodSchema.Validate).
+// An empty Method is local (as NewSigner does)
+func (c SignerConfig) Validate() error {
+	method := c.Method
+	if method == "" {
+		method = MethodLocal
+	}
+	schema, ok := Schema(method)
+	if !ok {
+		return fmt.Errorf("config: method %q. Err: %w", method, ErrUnknownMethod)
+	}
+	return schema.Validate(c)
+}
+
+// allFields returns the fields of the method plus the common ones
+func (s MethodSchema) allFields() []FieldSchema {
+	return append(append([]FieldSchema{}, s.Fields...), commonFields...)
+}
+
+// Validate checks c against the schema and returns all the problems found (errors.Join):
+// unknown keys, wrong types, missing required fields and invalid values. An empty Config is
+// valid, the method uses its defaults (e.g. an empty local config)
+func (s MethodSchema) Validate(c SignerConfig) error {
+	if len(c.Config) == 0 {
+		return nil
+	}
+	method := s.Method
+	fields := s.allFields()
+	var errs []error
+	for key := range c.Config {
+		if findField(fields, key) == nil {
+			errs = append(errs, fmt.Errorf("config %s: field %s%s. Err: %w",
+				method, key, suggestField(fields, key), ErrUnknownConfigParam))
+		}
+	}
+	for _, field := range fields {
+		value, err := c.lookup(field.Name)
+		if err != nil {
+			if field.Required {
+				errs = append(errs, fmt.Errorf("config %s: field %s is required. Err: %w",
+					method, field.Name, ErrMissingConfigParam))
+			}
+			continue
+		}
+		if err := field.check(c, value); err != nil {
+			errs = append(errs, fmt.Errorf("config %s: field %s: %w", method, field.Name, err))
+		}
+	}
+	if s.Check != nil {
+		errs = append(errs, s.Check(c)...)
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
+}
+
+func (f FieldSchema) check(cfg SignerConfig, value any) error {
+	var err error
+	switch f.Type {
+	case FieldTypeString:
+		err = checkType[string](value)
+	case FieldTypeAddress:
+		if err = checkType[string](value); err == nil && !common.IsHexAddress(value.(string)) {
+			err = fmt.Errorf("%q is not an address. Err: %w", value, ErrBadConfigParams)
+		}
+	case FieldTypeInt:
+		_, err = (SignerConfig{Config: map[string]any{f.Name: value}}).GetInt(f.Name)
+	case FieldTypeFloat:
+		_, err = (SignerConfig{Config: map[string]any{f.Name: value}}).GetFloat(f.Name)
+	case FieldTypeDuration:
+		_, err = (SignerConfig{Config: map[string]any{f.Name: value}}).GetDuration(f.Name)
+	case FieldTypeBool:
+		err = checkBool(value)
+	case FieldTypeStringMap:
+		err = checkStringMap(value)
+	case FieldTypeStringList:
+		err = checkStringList(value)
+	}
+	if err != nil {
+		return err
+	}
+	if f.Check != nil {
+		return f.Check(value)
+	}
+	return nil
+}
+
+func checkType[T any](value any) error {
+	if _, ok := value.(T); !ok {
+		var zero T
+		return fmt.Errorf("must be %T, found %T. Err: %w", zero, value, ErrBadConfigParams)
+	}
+	return nil
+}
+
+// checkBool accepts a bool or a string with a bool (e.g. from env vars)
+func checkBool(value any) error {
+	if s, ok := value.(string); ok {
+		if _, err := strconv.ParseBool(s); err != nil {
+			return fmt.Errorf("%q is not a bool. Err: %w", s, ErrBadConfigParams)
+		}
+		return nil
+	}
+	return checkType[bool](value)
+}
+
+func checkStringMap(value any) error {
+	switch m := value.(type) {
+	case map[string]string:
+		return nil
+	case map[string]any:
+		for key, v := range m {
+			if _, ok := v.(string); !ok {
+				return fmt.Errorf("value of %s must be string, found %T. Err: %w", key, v, ErrBadConfigParams)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("must be a map of strings, found %T. Err: %w", value, ErrBadConfigParams)
+}
+
+func checkStringList(value any) error {
+	switch l := value.(type) {
+	case []string:
+		return nil
+	case []any:
+		for i, v := range l {
+			if _, ok := v.(string); !ok {
+				return fmt.Errorf("item %d must be string, found %T. Err: %w", i, v, ErrBadConfigParams)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("must be a list of strings, found %T. Err: %w", value, ErrBadConfigParams)
+}
+
+func findField(fields []FieldSchema, key string) *FieldSchema {
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, key) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// maxSuggestionDistance is the max edit distance to suggest a field for an unknown key
+const maxSuggestionDistance = 2
+
+// suggestField returns " (did you mean X?)" if there is a field similar to key
+func suggestField(fields []FieldSchema, key string) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, field := range fields {
+		if d := editDistance(strings.ToLower(field.Name), strings.ToLower(key)); d < bestDistance {
+			best, bestDistance = field.Name, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %s?)", best)
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+// JSONSchema returns the JSON Schema (draft-07) of SignerConfig with the schemas of methods.
+// The config keys are case-insensitive, the schema uses the canonical names
+func JSONSchema(methods []MethodSchema) ([]byte, error) {
+	oneOf := make([]map[string]any, 0, len(methods))
+	enum := make([]string, 0, len(methods))
+	for _, schema := range methods {
+		enum = append(enum, schema.Method.String())
+		properties := map[string]any{
+			"Method": map[string]any{"const": schema.Method.String()},
+		}
+		required := []string{"Method"}
+		for _, field := range schema.allFields() {
+			properties[field.Name] = field.jsonSchema()
+			if field.Required {
+				required = append(required, field.Name)
+			}
+		}
+		oneOf = append(oneOf, map[string]any{
+			"title":                schema.Method.String(),
+			"description":          schema.Description,
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		})
+	}
+	return json.MarshalIndent(map[string]any{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "SignerConfig",
+		"description": "Configuration of a signer, the fields depend on Method",
+		"type":        "object",
+		"properties": map[string]any{
+			"Method": map[string]any{"type": "string", "enum": enum},
+		},
+		"oneOf": oneOf,
+	}, "", "  ")
+}
+
+func (f FieldSchema) jsonSchema() map[string]any {
+	res := map[string]any{"description": f.Description}
+	switch f.Type {
+	case FieldTypeInt:
+		res["type"] = "integer"
+	case FieldTypeFloat:
+		res["type"] = "number"
+	case FieldTypeBool:
+		res["type"] = "boolean"
+	case FieldTypeDuration:
+		res["type"] = "string"
+		res["format"] = "duration"
+	case FieldTypeAddress:
+		res["type"] = "string"
+		res["pattern"] = "^(0x)?[0-9a-fA-F]{40}$"
+	case FieldTypeStringMap:
+		res["type"] = "object"
+		res["additionalProperties"] = map[string]any{"type": "string"}
+	case FieldTypeStringList:
+		res["type"] = "array"
+		res["items"] = map[string]any{"type": "string"}
+	default:
+		res["type"] = "string"
+	}
+	return res
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditDistance(t *testing.T) {
+	require.Equal(t, 0, editDistance("password", "password"))
+	require.Equal(t, 1, editDistance("password", "pasword"))
+	require.Equal(t, 2, editDistance("url", "uri2"))
+	require.Equal(t, 3, editDistance("", "abc"))
+}
+
+func TestMethodSchemaValidate(t *testing.T) {
+	method := SignMethod("test-schema")
+	schema := MethodSchema{
+		Method: method,
+		Fields: []FieldSchema{
+			{Name: "Endpoint", Type: FieldTypeString, Required: true},
+			{Name: "Timeout", Type: FieldTypeDuration},
+			{Name: "Passwords", Type: FieldTypeStringMap},
+			{Name: "Files", Type: FieldTypeStringList},
+		},
+	}
+	require.NoError(t, schema.Validate(SignerConfig{Method: method, Config: map[string]any{
+		"endpoint":  "x",
+		"timeout":   "1s",
+		"passwords": map[string]any{"0x1": "a"},
+		"files":     []any{"a.json", "b.json"},
+	}}))
+	err := schema.Validate(SignerConfig{Method: method, Config: map[string]any{
+		"Endpont":   "x",
+		"Timeout":   "soon",
+		"Passwords": map[string]any{"0x1": 1},
+	}})
+	require.ErrorIs(t, err, ErrUnknownConfigParam)
+	require.ErrorIs(t, err, ErrMissingConfigParam)
+	require.ErrorIs(t, err, ErrBadConfigParams)
+	require.ErrorContains(t, err, "did you mean Endpoint?")
+	err = schema.Validate(SignerConfig{Method: method, Config: map[string]any{
+		"Endpoint": "x",
+		"Files":    []any{"a.json", 2},
+	}})
+	require.ErrorIs(t, err, ErrBadConfigParams)
+	require.ErrorContains(t, err, "item 1 must be string")
+	// An empty config uses the defaults of the method
+	require.NoError(t, schema.Validate(SignerConfig{Method: method}))
+}
+
+func TestSignerConfigValidate(t *testing.T) {
+	method := SignMethod("test-registered")
+	RegisterSchema(MethodSchema{
+		Method: method,
+		Fields: []FieldSchema{{Name: "Endpoint", Type: FieldTypeString, Required: true}},
+	})
+	schema, ok := Schema(method)
+	require.True(t, ok)
+	require.Equal(t, method, schema.Method)
+	require.Contains(t, Schemas(), schema)
+
+	require.NoError(t, SignerConfig{Method: method, Config: map[string]any{"endpoint": "x"}}.Validate())
+	err := SignerConfig{Method: method, Config: map[string]any{"Timeout": "1s"}}.Validate()
+	require.ErrorIs(t, err, ErrUnknownConfigParam)
+	require.ErrorIs(t, err, ErrMissingConfigParam)
+	err = SignerConfig{Method: "test-unregistered"}.Validate()
+	require.ErrorIs(t, err, ErrUnknownMethod)
+}
@@ -23,9 +23,14 @@ type Signer interface {
 	PublicAddress() common.Address
 	// String returns a string representation of the signer (no secrets)
 	String() string
+	// Close releases the resources of the signer (e.g. connections) and wipes the key material
+	// it holds. The signer can't be used after it. Calling it again does nothing
+	Close() error
 
 	HashSigner
 	TxSigner
+	BatchSigner
+	AuthorizationSigner
 }
 
 type HashSigner interface {
@@ -36,4 +41,16 @@ type HashSigner interface {
 type TxSigner interface {
 	// SignTx signs the hash using the private key
 	SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error)
+	// SignTxForChain signs tx for chainID, so a signer can sign for several chains. If chainID
+	// is 0 it uses the chain ID of the signer or, if it's not set, the one of the typed tx
+	SignTxForChain(ctx context.Context, chainID uint64, tx *types.Transaction) (*types.Transaction, error)
+}
+
+type BatchSigner interface {
+	// SignHashes signs a batch of hashes. The result has the same order as the input.
+	// If some item fails it returns a *BatchError and the result have nil on that positions
+	SignHashes(ctx context.Context, hashes []common.Hash) ([][]byte, error)
+	// SignTxs signs a batch of txs. The result has the same order as the input.
+	// If some item fails it returns a *BatchError and the result have nil on that positions
+	SignTxs(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error)
 }
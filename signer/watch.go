@@ -0,0 +1,297 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	signercommon "github.com/agglayer/go_signer/common"
+	"github.com/agglayer/go_signer/signer/types"
+	"github.com/fsnotify/fsnotify"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+const (
+	// WatchConfigKey is the key of the config file with the signers (name -> SignerConfig)
+	WatchConfigKey = "Signers"
+	// watchDebounce groups the file events of a write (or a k8s symlink swap) in one reload
+	watchDebounce = 100 * time.Millisecond
+)
+
+// Watcher keeps the signers defined in a config file up to date. Each signer has a stable
+// handle (a RotatingSign) and only the signers whose config changes are rebuilt. The file
+// is reloaded when it changes (it watches the directory, so a k8s mounted file is supported)
+// or, if ReloadOnSIGHUP is called, when the process receives SIGHUP
+type Watcher struct {
+	// reloadMu serializes the reloads, mu protects signers
+	reloadMu sync.Mutex
+	mu       sync.Mutex
+	path     string
+	chainID  uint64
+	logger   signercommon.Logger
+	opts     []SignerOption
+	signers  map[string]*watchedSigner
+
+	fsWatcher *fsnotify.Watcher
+	sighup    chan os.Signal
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+type watchedSigner struct {
+	cfg  types.SignerConfig
+	sign *RotatingSign
+}
+
+// watchedOld is the old key of a handle, replaced on a reload
+type watchedOld struct {
+	sign  *RotatingSign
+	entry *rotatingEntry
+}
+
+// Watch loads the signers of the config file path (key Signers) and watches it for changes.
+// opts are passed to NewSigner for each signer. It stops when ctx is done or calling Close.
+// The names of the signers are lowercase (as viper keys)
+func Watch(ctx context.Context, path string, chainID uint64, logger signercommon.Logger,
+	opts ...SignerOption) (*Watcher, error) {
+	res := &Watcher{
+		path:    filepath.Clean(path),
+		chainID: chainID,
+		logger:  logger,
+		opts:    opts,
+		signers: map[string]*watchedSigner{},
+		sighup:  make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+	}
+	if err := res.Reload(ctx); err != nil {
+		return nil, err
+	}
+	// The signers are already built, so they are closed if the file can't be watched
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("signer watch: can't create file watcher. Err: %w", err),
+			res.closeSigners())
+	}
+	if err := fsWatcher.Add(filepath.Dir(res.path)); err != nil {
+		return nil, errors.Join(fmt.Errorf("signer watch: can't watch %s. Err: %w", res.path, err),
+			fsWatcher.Close(), res.closeSigners())
+	}
+	res.fsWatcher = fsWatcher
+	ctx, res.cancel = context.WithCancel(ctx)
+	go res.run(ctx)
+	return res, nil
+}
+
+// ReloadOnSIGHUP also reloads the config file when the process receives SIGHUP. It's opt-in
+// because the signal handler is process-wide: the application decides who handles SIGHUP
+func (w *Watcher) ReloadOnSIGHUP() {
+	signal.Notify(w.sighup, syscall.SIGHUP)
+}
+
+// Signer returns the handle of the signer called name. It's the same after each reload
+func (w *Watcher) Signer(name string) (types.Signer, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	watched, ok := w.signers[name]
+	if !ok {
+		return nil, fmt.Errorf("signer watch: name %s. Err: %w", name, ErrSignerNotFound)
+	}
+	return watched.sign, nil
+}
+
+// Names returns the names of the signers
+func (w *Watcher) Names() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	res := make([]string, 0, len(w.signers))
+	for name := range w.signers {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Reload reads the config file and rebuilds the signers whose config has changed. It's all or
+// nothing: all the new signers are created and initialized before switching to them, if one
+// fails they are closed and the running signers are kept
+func (w *Watcher) Reload(ctx context.Context) error {
+	cfgs, err := w.readConfig()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for name, cfg := range cfgs {
+		if err := ValidateConfig(cfg); err != nil {
+			errs = append(errs, fmt.Errorf("signer watch: signer %s of %s. Err: %w", name, w.path, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+	built := map[string]types.Signer{}
+	for name, cfg := range cfgs {
+		w.mu.Lock()
+		watched, ok := w.signers[name]
+		w.mu.Unlock()
+		if ok && reflect.DeepEqual(watched.cfg, cfg) {
+			continue
+		}
+		newSigner, err := w.build(ctx, name, cfg)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		built[name] = newSigner
+	}
+	if len(errs) > 0 {
+		for _, newSigner := range built {
+			_ = newSigner.Close()
+		}
+		return errors.Join(errs...)
+	}
+	w.apply(ctx, cfgs, built)
+	for _, name := range w.Names() {
+		if _, ok := cfgs[name]; !ok {
+			w.logger.Warnf("signer watch: signer %s has been removed from %s, keeping the running one", name, w.path)
+		}
+	}
+	return nil
+}
+
+// build creates and initializes the signer of cfg
+func (w *Watcher) build(ctx context.Context, name string, cfg types.SignerConfig) (types.Signer, error) {
+	newSigner, err := NewSigner(ctx, w.chainID, cfg, name, w.logger, w.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("signer watch: can't create signer %s. Err: %w", name, err)
+	}
+	if err := newSigner.Initialize(ctx); err != nil {
+		_ = newSigner.Close()
+		return nil, fmt.Errorf("signer watch: can't initialize signer %s. Err: %w", name, err)
+	}
+	return newSigner, nil
+}
+
+// apply switches all the handles to the built signers (or creates the handles of the new ones)
+// and then waits for the calls in-flight on the old keys. Must be called with reloadMu locked
+func (w *Watcher) apply(ctx context.Context, cfgs map[string]types.SignerConfig, built map[string]types.Signer) {
+	var olds []watchedOld
+	w.mu.Lock()
+	for name, newSigner := range built {
+		watched, ok := w.signers[name]
+		if !ok {
+			w.signers[name] = &watchedSigner{
+				cfg:  cfgs[name],
+				sign: NewRotatingSign(name, w.logger, newSigner, DefaultRotationGracePeriod),
+			}
+			continue
+		}
+		olds = append(olds, watchedOld{sign: watched.sign, entry: watched.sign.swap(newSigner)})
+		watched.cfg = cfgs[name]
+		w.logger.Infof("signer watch: signer %s reloaded from %s", name, w.path)
+	}
+	w.mu.Unlock()
+	var wg sync.WaitGroup
+	for _, old := range olds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			old.sign.waitInFlight(ctx, old.entry)
+		}()
+	}
+	wg.Wait()
+}
+
+func (w *Watcher) readConfig() (map[string]types.SignerConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(w.path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("signer watch: can't read %s. Err: %w", w.path, err)
+	}
+	var cfgs map[string]types.SignerConfig
+	decodeHook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.TextUnmarshallerHookFunc(), mapstructure.StringToSliceHookFunc(",")))
+	if err := v.UnmarshalKey(WatchConfigKey, &cfgs, decodeHook); err != nil {
+		return nil, fmt.Errorf("signer watch: can't decode %s of %s. Err: %w", WatchConfigKey, w.path, err)
+	}
+	if len(cfgs) == 0 {
+		return nil, fmt.Errorf("signer watch: no signers on %s (key %s). Err: %w",
+			w.path, WatchConfigKey, types.ErrMissingConfigParam)
+	}
+	return cfgs, nil
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer close(w.done)
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if w.isConfigEvent(ev) {
+				debounce.Reset(watchDebounce)
+			}
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Errorf("signer watch: error watching %s. Err: %v", w.path, err)
+		case <-w.sighup:
+			w.logger.Infof("signer watch: SIGHUP received, reloading %s", w.path)
+			w.reload(ctx)
+		case <-debounce.C:
+			w.reload(ctx)
+		}
+	}
+}
+
+// isConfigEvent returns true if the event can change the content of the config file. For
+// a k8s mounted file the events are on the ..data symlink, so any create/remove is considered
+func (w *Watcher) isConfigEvent(ev fsnotify.Event) bool {
+	if filepath.Clean(ev.Name) == w.path {
+		return ev.Has(fsnotify.Write) || ev.Has(fsnotify.Create) || ev.Has(fsnotify.Rename)
+	}
+	return ev.Has(fsnotify.Create) || ev.Has(fsnotify.Remove)
+}
+
+func (w *Watcher) reload(ctx context.Context) {
+	if err := w.Reload(ctx); err != nil {
+		w.logger.Errorf("signer watch: config rejected, running signers are kept. Err: %v", err)
+	}
+}
+
+// Close stops watching the config file and closes the signers, their handles can't be used after it
+func (w *Watcher) Close() error {
+	signal.Stop(w.sighup)
+	w.cancel()
+	<-w.done
+	return errors.Join(w.fsWatcher.Close(), w.closeSigners())
+}
+
+// closeSigners closes the handles of all the signers
+func (w *Watcher) closeSigners() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var errs []error
+	for name, watched := range w.signers {
+		if err := watched.sign.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("signer watch: can't close signer %s. Err: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
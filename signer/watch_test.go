@@ -0,0 +1,102 @@
+package signer
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agglayer/go_signer/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWatchKey(t *testing.T) (string, common.Address) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return hex.EncodeToString(crypto.FromECDSA(key)), crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func writeTestWatchConfig(t *testing.T, path, key1, key2 string) {
+	t.Helper()
+	content := fmt.Sprintf(`
+[Signers.wallet1]
+Method = "mock"
+PrivateKey = "%s"
+
+[Signers.wallet2]
+Method = "mock"
+PrivateKey = "%s"
+`, key1, key2)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestWatchReload(t *testing.T) {
+	ctx := context.TODO()
+	path := filepath.Join(t.TempDir(), "signers.toml")
+	key1, addr1 := newTestWatchKey(t)
+	key2, addr2 := newTestWatchKey(t)
+	writeTestWatchConfig(t, path, key1, key2)
+
+	sut, err := Watch(ctx, path, 1, log.WithFields("test", "test"))
+	require.NoError(t, err)
+	defer sut.Close()
+	require.Equal(t, []string{"wallet1", "wallet2"}, sut.Names())
+	wallet1, err := sut.Signer("wallet1")
+	require.NoError(t, err)
+	wallet2, err := sut.Signer("wallet2")
+	require.NoError(t, err)
+	require.Equal(t, addr1, wallet1.PublicAddress())
+	require.Equal(t, addr2, wallet2.PublicAddress())
+	handle2 := wallet2.(*RotatingSign).Unwrap()
+
+	// Only wallet1 changes
+	key3, addr3 := newTestWatchKey(t)
+	writeTestWatchConfig(t, path, key3, key2)
+	require.NoError(t, sut.Reload(ctx))
+	require.Equal(t, addr3, wallet1.PublicAddress())
+	require.Same(t, handle2, wallet2.(*RotatingSign).Unwrap())
+
+	// A bad config is rejected
+	require.NoError(t, os.WriteFile(path, []byte("[Signers"), 0o600))
+	require.Error(t, sut.Reload(ctx))
+	writeTestWatchConfig(t, path, "not a key", key2)
+	require.Error(t, sut.Reload(ctx))
+	require.Equal(t, addr3, wallet1.PublicAddress())
+
+	// A config that doesn't match the schema is rejected before building any signer
+	require.NoError(t, os.WriteFile(path, []byte("[Signers.wallet1]\nMethod = \"mock\"\nPrivatKey = \"x\"\n"), 0o600))
+	require.ErrorIs(t, sut.Reload(ctx), signertypes.ErrUnknownConfigParam)
+
+	// All or nothing: wallet2 is valid but is not rotated because wallet1 fails
+	key4, _ := newTestWatchKey(t)
+	writeTestWatchConfig(t, path, "not a key", key4)
+	require.Error(t, sut.Reload(ctx))
+	require.Equal(t, addr3, wallet1.PublicAddress())
+	require.Equal(t, addr2, wallet2.PublicAddress())
+	require.Same(t, handle2, wallet2.(*RotatingSign).Unwrap())
+}
+
+func TestWatchFileChange(t *testing.T) {
+	ctx := context.TODO()
+	path := filepath.Join(t.TempDir(), "signers.toml")
+	key1, _ := newTestWatchKey(t)
+	key2, addr2 := newTestWatchKey(t)
+	writeTestWatchConfig(t, path, key1, key2)
+	sut, err := Watch(ctx, path, 1, log.WithFields("test", "test"))
+	require.NoError(t, err)
+	defer sut.Close()
+	wallet1, err := sut.Signer("wallet1")
+	require.NoError(t, err)
+
+	writeTestWatchConfig(t, path, key2, key2)
+	require.Eventually(t, func() bool {
+		return wallet1.PublicAddress() == addr2
+	}, 5*time.Second, 50*time.Millisecond)
+}